@@ -480,13 +480,57 @@ type SessionProviderMeta struct {
 	// Meta          map[string]interface{} `json:"meta"`
 }
 
+// Events fired by the gateway which can trigger event handlers.
+const (
+	EventQuotaExceeded     TykEvent = "QuotaExceeded"
+	EventRateLimitExceeded TykEvent = "RatelimitExceeded"
+	EventAuthFailure       TykEvent = "AuthFailure"
+	EventKeyExpired        TykEvent = "KeyExpired"
+	EventVersionFailure    TykEvent = "VersionFailure"
+	EventBreakerTriggered  TykEvent = "BreakerTriggered"
+	EventHostDown          TykEvent = "HostDown"
+	EventHostUp            TykEvent = "HostUp"
+)
+
+// WebHookHandler is the name of the event handler calling a webhook.
+const WebHookHandler TykEventHandlerName = "eh_web_hook_handler"
+
 type EventHandlerTriggerConfig struct {
-	Handler TykEventHandlerName `json:"handler_name"`
-	// HandlerMeta map[string]interface{} `json:"handler_meta"`
+	Handler     TykEventHandlerName `json:"handler_name"`
+	HandlerMeta WebHookHandlerConf  `json:"handler_meta"`
 }
 
+// EventHandlerTriggerConfigs are the handlers called when an event is fired.
+type EventHandlerTriggerConfigs []EventHandlerTriggerConfig
+
 type EventHandlerMetaConfig struct {
-	Events map[TykEvent][]EventHandlerTriggerConfig `json:"events"`
+	Events map[TykEvent]EventHandlerTriggerConfigs `json:"events"`
+}
+
+// WebHookHandlerConf configures a webhook called when an event is fired. On the
+// dashboard, webhooks are stored on their own and referenced by ID.
+type WebHookHandlerConf struct {
+	// ID of the webhook on the dashboard.
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Disabled stops the webhook from being called.
+	Disabled bool   `json:"disabled,omitempty"`
+	Method   string `json:"method"`
+
+	// TargetPath is the URL of the webhook.
+	TargetPath string `json:"target_path"`
+
+	// TemplatePath is the path on the gateway of the template rendering the
+	// body of the request. The default template of the gateway is used when
+	// empty.
+	TemplatePath string `json:"template_path,omitempty"`
+
+	HeaderMap map[string]string `json:"header_map,omitempty"`
+
+	// EventTimeout is the number of seconds during which the same event does
+	// not call the webhook again.
+	EventTimeout int64 `json:"event_timeout,omitempty"`
 }
 
 type MiddlewareDefinition struct {
@@ -788,7 +832,12 @@ type APIDefinitionSpec struct {
 	Internal *bool `json:"internal,omitempty"`
 	//AuthProvider           AuthProviderMeta    `json:"auth_provider"`
 	//SessionProvider        SessionProviderMeta `json:"session_provider"`
-	////EventHandlers             EventHandlerMetaConfig `json:"event_handlers"`
+
+	// EventHandlers are called when the events they are set for are fired. The
+	// webhooks of the ApiEventWebhook resources referenced by eventWebhookRefs
+	// are added to them.
+	EventHandlers *EventHandlerMetaConfig `json:"event_handlers,omitempty"`
+
 	//EnableBatchRequestSupport bool `json:"enable_batch_request_support"`
 
 	// EnableIPWhiteListing activates the ip whitelisting middleware.
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// CertificateMeta describes a certificate stored on the Tyk Gateway or
+// Dashboard, as listed by their detailed certificate endpoints.
+// +kubebuilder:object:generate=false
+type CertificateMeta struct {
+	ID            string    `json:"id"`
+	Fingerprint   string    `json:"fingerprint,omitempty"`
+	IssuerCN      string    `json:"issuer_cn"`
+	SubjectCN     string    `json:"subject_cn"`
+	DNSNames      []string  `json:"dns_names"`
+	HasPrivateKey bool      `json:"has_private"`
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	IsCA          bool      `json:"is_ca"`
+}
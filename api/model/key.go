@@ -0,0 +1,36 @@
+package model
+
+// SessionState is a Tyk key, the credentials of an API consumer. It grants
+// access to the APIs of its access rights and of the policies it applies.
+type SessionState struct {
+	OrgID string `json:"org_id,omitempty"`
+
+	// Alias is a human readable name of the key, recorded in the analytics.
+	Alias string `json:"alias,omitempty"`
+
+	// ApplyPolicies lists the ids of the policies applied to the key.
+	ApplyPolicies []string `json:"apply_policies,omitempty"`
+
+	// AccessRights are keyed by api_id.
+	AccessRights map[string]AccessDefinition `json:"access_rights,omitempty"`
+
+	// Expires is the unix timestamp the key expires at, 0 if it never expires.
+	Expires int64 `json:"expires"`
+
+	// Rate limit per X seconds (x="Per"), omit or "-1" for unlimited
+	Rate int64 `json:"rate,omitempty"`
+
+	// To be used in conjunction with "Rate".  Per seconds. 1 minute=60.  1 hour=3600
+	// omit or "-1" for unlimited
+	Per int64 `json:"per,omitempty"`
+
+	// Value of Quota allowed, omit or "-1" for unlimited
+	QuotaMax int64 `json:"quota_max,omitempty"`
+
+	// Value reset length, in seconds, omit or "-1" for unlimited
+	QuotaRenewalRate int64 `json:"quota_renewal_rate,omitempty"`
+
+	IsInactive bool              `json:"is_inactive,omitempty"`
+	Tags       []string          `json:"tags,omitempty"`
+	MetaData   map[string]string `json:"meta_data,omitempty"`
+}
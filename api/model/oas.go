@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// TykOASExtension is the key of the Tyk settings in a Tyk OAS API definition.
+const TykOASExtension = "x-tyk-api-gateway"
+
+// ErrMissingTykOASExtension is returned when parsing an OAS document without the
+// x-tyk-api-gateway extension.
+var ErrMissingTykOASExtension = errors.New("OAS document has no " + TykOASExtension + " extension")
+
+// TykOAS is an OpenAPI 3 document holding the x-tyk-api-gateway extension.
+//
+// Only the fields of the extension managed by the operator are accessed, the
+// rest of the document is sent to Tyk untouched.
+// +kubebuilder:object:generate=false
+type TykOAS map[string]interface{}
+
+// ParseTykOAS parses a Tyk OAS API definition in JSON or YAML.
+func ParseTykOAS(b []byte) (TykOAS, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var o TykOAS
+	if err := json.Unmarshal(j, &o); err != nil {
+		return nil, err
+	}
+
+	if _, ok := o[TykOASExtension].(map[string]interface{}); !ok {
+		return nil, ErrMissingTykOASExtension
+	}
+
+	return o, nil
+}
+
+// object returns the object found following keys from the extension, creating
+// the missing ones.
+func (o TykOAS) object(keys ...string) map[string]interface{} {
+	m, ok := o[TykOASExtension].(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+		o[TykOASExtension] = m
+	}
+
+	for _, k := range keys {
+		v, ok := m[k].(map[string]interface{})
+		if !ok {
+			v = make(map[string]interface{})
+			m[k] = v
+		}
+
+		m = v
+	}
+
+	return m
+}
+
+// lookup returns the value found following keys from the extension, nil if
+// there is none.
+func (o TykOAS) lookup(keys ...string) interface{} {
+	var v interface{} = o[TykOASExtension]
+
+	for _, k := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		v = m[k]
+	}
+
+	return v
+}
+
+func (o TykOAS) lookupString(keys ...string) string {
+	s, _ := o.lookup(keys...).(string)
+	return s
+}
+
+// ID returns info.id, the API ID of the definition.
+func (o TykOAS) ID() string {
+	return o.lookupString("info", "id")
+}
+
+// SetID sets info.id.
+func (o TykOAS) SetID(id string) {
+	o.object("info")["id"] = id
+}
+
+// OrgID returns info.orgId.
+func (o TykOAS) OrgID() string {
+	return o.lookupString("info", "orgId")
+}
+
+// SetOrgID sets info.orgId.
+func (o TykOAS) SetOrgID(id string) {
+	o.object("info")["orgId"] = id
+}
+
+// DBID returns info.dbId, the id of the API in the dashboard database.
+func (o TykOAS) DBID() string {
+	return o.lookupString("info", "dbId")
+}
+
+// SetDBID sets info.dbId.
+func (o TykOAS) SetDBID(id string) {
+	o.object("info")["dbId"] = id
+}
+
+// Active returns info.state.active.
+func (o TykOAS) Active() bool {
+	b, _ := o.lookup("info", "state", "active").(bool)
+	return b
+}
+
+// ListenPath returns server.listenPath.value.
+func (o TykOAS) ListenPath() string {
+	return o.lookupString("server", "listenPath", "value")
+}
+
+// TargetURL returns upstream.url.
+func (o TykOAS) TargetURL() string {
+	return o.lookupString("upstream", "url")
+}
+
+// Domain returns server.customDomain.name.
+func (o TykOAS) Domain() string {
+	return o.lookupString("server", "customDomain", "name")
+}
+
+// SetCertificates sets the certificates served for the custom domain,
+// server.customDomain.certificates.
+func (o TykOAS) SetCertificates(ids []string) {
+	o.object("server", "customDomain")["certificates"] = ids
+}
+
+// SetClientCertificates sets the client certificates allowed when mutual TLS
+// is enabled, server.clientCertificates.allowlist.
+func (o TykOAS) SetClientCertificates(ids []string) {
+	o.object("server", "clientCertificates")["allowlist"] = ids
+}
+
+// SetUpstreamCertificates sets the certificates presented to the upstream
+// domains, upstream.mutualTLS.domainToCertificateMapping.
+func (o TykOAS) SetUpstreamCertificates(domains map[string]string) {
+	mapping := make([]interface{}, 0, len(domains))
+
+	for _, domain := range sortedKeys(domains) {
+		mapping = append(mapping, map[string]interface{}{
+			"domain":      domain,
+			"certificate": domains[domain],
+		})
+	}
+
+	o.object("upstream", "mutualTLS")["domainToCertificateMapping"] = mapping
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTykOAS(t *testing.T) {
+	docs := map[string]string{
+		"json": `{
+			"openapi": "3.0.3",
+			"x-tyk-api-gateway": {
+				"info": {"id": "httpbin", "state": {"active": true}},
+				"server": {"listenPath": {"value": "/httpbin/"}},
+				"upstream": {"url": "http://httpbin.org"}
+			}
+		}`,
+		"yaml": `
+openapi: 3.0.3
+x-tyk-api-gateway:
+  info:
+    id: httpbin
+    state:
+      active: true
+  server:
+    listenPath:
+      value: /httpbin/
+  upstream:
+    url: http://httpbin.org
+`,
+	}
+
+	for name, doc := range docs {
+		o, err := ParseTykOAS([]byte(doc))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if o.ID() != "httpbin" || !o.Active() || o.ListenPath() != "/httpbin/" || o.TargetURL() != "http://httpbin.org" {
+			t.Errorf("%s: unexpected definition %+v", name, o)
+		}
+
+		if o["openapi"] != "3.0.3" {
+			t.Errorf("%s: expected openapi to be kept got %v", name, o["openapi"])
+		}
+	}
+
+	if _, err := ParseTykOAS([]byte(`openapi: 3.0.3`)); !errors.Is(err, ErrMissingTykOASExtension) {
+		t.Errorf("expected %v got %v", ErrMissingTykOASExtension, err)
+	}
+}
+
+func TestTykOASSetters(t *testing.T) {
+	o, err := ParseTykOAS([]byte(`{"x-tyk-api-gateway": {"info": {"name": "httpbin"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o.SetID("id")
+	o.SetOrgID("org")
+	o.SetCertificates([]string{"server"})
+	o.SetClientCertificates([]string{"client"})
+	o.SetUpstreamCertificates(map[string]string{"b.com": "b", "a.com": "a"})
+
+	expect := TykOAS{
+		TykOASExtension: map[string]interface{}{
+			"info": map[string]interface{}{"name": "httpbin", "id": "id", "orgId": "org"},
+			"server": map[string]interface{}{
+				"customDomain":       map[string]interface{}{"certificates": []string{"server"}},
+				"clientCertificates": map[string]interface{}{"allowlist": []string{"client"}},
+			},
+			"upstream": map[string]interface{}{
+				"mutualTLS": map[string]interface{}{
+					"domainToCertificateMapping": []interface{}{
+						map[string]interface{}{"domain": "a.com", "certificate": "a"},
+						map[string]interface{}{"domain": "b.com", "certificate": "b"},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(o, expect) {
+		t.Errorf("expected %+v got %+v", expect, o)
+	}
+}
@@ -0,0 +1,14 @@
+package model
+
+// Organisation is an organisation of the Tyk Dashboard, managed through its
+// admin API.
+type Organisation struct {
+	ID        string `json:"id,omitempty"`
+	OwnerName string `json:"owner_name"`
+	OwnerSlug string `json:"owner_slug,omitempty"`
+
+	// CNameEnabled enables the custom domain of the developer portal of the
+	// organisation, set in CName.
+	CNameEnabled bool   `json:"cname_enabled"`
+	CName        string `json:"cname,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+// DashboardUser is a user of the Tyk Dashboard.
+type DashboardUser struct {
+	ID           string `json:"id,omitempty"`
+	OrgID        string `json:"org_id,omitempty"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	EmailAddress string `json:"email_address"`
+
+	// Password is only sent when the user is created, see Users.SetPassword.
+	Password string `json:"password,omitempty"`
+
+	Active bool `json:"active"`
+
+	// UserPermissions maps dashboard objects to the access granted on them, for
+	// instance "apis": "write". "IsAdmin": "admin" grants every permission.
+	UserPermissions map[string]string `json:"user_permissions,omitempty"`
+
+	// GroupID is the id of the user group the user belongs to. The permissions of
+	// the group apply instead of UserPermissions.
+	GroupID string `json:"group_id,omitempty"`
+
+	// AccessKey is the key used by the user to call the dashboard API. It is only
+	// returned by the admin API.
+	AccessKey string `json:"access_key,omitempty"`
+}
+
+// UserGroup is a group of dashboard users sharing the same permissions.
+type UserGroup struct {
+	ID              string            `json:"id,omitempty"`
+	OrgID           string            `json:"org_id,omitempty"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	UserPermissions map[string]string `json:"user_permissions,omitempty"`
+}
@@ -250,6 +250,11 @@ func (in *APIDefinitionSpec) DeepCopyInto(out *APIDefinitionSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EventHandlers != nil {
+		in, out := &in.EventHandlers, &out.EventHandlers
+		*out = new(EventHandlerMetaConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnableIPWhiteListing != nil {
 		in, out := &in.EnableIPWhiteListing, &out.EnableIPWhiteListing
 		*out = new(bool)
@@ -800,6 +805,28 @@ func (in *DCROptions) DeepCopy() *DCROptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUser) DeepCopyInto(out *DashboardUser) {
+	*out = *in
+	if in.UserPermissions != nil {
+		in, out := &in.UserPermissions, &out.UserPermissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUser.
+func (in *DashboardUser) DeepCopy() *DashboardUser {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSourceConfig) DeepCopyInto(out *DataSourceConfig) {
 	*out = *in
@@ -919,15 +946,17 @@ func (in *EventHandlerMetaConfig) DeepCopyInto(out *EventHandlerMetaConfig) {
 	*out = *in
 	if in.Events != nil {
 		in, out := &in.Events, &out.Events
-		*out = make(map[TykEvent][]EventHandlerTriggerConfig, len(*in))
+		*out = make(map[TykEvent]EventHandlerTriggerConfigs, len(*in))
 		for key, val := range *in {
 			var outVal []EventHandlerTriggerConfig
 			if val == nil {
 				(*out)[key] = nil
 			} else {
 				in, out := &val, &outVal
-				*out = make([]EventHandlerTriggerConfig, len(*in))
-				copy(*out, *in)
+				*out = make(EventHandlerTriggerConfigs, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
 			}
 			(*out)[key] = outVal
 		}
@@ -947,6 +976,7 @@ func (in *EventHandlerMetaConfig) DeepCopy() *EventHandlerMetaConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventHandlerTriggerConfig) DeepCopyInto(out *EventHandlerTriggerConfig) {
 	*out = *in
+	in.HandlerMeta.DeepCopyInto(&out.HandlerMeta)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventHandlerTriggerConfig.
@@ -959,6 +989,27 @@ func (in *EventHandlerTriggerConfig) DeepCopy() *EventHandlerTriggerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in EventHandlerTriggerConfigs) DeepCopyInto(out *EventHandlerTriggerConfigs) {
+	{
+		in := &in
+		*out = make(EventHandlerTriggerConfigs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventHandlerTriggerConfigs.
+func (in EventHandlerTriggerConfigs) DeepCopy() EventHandlerTriggerConfigs {
+	if in == nil {
+		return nil
+	}
+	out := new(EventHandlerTriggerConfigs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedPathsSet) DeepCopyInto(out *ExtendedPathsSet) {
 	*out = *in
@@ -1916,6 +1967,21 @@ func (in *OpenIDOptions) DeepCopy() *OpenIDOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Organisation) DeepCopyInto(out *Organisation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Organisation.
+func (in *Organisation) DeepCopy() *Organisation {
+	if in == nil {
+		return nil
+	}
+	out := new(Organisation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyPartitions) DeepCopyInto(out *PolicyPartitions) {
 	*out = *in
@@ -2385,6 +2451,45 @@ func (in *SessionProviderMeta) DeepCopy() *SessionProviderMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionState) DeepCopyInto(out *SessionState) {
+	*out = *in
+	if in.ApplyPolicies != nil {
+		in, out := &in.ApplyPolicies, &out.ApplyPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccessRights != nil {
+		in, out := &in.AccessRights, &out.AccessRights
+		*out = make(map[string]AccessDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetaData != nil {
+		in, out := &in.MetaData, &out.MetaData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionState.
+func (in *SessionState) DeepCopy() *SessionState {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SignatureConfig) DeepCopyInto(out *SignatureConfig) {
 	*out = *in
@@ -2707,6 +2812,28 @@ func (in *UptimeTests) DeepCopy() *UptimeTests {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserGroup) DeepCopyInto(out *UserGroup) {
+	*out = *in
+	if in.UserPermissions != nil {
+		in, out := &in.UserPermissions, &out.UserPermissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserGroup.
+func (in *UserGroup) DeepCopy() *UserGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(UserGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatePathMeta) DeepCopyInto(out *ValidatePathMeta) {
 	*out = *in
@@ -2881,3 +3008,25 @@ func (in *VirtualMeta) DeepCopy() *VirtualMeta {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebHookHandlerConf) DeepCopyInto(out *WebHookHandlerConf) {
+	*out = *in
+	if in.HeaderMap != nil {
+		in, out := &in.HeaderMap, &out.HeaderMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebHookHandlerConf.
+func (in *WebHookHandlerConf) DeepCopy() *WebHookHandlerConf {
+	if in == nil {
+		return nil
+	}
+	out := new(WebHookHandlerConf)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -28,6 +28,19 @@ type APIDefinitionSpec struct {
 	// Context specify namespace/name of the OperatorContext object used for
 	// reconciling this APIDefinition
 	Context *model.Target `json:"contextRef,omitempty"`
+
+	// UserOwnerRefs references the DashboardUser resources owning the API on
+	// the dashboard, in addition to the user_owners of the OperatorContext.
+	UserOwnerRefs []model.Target `json:"userOwnerRefs,omitempty"`
+
+	// UserGroupOwnerRefs references the DashboardUserGroup resources owning
+	// the API on the dashboard, in addition to the user_group_owners of the
+	// OperatorContext.
+	UserGroupOwnerRefs []model.Target `json:"userGroupOwnerRefs,omitempty"`
+
+	// EventWebhookRefs references the ApiEventWebhook resources called when
+	// the events they listen to are fired by the API.
+	EventWebhookRefs []model.Target `json:"eventWebhookRefs,omitempty"`
 }
 
 // ApiDefinitionStatus defines the observed state of ApiDefinition
@@ -66,6 +79,68 @@ type ApiDefinitionStatus struct {
 	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
 
 	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// Nodes reports how many gateways of the group loaded the latest
+	// definition of the API. It is only set when reload verification is
+	// enabled on the environment, or when the API is applied to several
+	// gateways.
+	Nodes *NodesStatus `json:"nodes,omitempty"`
+
+	// Certificates describes the certificates referenced by the ApiDefinition,
+	// as stored on Tyk.
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CertificateStatus describes a certificate stored on Tyk.
+type CertificateStatus struct {
+	// ID is the id of the certificate on Tyk.
+	ID string `json:"id"`
+
+	Subject     string   `json:"subject,omitempty"`
+	Issuer      string   `json:"issuer,omitempty"`
+	DNSNames    []string `json:"dnsNames,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+
+	// NotAfter is the time the certificate expires at.
+	NotAfter metav1.Time `json:"notAfter,omitempty"`
+
+	// ExpiryWarning is the last expiry warning reported for the certificate,
+	// zero once it expired. It is unset when no warning was reported.
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+}
+
+// NodesStatus counts the gateways serving the latest definition of a resource.
+type NodesStatus struct {
+	// Synced is the number of gateways serving the latest definition.
+	Synced int `json:"synced"`
+
+	// Total is the number of gateways polled.
+	Total int `json:"total"`
+
+	// Failed lists the gateways the resource could not be applied to.
+	Failed []NodeFailure `json:"failed,omitempty"`
+
+	// VerifyingSince is when the gateways were first polled for the latest
+	// definition, while some of them do not serve it yet.
+	// +optional
+	VerifyingSince *metav1.Time `json:"verifyingSince,omitempty"`
+}
+
+// NodeFailure is the error a gateway returned when a resource was applied to
+// it.
+type NodeFailure struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
 }
 
 // TransactionStatus indicates the status of the Tyk API calls for currently reconciled object.
@@ -102,6 +177,9 @@ type TransactionInfo struct {
 // +kubebuilder:printcolumn:name="Proxy.TargetURL",type=string,JSONPath=`.spec.proxy.target_url`
 // +kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.active`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Synced",type=integer,JSONPath=`.status.nodes.synced`,priority=1
+// +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.nodes.total`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:resource:categories="tyk",shortName="tykapis"
 type ApiDefinition struct {
 	metav1.TypeMeta   `json:",inline"`
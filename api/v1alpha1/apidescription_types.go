@@ -39,13 +39,24 @@ type APIDocumentation struct {
 }
 
 // APIDescriptionStatus defines the observed state of APIDescription
-type APIDescriptionStatus struct{}
+type APIDescriptionStatus struct {
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
 // APIDescription is the Schema for the apidescriptions API
 // +kubebuilder:resource:categories=tyk
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type APIDescription struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,129 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApiEventWebhookSpec defines the desired state of ApiEventWebhook
+type ApiEventWebhookSpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this ApiEventWebhook
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	// TargetURL is the URL called when one of the events is fired.
+	TargetURL string `json:"targetURL,omitempty"`
+
+	// Service references the Service called when one of the events is fired,
+	// instead of TargetURL.
+	Service *WebhookServiceReference `json:"service,omitempty"`
+
+	// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE
+	// +kubebuilder:default=POST
+	// +optional
+	Method string `json:"method"`
+
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// TemplatePath is the path on the gateway of the template rendering the
+	// body of the request. The default template of the gateway is used when
+	// empty.
+	TemplatePath string `json:"templatePath,omitempty"`
+
+	// Events calling the webhook, for instance QuotaExceeded, BreakerTriggered
+	// or KeyExpired.
+	// +kubebuilder:validation:MinItems=1
+	Events []model.TykEvent `json:"events"`
+
+	// EventTimeout is the number of seconds during which the same event does
+	// not call the webhook again.
+	EventTimeout int64 `json:"eventTimeout,omitempty"`
+
+	// Disabled stops the webhook from being called.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// WebhookServiceReference references a port of a Service, resolved to its
+// cluster URL.
+type WebhookServiceReference struct {
+	Name string `json:"name"`
+
+	// Namespace of the Service, defaults to the namespace of the
+	// ApiEventWebhook.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Port of the Service, defaults to its first port.
+	Port int32 `json:"port,omitempty"`
+
+	// Path appended to the URL of the Service.
+	Path string `json:"path,omitempty"`
+
+	// +kubebuilder:validation:Enum=http;https
+	// +kubebuilder:default=http
+	// +optional
+	Scheme string `json:"scheme"`
+}
+
+// ApiEventWebhookStatus defines the observed state of ApiEventWebhook
+type ApiEventWebhookStatus struct {
+	// TargetURL is the URL called by the webhook.
+	TargetURL string `json:"targetURL,omitempty"`
+
+	// WebhookID is the id of the webhook on the dashboard.
+	WebhookID string `json:"webhookID,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the webhook last sent to the
+	// dashboard.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ApiEventWebhook is the Schema for the apieventwebhooks API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.targetURL`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykwebhooks"
+type ApiEventWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApiEventWebhookSpec   `json:"spec,omitempty"`
+	Status ApiEventWebhookStatus `json:"status,omitempty"`
+}
+
+// ApiEventWebhookList contains a list of ApiEventWebhook
+// +kubebuilder:object:root=true
+type ApiEventWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApiEventWebhook `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApiEventWebhook{}, &ApiEventWebhookList{})
+}
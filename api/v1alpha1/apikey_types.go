@@ -0,0 +1,137 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApiKeySecretKey is the key of the Secret of an ApiKey holding the value of the
+// Tyk key.
+const ApiKeySecretKey = "key"
+
+// ApiKeyPreviousSecretKey is the key of the Secret of an ApiKey holding the
+// value of the Tyk key it rotated out, until that key is revoked from Tyk.
+const ApiKeyPreviousSecretKey = "previousKey"
+
+// ApiKeySpec defines the desired state of ApiKey
+type ApiKeySpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this ApiKey
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	// Alias is a human readable name of the key, recorded in the analytics.
+	Alias string `json:"alias,omitempty"`
+
+	// Policies references the SecurityPolicy resources applied to the key. The
+	// namespace defaults to the namespace of the ApiKey.
+	Policies []model.Target `json:"policies,omitempty"`
+
+	// AccessRights grants the key access to ApiDefinition resources, in addition
+	// to the ones granted by its policies.
+	AccessRights []model.AccessDefinition `json:"accessRights,omitempty"`
+
+	// Rate limit per X seconds (x="Per"), omit or "-1" for unlimited
+	Rate int64 `json:"rate,omitempty"`
+
+	// To be used in conjunction with "Rate".  Per seconds. 1 minute=60.  1 hour=3600
+	// omit or "-1" for unlimited
+	Per int64 `json:"per,omitempty"`
+
+	// Value of Quota allowed, omit or "-1" for unlimited
+	QuotaMax int64 `json:"quotaMax,omitempty"`
+
+	// Value reset length, in seconds, omit or "-1" for unlimited
+	QuotaRenewalRate int64 `json:"quotaRenewalRate,omitempty"`
+
+	// Tags are applied to the key and transferred to the analytics.
+	Tags []string `json:"tags,omitempty"`
+
+	MetaData map[string]string `json:"metaData,omitempty"`
+
+	// Expires is the time the key expires at. The key never expires if it is not
+	// set.
+	Expires *metav1.Time `json:"expires,omitempty"`
+
+	// SecretName is the name of the Secret the key is written into, it defaults
+	// to the name of the ApiKey. The Secret is owned by the ApiKey.
+	SecretName string `json:"secretName,omitempty"`
+
+	// Rotation rotates the key whenever it is changed, for instance to the
+	// current date. A new key is written into the Secret and the previous one is
+	// deleted from Tyk.
+	Rotation string `json:"rotation,omitempty"`
+}
+
+// ApiKeyStatus defines the observed state of ApiKey
+type ApiKeyStatus struct {
+	// SecretName is the name of the Secret holding the key.
+	SecretName string `json:"secretName,omitempty"`
+
+	// PolicyIDs are the Tyk ids of the policies applied to the key.
+	PolicyIDs []string `json:"policyIDs,omitempty"`
+
+	// Rotation is the rotation of the key held by the Secret.
+	Rotation string `json:"rotation,omitempty"`
+
+	// KeyHash is the hash Tyk stores the key under, used to revoke the key when
+	// its Secret is lost. It is empty if Tyk does not hash keys.
+	KeyHash string `json:"keyHash,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the key last sent to Tyk.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ApiKey is the Schema for the apikeys API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Secret",type=string,JSONPath=`.status.secretName`
+// +kubebuilder:printcolumn:name="Expires",type=string,JSONPath=`.spec.expires`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykkeys"
+type ApiKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApiKeySpec   `json:"spec,omitempty"`
+	Status ApiKeyStatus `json:"status,omitempty"`
+}
+
+// ApiKeyList contains a list of ApiKey
+// +kubebuilder:object:root=true
+type ApiKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApiKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApiKey{}, &ApiKeyList{})
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// ConditionReady is True when the resource is applied to Tyk and works as
+	// desired. kubectl wait --for=condition=Ready waits for it.
+	ConditionReady = "Ready"
+
+	// ConditionSynced is True when the latest generation of the resource was
+	// applied to Tyk.
+	ConditionSynced = "Synced"
+
+	// ConditionDependenciesResolved is False when the resource references
+	// objects that do not exist, or are not applied to Tyk yet.
+	ConditionDependenciesResolved = "DependenciesResolved"
+
+	// ConditionDegraded is True when Tyk serves the resource, but not as
+	// desired: some gateways failed, or an older version is still served.
+	ConditionDegraded = "Degraded"
+
+	// ConditionDrifted is True when the object of a resource on Tyk was changed
+	// outside of the operator and the change was kept.
+	ConditionDrifted = "Drifted"
+
+	// ConditionPaused is True while the reconciliation of the resource is
+	// paused, by its tyk.io/reconcile annotation or by its OperatorContext.
+	ConditionPaused = "Paused"
+
+	// ConditionDryRun is True while the resource is reconciled by an operator
+	// in dry run mode, its changes are recorded and not applied to Tyk.
+	ConditionDryRun = "DryRun"
+)
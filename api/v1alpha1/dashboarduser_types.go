@@ -0,0 +1,108 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DashboardUserPasswordKey is the key of the Secret holding the password of a
+// DashboardUser.
+const DashboardUserPasswordKey = "password"
+
+// DashboardUserSpec defines the desired state of DashboardUser
+type DashboardUserSpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this DashboardUser
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	FirstName    string `json:"firstName"`
+	LastName     string `json:"lastName"`
+	EmailAddress string `json:"emailAddress"`
+
+	// Active users can log into the dashboard.
+	// +optional
+	// +kubebuilder:default=true
+	Active bool `json:"active"`
+
+	// UserPermissions maps dashboard objects to the access granted on them, for
+	// instance apis: write. IsAdmin: admin grants every permission.
+	UserPermissions map[string]string `json:"userPermissions,omitempty"`
+
+	// UserGroupRef references the DashboardUserGroup the user belongs to. The
+	// permissions of the group apply instead of UserPermissions.
+	UserGroupRef *model.Target `json:"userGroupRef,omitempty"`
+
+	// PasswordSecretRef references the secret holding the password of the user
+	// under the password key. When namespace is omitted, the namespace of the
+	// DashboardUser is used. The password is set again whenever the secret
+	// changes.
+	PasswordSecretRef *model.Target `json:"passwordSecretRef,omitempty"`
+}
+
+// DashboardUserStatus defines the observed state of DashboardUser
+type DashboardUserStatus struct {
+	// UserID is the id of the user on the dashboard.
+	UserID string `json:"userID,omitempty"`
+
+	// UserGroupID is the id of the user group the user belongs to.
+	UserGroupID string `json:"userGroupID,omitempty"`
+
+	// PasswordSecretVersion is the resource version of the password secret
+	// when the password was last set.
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the user last sent to the dashboard.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DashboardUser is the Schema for the dashboardusers API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Email",type=string,JSONPath=`.spec.emailAddress`
+// +kubebuilder:printcolumn:name="UserID",type=string,JSONPath=`.status.userID`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykusers"
+type DashboardUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DashboardUserSpec   `json:"spec,omitempty"`
+	Status DashboardUserStatus `json:"status,omitempty"`
+}
+
+// DashboardUserList contains a list of DashboardUser
+// +kubebuilder:object:root=true
+type DashboardUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DashboardUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DashboardUser{}, &DashboardUserList{})
+}
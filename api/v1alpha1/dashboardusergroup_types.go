@@ -0,0 +1,84 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DashboardUserGroupSpec defines the desired state of DashboardUserGroup
+type DashboardUserGroupSpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this DashboardUserGroup
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	// Name of the user group as displayed in the dashboard.
+	Name string `json:"name"`
+
+	Description string `json:"description,omitempty"`
+
+	// UserPermissions maps dashboard objects to the access granted on them to
+	// the users of the group, for instance apis: write.
+	UserPermissions map[string]string `json:"userPermissions,omitempty"`
+}
+
+// DashboardUserGroupStatus defines the observed state of DashboardUserGroup
+type DashboardUserGroupStatus struct {
+	// UserGroupID is the id of the user group on the dashboard.
+	UserGroupID string `json:"userGroupID,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the user group last sent to the
+	// dashboard.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DashboardUserGroup is the Schema for the dashboardusergroups API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="UserGroupID",type=string,JSONPath=`.status.userGroupID`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykusergroups"
+type DashboardUserGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DashboardUserGroupSpec   `json:"spec,omitempty"`
+	Status DashboardUserGroupStatus `json:"status,omitempty"`
+}
+
+// DashboardUserGroupList contains a list of DashboardUserGroup
+// +kubebuilder:object:root=true
+type DashboardUserGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DashboardUserGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DashboardUserGroup{}, &DashboardUserGroupList{})
+}
@@ -55,6 +55,84 @@ const (
 	TykUserOwners = "TYK_USER_OWNERS"
 
 	TykUserGroupOwners = "TYK_USER_GROUP_OWNERS"
+
+	// TykCASecret is the namespace/name of the secret holding the CA bundle used
+	// to verify the certificate of the gateway/dashboard
+	TykCASecret = "TYK_CA_SECRET"
+
+	// TykClientCertSecret is the namespace/name of the kubernetes.io/tls secret
+	// holding the client certificate presented to the gateway/dashboard
+	TykClientCertSecret = "TYK_CLIENT_CERT_SECRET"
+
+	// TykProxyURL is the url of the proxy used to reach the gateway/dashboard
+	TykProxyURL = "TYK_PROXY_URL"
+
+	// TykRequestTimeout is the time limit of a single api call, eg 30s
+	TykRequestTimeout = "TYK_REQUEST_TIMEOUT"
+
+	// TykDialTimeout is the time limit for establishing a connection, eg 10s
+	TykDialTimeout = "TYK_DIAL_TIMEOUT"
+
+	// TykTLSHandshakeTimeout is the time limit of the tls handshake, eg 10s
+	TykTLSHandshakeTimeout = "TYK_TLS_HANDSHAKE_TIMEOUT"
+
+	// TykHotReloadWindow is how long gateway hot reloads are batched, eg 2s
+	TykHotReloadWindow = "TYK_HOT_RELOAD_WINDOW"
+
+	// TykAdminSecret is the namespace/name of the secret holding the admin
+	// secret of the dashboard
+	TykAdminSecret = "TYK_ADMIN_SECRET"
+
+	// TykReloadVerificationService is the namespace/name of the Service
+	// selecting the gateways whose reloads are verified
+	TykReloadVerificationService = "TYK_RELOAD_VERIFICATION_SERVICE"
+
+	// TykReloadVerificationPort is the port of the gateways on their pods
+	TykReloadVerificationPort = "TYK_RELOAD_VERIFICATION_PORT"
+
+	// TykReloadVerificationTimeout is how long gateways are polled after a
+	// reload, eg 30s
+	TykReloadVerificationTimeout = "TYK_RELOAD_VERIFICATION_TIMEOUT"
+
+	// TykCertificateExpiryWarnings is a comma separated list of durations
+	// before expiry at which certificates are reported, eg 720h,168h
+	TykCertificateExpiryWarnings = "TYK_CERTIFICATE_EXPIRY_WARNINGS"
+
+	// TykGatewayURLs is a comma separated list of the urls of the gateways
+	// resources are applied to in ce mode
+	TykGatewayURLs = "TYK_GATEWAY_URLS"
+
+	// TykGatewaySelector is the label selector of the Endpoints of the gateways
+	// resources are applied to in ce mode, eg app=tyk-gateway
+	TykGatewaySelector = "TYK_GATEWAY_SELECTOR"
+
+	// TykGatewayNamespace is the namespace of the Endpoints selected by
+	// TYK_GATEWAY_SELECTOR
+	TykGatewayNamespace = "TYK_GATEWAY_NAMESPACE"
+
+	// TykGatewayPort is the port of the gateways on their pods
+	TykGatewayPort = "TYK_GATEWAY_PORT"
+
+	// TykResyncInterval is how often ApiDefinitions and SecurityPolicies are
+	// compared with Tyk, eg 10m
+	TykResyncInterval = "TYK_RESYNC_INTERVAL"
+
+	// TykDriftPolicy is what is done with objects changed on Tyk outside of the
+	// operator, one of correct, report or ignore
+	TykDriftPolicy = "TYK_DRIFT_POLICY"
+
+	// TykDryRun records the changes the operator would make to Tyk and to the
+	// cluster instead of making them, eg true
+	TykDryRun = "TYK_DRY_RUN"
+
+	// TykShardSelector is the label selector of the resources managed by this
+	// shard of the operator, eg tyk.io/shard=a
+	TykShardSelector = "TYK_SHARD_SELECTOR"
+
+	// TykOperatorID identifies this install of the operator on Tyk, the objects
+	// it creates are stamped with it. It defaults to the uid of the kube-system
+	// namespace
+	TykOperatorID = "TYK_OPERATOR_ID"
 )
 
 // OperatorContextMode is the mode to which the admin api binding is done values are
@@ -62,6 +140,23 @@ const (
 // +kubebuilder:validation:Enum=ce;pro
 type OperatorContextMode string
 
+// DriftPolicy is what the operator does when an object it manages was changed on
+// Tyk outside of the operator.
+// +kubebuilder:validation:Enum=correct;report;ignore
+type DriftPolicy string
+
+const (
+	// DriftCorrect applies the resource again, reverting the change.
+	DriftCorrect DriftPolicy = "correct"
+
+	// DriftReport keeps the change, and reports it with a Warning event and
+	// the Drifted condition of the resource.
+	DriftReport DriftPolicy = "report"
+
+	// DriftIgnore keeps the change without reporting it.
+	DriftIgnore DriftPolicy = "ignore"
+)
+
 // OperatorContextSpec defines the desired state of OperatorContext
 type OperatorContextSpec struct {
 	// Reference to k8s secret resource that we load environment from.
@@ -69,6 +164,26 @@ type OperatorContextSpec struct {
 	// Env is the values of the admin api endpoint that the operator will use to
 	// reconcile resources
 	Env *Environment `json:"env,omitempty"`
+
+	// GarbageCollection enables the periodic collection of the APIs and
+	// policies left on Tyk by resources which no longer exist.
+	GarbageCollection *GarbageCollection `json:"garbageCollection,omitempty"`
+
+	// Paused stops applying the resources linked to the OperatorContext to Tyk,
+	// and collecting its garbage, until it is set back to false.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// GarbageCollection configures the collection of the objects created on Tyk by
+// the operator for resources which no longer exist, for instance because their
+// finalizer was removed by hand.
+type GarbageCollection struct {
+	// Interval is how often objects are collected. Defaults to 1h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// DryRun only reports orphaned objects, with events and the status of the
+	// OperatorContext, without deleting them.
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type Environment struct {
@@ -80,6 +195,111 @@ type Environment struct {
 	InsecureSkipVerify bool                `json:"insecureSkipVerify,omitempty"`
 	UserOwners         []string            `json:"user_owners,omitempty"`
 	UserGroupOwners    []string            `json:"user_group_owners,omitempty"`
+
+	// TLS configures the certificates used when connecting to the gateway or
+	// the dashboard.
+	TLS *TLS `json:"tls,omitempty"`
+
+	// ProxyURL is the url of the HTTP proxy used to reach the gateway or the
+	// dashboard. When empty, HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables of the operator are used.
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// Timeouts of the api calls made to the gateway or the dashboard.
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// HotReloadWindow is how long hot reloads of the gateway group are batched
+	// before a single reload is sent. This only applies to ce mode. When zero,
+	// every change is followed by its own reload.
+	HotReloadWindow *metav1.Duration `json:"hotReloadWindow,omitempty"`
+
+	// AdminSecretRef is a reference to a secret holding the admin secret of
+	// the dashboard, admin_secret in tyk_analytics.conf, under the adminSecret
+	// key. It is only used to manage TykOrganisation resources. When namespace
+	// is omitted, the namespace of the OperatorContext is used.
+	AdminSecretRef *model.Target `json:"adminSecretRef,omitempty"`
+
+	// ReloadVerification makes the operator check that every gateway of the
+	// group loaded an ApiDefinition after it was reloaded. This only applies
+	// to ce mode.
+	ReloadVerification *ReloadVerification `json:"reloadVerification,omitempty"`
+
+	// CertificateExpiryWarnings are the durations before expiry at which
+	// Warning events are recorded for the certificates referenced by an
+	// ApiDefinition. Defaults to 720h and 168h.
+	CertificateExpiryWarnings []metav1.Duration `json:"certificateExpiryWarnings,omitempty"`
+
+	// Gateways lists the gateways ApiDefinitions, SecurityPolicies and
+	// certificates are applied to, when they do not share their storage and
+	// can't be reached all at once through url. This only applies to ce mode.
+	Gateways *Gateways `json:"gateways,omitempty"`
+
+	// ResyncInterval is how often ApiDefinitions and SecurityPolicies are
+	// compared with their object on Tyk, to detect changes made outside of the
+	// operator. When zero, they are only compared when they are reconciled.
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+
+	// DriftPolicy is what is done with ApiDefinitions and SecurityPolicies
+	// changed on Tyk outside of the operator. Defaults to correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+}
+
+type Gateways struct {
+	// URLs are the urls of the gateways.
+	URLs []string `json:"urls,omitempty"`
+
+	// Selector selects the Endpoints of the gateways. Every ready address of
+	// the selected Endpoints is a gateway.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Namespace is the namespace of the selected Endpoints. Defaults to the
+	// namespace of the OperatorContext.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Port is the port of the gateway on the pods. Defaults to the first port
+	// of the Endpoints.
+	Port int32 `json:"port,omitempty"`
+}
+
+type ReloadVerification struct {
+	// Service is a reference to the Service selecting the gateway pods. Every
+	// ready address of its Endpoints is polled. When namespace is omitted, the
+	// namespace of the OperatorContext is used.
+	Service model.Target `json:"service"`
+
+	// Port is the port of the gateway on the pods. Defaults to the first port
+	// of the Endpoints.
+	Port int32 `json:"port,omitempty"`
+
+	// Timeout is how long the gateways are polled before giving up. Defaults
+	// to 30s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type TLS struct {
+	// CASecretRef is a reference to a secret holding a PEM encoded CA bundle
+	// under the ca.crt key. It is used instead of the system roots to verify
+	// the certificate of the gateway or the dashboard. When namespace is
+	// omitted, the namespace of the OperatorContext is used.
+	CASecretRef *model.Target `json:"caSecretRef,omitempty"`
+
+	// ClientCertSecretRef is a reference to a kubernetes.io/tls secret whose
+	// tls.crt and tls.key are presented to the gateway or the dashboard for
+	// mutual TLS. When namespace is omitted, the namespace of the
+	// OperatorContext is used.
+	ClientCertSecretRef *model.Target `json:"clientCertSecretRef,omitempty"`
+}
+
+type Timeouts struct {
+	// Request is the time limit of a single api call, including reading the
+	// response body. Zero means no timeout.
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// Dial is the time limit for establishing a connection. Defaults to 30s.
+	Dial *metav1.Duration `json:"dial,omitempty"`
+
+	// TLSHandshake is the time limit of the TLS handshake. Defaults to 10s.
+	TLSHandshake *metav1.Duration `json:"tlsHandshake,omitempty"`
 }
 
 type Ingress struct {
@@ -89,11 +309,70 @@ type Ingress struct {
 
 // OperatorContextStatus defines the observed state of OperatorContext
 type OperatorContextStatus struct {
-	LinkedApiDefinitions      []model.Target `json:"linked_api_definitions,omitempty"`
-	LinkedApiDescriptions     []model.Target `json:"linked_api_descriptions,omitempty"`
-	LinkedPortalAPICatalogues []model.Target `json:"linked_portal_catalogues,omitempty"`
-	LinkedSecurityPolicies    []model.Target `json:"linked_security_policies,omitempty"`
-	LinkedPortalConfigs       []model.Target `json:"linked_portal_configs,omitempty"`
+	LinkedApiDefinitions       []model.Target `json:"linked_api_definitions,omitempty"`
+	LinkedApiDescriptions      []model.Target `json:"linked_api_descriptions,omitempty"`
+	LinkedPortalAPICatalogues  []model.Target `json:"linked_portal_catalogues,omitempty"`
+	LinkedSecurityPolicies     []model.Target `json:"linked_security_policies,omitempty"`
+	LinkedPortalConfigs        []model.Target `json:"linked_portal_configs,omitempty"`
+	LinkedTykOasApiDefinitions []model.Target `json:"linked_tyk_oas_api_definitions,omitempty"`
+	LinkedApiKeys              []model.Target `json:"linked_api_keys,omitempty"`
+	LinkedDashboardUsers       []model.Target `json:"linked_dashboard_users,omitempty"`
+	LinkedDashboardUserGroups  []model.Target `json:"linked_dashboard_user_groups,omitempty"`
+	LinkedTykOrganisations     []model.Target `json:"linked_tyk_organisations,omitempty"`
+	LinkedApiEventWebhooks     []model.Target `json:"linked_api_event_webhooks,omitempty"`
+
+	// CredentialsGeneration is incremented whenever a secret referenced by the
+	// OperatorContext changes, which reconciles the linked resources with the
+	// new credentials.
+	CredentialsGeneration int64 `json:"credentialsGeneration,omitempty"`
+
+	// LatestSecretsHash is the hash of the versions of the secrets referenced
+	// by the OperatorContext.
+	LatestSecretsHash string `json:"latestSecretsHash,omitempty"`
+
+	// GarbageCollection is the outcome of the latest garbage collection.
+	GarbageCollection *GarbageCollectionStatus `json:"garbageCollection,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GarbageCollectionStatus describes the latest garbage collection of an
+// OperatorContext.
+type GarbageCollectionStatus struct {
+	// Time is when objects were last collected.
+	Time metav1.Time `json:"time"`
+
+	// Deleted is the number of orphaned objects deleted from Tyk.
+	Deleted int `json:"deleted,omitempty"`
+
+	// Orphans lists the orphaned objects left on Tyk, because of a dry run or
+	// because they could not be deleted.
+	Orphans []OrphanedObject `json:"orphans,omitempty"`
+}
+
+// OrphanedObject is an object created on Tyk by the operator for a resource
+// which no longer exists.
+type OrphanedObject struct {
+	// Kind is the kind of the resource the object was created for,
+	// ApiDefinition or SecurityPolicy.
+	Kind string `json:"kind"`
+
+	// ID is the id of the object on Tyk.
+	ID string `json:"id"`
+
+	// Resource is the namespace/name of the resource the object was created
+	// for.
+	Resource string `json:"resource"`
+
+	// Error is why the object could not be deleted.
+	Error string `json:"error,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -101,6 +380,8 @@ type OperatorContextStatus struct {
 
 // OperatorContext is the Schema for the operatorcontexts API
 // +kubebuilder:resource:categories=tyk
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type OperatorContext struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -156,6 +437,30 @@ func (opStatus *OperatorContextStatus) RemoveLinkedPortalConfig(target model.Tar
 	opStatus.LinkedPortalConfigs = removeLinkedResource(target, opStatus.LinkedPortalConfigs)
 }
 
+func (opStatus *OperatorContextStatus) RemoveLinkedTykOasApiDefinition(target model.Target) {
+	opStatus.LinkedTykOasApiDefinitions = removeLinkedResource(target, opStatus.LinkedTykOasApiDefinitions)
+}
+
+func (opStatus *OperatorContextStatus) RemoveLinkedApiKey(target model.Target) {
+	opStatus.LinkedApiKeys = removeLinkedResource(target, opStatus.LinkedApiKeys)
+}
+
+func (opStatus *OperatorContextStatus) RemoveLinkedDashboardUser(target model.Target) {
+	opStatus.LinkedDashboardUsers = removeLinkedResource(target, opStatus.LinkedDashboardUsers)
+}
+
+func (opStatus *OperatorContextStatus) RemoveLinkedDashboardUserGroup(target model.Target) {
+	opStatus.LinkedDashboardUserGroups = removeLinkedResource(target, opStatus.LinkedDashboardUserGroups)
+}
+
+func (opStatus *OperatorContextStatus) RemoveLinkedTykOrganisation(target model.Target) {
+	opStatus.LinkedTykOrganisations = removeLinkedResource(target, opStatus.LinkedTykOrganisations)
+}
+
+func (opStatus *OperatorContextStatus) RemoveLinkedApiEventWebhook(target model.Target) {
+	opStatus.LinkedApiEventWebhooks = removeLinkedResource(target, opStatus.LinkedApiEventWebhooks)
+}
+
 func (opStatus *OperatorContextStatus) AddLinkedAPIDefinition(target model.Target) {
 	opStatus.RemoveLinkedAPIDefinition(target)
 	opStatus.LinkedApiDefinitions = append(opStatus.LinkedApiDefinitions, target)
@@ -180,3 +485,33 @@ func (opStatus *OperatorContextStatus) AddLinkedPortalConfig(target model.Target
 	opStatus.RemoveLinkedPortalConfig(target)
 	opStatus.LinkedPortalConfigs = append(opStatus.LinkedPortalConfigs, target)
 }
+
+func (opStatus *OperatorContextStatus) AddLinkedTykOasApiDefinition(target model.Target) {
+	opStatus.RemoveLinkedTykOasApiDefinition(target)
+	opStatus.LinkedTykOasApiDefinitions = append(opStatus.LinkedTykOasApiDefinitions, target)
+}
+
+func (opStatus *OperatorContextStatus) AddLinkedApiKey(target model.Target) {
+	opStatus.RemoveLinkedApiKey(target)
+	opStatus.LinkedApiKeys = append(opStatus.LinkedApiKeys, target)
+}
+
+func (opStatus *OperatorContextStatus) AddLinkedDashboardUser(target model.Target) {
+	opStatus.RemoveLinkedDashboardUser(target)
+	opStatus.LinkedDashboardUsers = append(opStatus.LinkedDashboardUsers, target)
+}
+
+func (opStatus *OperatorContextStatus) AddLinkedDashboardUserGroup(target model.Target) {
+	opStatus.RemoveLinkedDashboardUserGroup(target)
+	opStatus.LinkedDashboardUserGroups = append(opStatus.LinkedDashboardUserGroups, target)
+}
+
+func (opStatus *OperatorContextStatus) AddLinkedTykOrganisation(target model.Target) {
+	opStatus.RemoveLinkedTykOrganisation(target)
+	opStatus.LinkedTykOrganisations = append(opStatus.LinkedTykOrganisations, target)
+}
+
+func (opStatus *OperatorContextStatus) AddLinkedApiEventWebhook(target model.Target) {
+	opStatus.RemoveLinkedApiEventWebhook(target)
+	opStatus.LinkedApiEventWebhooks = append(opStatus.LinkedApiEventWebhooks, target)
+}
@@ -47,12 +47,22 @@ type PortalCatalogueDescription struct {
 type PortalAPICatalogueStatus struct {
 	// ID is the mongo ID of the PortalAPICatalogue object created by the dashboard.
 	ID string `json:"id,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:categories="tyk",shortName="tykcatalogues"
 // +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 
 // PortalAPICatalogue is the Schema for the portalapicatalogues API
 type PortalAPICatalogue struct {
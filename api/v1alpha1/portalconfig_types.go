@@ -30,6 +30,15 @@ type PortalConfigSpec struct {
 // PortalConfigStatus defines the observed state of PortalConfig
 type PortalConfigStatus struct {
 	ID string `json:"id,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -37,6 +46,8 @@ type PortalConfigStatus struct {
 
 // PortalConfig is the Schema for the portalconfigs API
 // +kubebuilder:resource:categories=tyk
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type PortalConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -37,12 +37,27 @@ type SecurityPolicyStatus struct {
 
 	LatestTykSpecHash string `json:"latestTykSpecHash,omitempty"`
 	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	// Nodes reports how many gateways the policy was applied to. It is only
+	// set when the policy is applied to several gateways.
+	Nodes *NodesStatus `json:"nodes,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // SecurityPolicy is the Schema for the securitypolicies API
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:categories="tyk",shortName="tykpolicies"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type SecurityPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -29,6 +29,15 @@ type SubGraphSpec struct {
 // SubGraphStatus defines the observed state of SubGraph
 type SubGraphStatus struct {
 	model.SubGraphStatus `json:",inline"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -36,6 +45,8 @@ type SubGraphStatus struct {
 
 // SubGraph is the Schema for the subgraphs API
 // +kubebuilder:resource:categories=tyk
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type SubGraph struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
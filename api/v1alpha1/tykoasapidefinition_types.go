@@ -0,0 +1,136 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TykOasApiDefinitionSpec defines the desired state of TykOasApiDefinition
+type TykOasApiDefinitionSpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this TykOasApiDefinition
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	// TykOAS holds the Tyk OAS API definition, an OpenAPI 3 document with the
+	// x-tyk-api-gateway extension.
+	TykOAS TykOASReference `json:"tykOAS"`
+
+	// CertificateSecretNames lists the kubernetes.io/tls secrets holding the
+	// certificates served for the custom domain of the API. They are uploaded to
+	// Tyk and set in server.customDomain.certificates.
+	CertificateSecretNames []string `json:"certificateSecretNames,omitempty"`
+
+	// ClientCertificateRefs lists the kubernetes.io/tls secrets holding the client
+	// certificates allowed when mutual TLS is enabled. They are uploaded to Tyk
+	// and set in server.clientCertificates.allowlist.
+	ClientCertificateRefs []string `json:"clientCertificateRefs,omitempty"`
+
+	// UpstreamCertificateRefs maps upstream domains to the kubernetes.io/tls
+	// secrets presented to them. They are uploaded to Tyk and set in
+	// upstream.mutualTLS.domainToCertificateMapping.
+	UpstreamCertificateRefs map[string]string `json:"upstreamCertificateRefs,omitempty"`
+}
+
+// TykOASReference holds a Tyk OAS API definition inline or references a
+// ConfigMap holding it. Exactly one of them must be set.
+type TykOASReference struct {
+	// Document is the Tyk OAS API definition in JSON or YAML.
+	Document string `json:"document,omitempty"`
+
+	// ConfigmapRef references the ConfigMap key holding the Tyk OAS API
+	// definition in JSON or YAML.
+	ConfigmapRef *ConfigMapReference `json:"configmapRef,omitempty"`
+}
+
+// ConfigMapReference references a key of a ConfigMap.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap, defaults to the namespace of the referencing
+	// resource.
+	Namespace string `json:"namespace,omitempty"`
+
+	// KeyName is the key of the ConfigMap holding the value.
+	KeyName string `json:"keyName"`
+}
+
+// TykOasApiDefinitionStatus defines the observed state of TykOasApiDefinition
+type TykOasApiDefinitionStatus struct {
+	// ApiID is the id of the API on Tyk, info.id of the x-tyk-api-gateway
+	// extension.
+	ApiID string `json:"apiID,omitempty"`
+
+	// OrgID corresponds to the Organization ID that this API belongs to.
+	OrgID string `json:"orgID,omitempty"`
+
+	Domain     string `json:"domain,omitempty"`
+	ListenPath string `json:"listenPath,omitempty"`
+	TargetURL  string `json:"targetURL,omitempty"`
+	Enabled    bool   `json:"enabled,omitempty"`
+
+	// LatestTykSpecHash stores the hash of the API definition read from Tyk after
+	// it was last created or updated.
+	LatestTykSpecHash string `json:"latestTykSpecHash,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the API definition last sent to Tyk.
+	// Tyk is only updated when either hash changed.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TykOasApiDefinition is the Schema for the tykoasapidefinitions API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.status.domain`
+// +kubebuilder:printcolumn:name="ListenPath",type=string,JSONPath=`.status.listenPath`
+// +kubebuilder:printcolumn:name="Proxy.TargetURL",type=string,JSONPath=`.status.targetURL`
+// +kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.status.enabled`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykoas"
+type TykOasApiDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TykOasApiDefinitionSpec   `json:"spec,omitempty"`
+	Status TykOasApiDefinitionStatus `json:"status,omitempty"`
+}
+
+// TykOasApiDefinitionList contains a list of TykOasApiDefinition
+// +kubebuilder:object:root=true
+type TykOasApiDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TykOasApiDefinition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TykOasApiDefinition{}, &TykOasApiDefinitionList{})
+}
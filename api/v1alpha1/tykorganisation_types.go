@@ -0,0 +1,109 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TykOrganisationSpec defines the desired state of TykOrganisation
+type TykOrganisationSpec struct {
+	// Context specify namespace/name of the OperatorContext object used for
+	// reconciling this TykOrganisation. Its env must have an adminSecretRef.
+	Context *model.Target `json:"contextRef,omitempty"`
+
+	// OwnerName is the name of the organisation.
+	OwnerName string `json:"ownerName"`
+
+	OwnerSlug string `json:"ownerSlug,omitempty"`
+
+	// CName is the custom domain of the developer portal of the organisation.
+	// Setting it enables the custom domain.
+	CName string `json:"cname,omitempty"`
+
+	// OperatorContext generates an OperatorContext reconciling resources in the
+	// organisation.
+	OperatorContext *GeneratedOperatorContext `json:"operatorContext,omitempty"`
+}
+
+// GeneratedOperatorContext describes the OperatorContext generated for a
+// TykOrganisation. It is created in the namespace of the TykOrganisation along
+// with a Secret of the same name holding the credentials of a dashboard user
+// of the organisation.
+type GeneratedOperatorContext struct {
+	// Name of the OperatorContext and of its Secret.
+	Name string `json:"name"`
+
+	// UserEmailAddress is the email address of the dashboard admin user created
+	// in the organisation for the operator.
+	UserEmailAddress string `json:"userEmailAddress"`
+}
+
+// TykOrganisationStatus defines the observed state of TykOrganisation
+type TykOrganisationStatus struct {
+	// OrgID is the id of the organisation on the dashboard.
+	OrgID string `json:"orgID,omitempty"`
+
+	// UserID is the id of the dashboard user created for the generated
+	// OperatorContext.
+	UserID string `json:"userID,omitempty"`
+
+	// OperatorContext is the name of the generated OperatorContext.
+	OperatorContext string `json:"operatorContext,omitempty"`
+
+	// LatestCRDSpecHash stores the hash of the organisation last sent to the
+	// dashboard.
+	LatestCRDSpecHash string `json:"latestCRDSpecHash,omitempty"`
+
+	LatestTransaction TransactionInfo `json:"latestTransaction,omitempty"`
+
+	// ObservedGeneration is the generation of the resource last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TykOrganisation is the Schema for the tykorganisations API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.spec.ownerName`
+// +kubebuilder:printcolumn:name="OrgID",type=string,JSONPath=`.status.orgID`
+// +kubebuilder:printcolumn:name="Context",type=string,JSONPath=`.status.operatorContext`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.latestTransaction.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:resource:categories="tyk",shortName="tykorgs"
+type TykOrganisation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TykOrganisationSpec   `json:"spec,omitempty"`
+	Status TykOrganisationStatus `json:"status,omitempty"`
+}
+
+// TykOrganisationList contains a list of TykOrganisation
+// +kubebuilder:object:root=true
+type TykOrganisationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TykOrganisation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TykOrganisation{}, &TykOrganisationList{})
+}
@@ -23,6 +23,7 @@ package v1alpha1
 
 import (
 	"github.com/TykTechnologies/tyk-operator/api/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -35,6 +36,27 @@ func (in *APIDefinitionSpec) DeepCopyInto(out *APIDefinitionSpec) {
 		*out = new(model.Target)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UserOwnerRefs != nil {
+		in, out := &in.UserOwnerRefs, &out.UserOwnerRefs
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserGroupOwnerRefs != nil {
+		in, out := &in.UserGroupOwnerRefs, &out.UserGroupOwnerRefs
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EventWebhookRefs != nil {
+		in, out := &in.EventWebhookRefs, &out.EventWebhookRefs
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIDefinitionSpec.
@@ -53,7 +75,7 @@ func (in *APIDescription) DeepCopyInto(out *APIDescription) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIDescription.
@@ -156,6 +178,13 @@ func (in *APIDescriptionSpec) DeepCopy() *APIDescriptionSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIDescriptionStatus) DeepCopyInto(out *APIDescriptionStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIDescriptionStatus.
@@ -267,61 +296,39 @@ func (in *ApiDefinitionStatus) DeepCopyInto(out *ApiDefinitionStatus) {
 		}
 	}
 	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiDefinitionStatus.
-func (in *ApiDefinitionStatus) DeepCopy() *ApiDefinitionStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ApiDefinitionStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Environment) DeepCopyInto(out *Environment) {
-	*out = *in
-	out.Ingress = in.Ingress
-	if in.UserOwners != nil {
-		in, out := &in.UserOwners, &out.UserOwners
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(NodesStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.UserGroupOwners != nil {
-		in, out := &in.UserGroupOwners, &out.UserGroupOwners
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Environment.
-func (in *Environment) DeepCopy() *Environment {
-	if in == nil {
-		return nil
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(Environment)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Ingress) DeepCopyInto(out *Ingress) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
-func (in *Ingress) DeepCopy() *Ingress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiDefinitionStatus.
+func (in *ApiDefinitionStatus) DeepCopy() *ApiDefinitionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Ingress)
+	out := new(ApiDefinitionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorContext) DeepCopyInto(out *OperatorContext) {
+func (in *ApiEventWebhook) DeepCopyInto(out *ApiEventWebhook) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -329,18 +336,18 @@ func (in *OperatorContext) DeepCopyInto(out *OperatorContext) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContext.
-func (in *OperatorContext) DeepCopy() *OperatorContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiEventWebhook.
+func (in *ApiEventWebhook) DeepCopy() *ApiEventWebhook {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorContext)
+	out := new(ApiEventWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperatorContext) DeepCopyObject() runtime.Object {
+func (in *ApiEventWebhook) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -348,31 +355,31 @@ func (in *OperatorContext) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorContextList) DeepCopyInto(out *OperatorContextList) {
+func (in *ApiEventWebhookList) DeepCopyInto(out *ApiEventWebhookList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OperatorContext, len(*in))
+		*out = make([]ApiEventWebhook, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextList.
-func (in *OperatorContextList) DeepCopy() *OperatorContextList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiEventWebhookList.
+func (in *ApiEventWebhookList) DeepCopy() *ApiEventWebhookList {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorContextList)
+	out := new(ApiEventWebhookList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperatorContextList) DeepCopyObject() runtime.Object {
+func (in *ApiEventWebhookList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -380,101 +387,86 @@ func (in *OperatorContextList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorContextSpec) DeepCopyInto(out *OperatorContextSpec) {
+func (in *ApiEventWebhookSpec) DeepCopyInto(out *ApiEventWebhookSpec) {
 	*out = *in
-	if in.FromSecret != nil {
-		in, out := &in.FromSecret, &out.FromSecret
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
 		*out = new(model.Target)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = new(Environment)
-		(*in).DeepCopyInto(*out)
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(WebhookServiceReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]model.TykEvent, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextSpec.
-func (in *OperatorContextSpec) DeepCopy() *OperatorContextSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiEventWebhookSpec.
+func (in *ApiEventWebhookSpec) DeepCopy() *ApiEventWebhookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorContextSpec)
+	out := new(ApiEventWebhookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorContextStatus) DeepCopyInto(out *OperatorContextStatus) {
+func (in *ApiEventWebhookStatus) DeepCopyInto(out *ApiEventWebhookStatus) {
 	*out = *in
-	if in.LinkedApiDefinitions != nil {
-		in, out := &in.LinkedApiDefinitions, &out.LinkedApiDefinitions
-		*out = make([]model.Target, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LinkedApiDescriptions != nil {
-		in, out := &in.LinkedApiDescriptions, &out.LinkedApiDescriptions
-		*out = make([]model.Target, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LinkedPortalAPICatalogues != nil {
-		in, out := &in.LinkedPortalAPICatalogues, &out.LinkedPortalAPICatalogues
-		*out = make([]model.Target, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LinkedSecurityPolicies != nil {
-		in, out := &in.LinkedSecurityPolicies, &out.LinkedSecurityPolicies
-		*out = make([]model.Target, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LinkedPortalConfigs != nil {
-		in, out := &in.LinkedPortalConfigs, &out.LinkedPortalConfigs
-		*out = make([]model.Target, len(*in))
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextStatus.
-func (in *OperatorContextStatus) DeepCopy() *OperatorContextStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiEventWebhookStatus.
+func (in *ApiEventWebhookStatus) DeepCopy() *ApiEventWebhookStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorContextStatus)
+	out := new(ApiEventWebhookStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalAPICatalogue) DeepCopyInto(out *PortalAPICatalogue) {
+func (in *ApiKey) DeepCopyInto(out *ApiKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogue.
-func (in *PortalAPICatalogue) DeepCopy() *PortalAPICatalogue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiKey.
+func (in *ApiKey) DeepCopy() *ApiKey {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalAPICatalogue)
+	out := new(ApiKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortalAPICatalogue) DeepCopyObject() runtime.Object {
+func (in *ApiKey) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -482,31 +474,31 @@ func (in *PortalAPICatalogue) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalAPICatalogueList) DeepCopyInto(out *PortalAPICatalogueList) {
+func (in *ApiKeyList) DeepCopyInto(out *ApiKeyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PortalAPICatalogue, len(*in))
+		*out = make([]ApiKey, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueList.
-func (in *PortalAPICatalogueList) DeepCopy() *PortalAPICatalogueList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiKeyList.
+func (in *ApiKeyList) DeepCopy() *ApiKeyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalAPICatalogueList)
+	out := new(ApiKeyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortalAPICatalogueList) DeepCopyObject() runtime.Object {
+func (in *ApiKeyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -514,93 +506,145 @@ func (in *PortalAPICatalogueList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalAPICatalogueSpec) DeepCopyInto(out *PortalAPICatalogueSpec) {
+func (in *ApiKeySpec) DeepCopyInto(out *ApiKeySpec) {
 	*out = *in
-	if in.APIDescriptionList != nil {
-		in, out := &in.APIDescriptionList, &out.APIDescriptionList
-		*out = make([]*PortalCatalogueDescription, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PortalCatalogueDescription)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = new(model.Target)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AccessRights != nil {
+		in, out := &in.AccessRights, &out.AccessRights
+		*out = make([]model.AccessDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetaData != nil {
+		in, out := &in.MetaData, &out.MetaData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueSpec.
-func (in *PortalAPICatalogueSpec) DeepCopy() *PortalAPICatalogueSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiKeySpec.
+func (in *ApiKeySpec) DeepCopy() *ApiKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalAPICatalogueSpec)
+	out := new(ApiKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalAPICatalogueStatus) DeepCopyInto(out *PortalAPICatalogueStatus) {
+func (in *ApiKeyStatus) DeepCopyInto(out *ApiKeyStatus) {
 	*out = *in
+	if in.PolicyIDs != nil {
+		in, out := &in.PolicyIDs, &out.PolicyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueStatus.
-func (in *PortalAPICatalogueStatus) DeepCopy() *PortalAPICatalogueStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiKeyStatus.
+func (in *ApiKeyStatus) DeepCopy() *ApiKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalAPICatalogueStatus)
+	out := new(ApiKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalCatalogueDescription) DeepCopyInto(out *PortalCatalogueDescription) {
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
-	in.APIDescriptionBase.DeepCopyInto(&out.APIDescriptionBase)
-	if in.APIDescriptionRef != nil {
-		in, out := &in.APIDescriptionRef, &out.APIDescriptionRef
-		*out = new(model.Target)
-		(*in).DeepCopyInto(*out)
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalCatalogueDescription.
-func (in *PortalCatalogueDescription) DeepCopy() *PortalCatalogueDescription {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalCatalogueDescription)
+	out := new(CertificateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalConfig) DeepCopyInto(out *PortalConfig) {
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUser) DeepCopyInto(out *DashboardUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfig.
-func (in *PortalConfig) DeepCopy() *PortalConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUser.
+func (in *DashboardUser) DeepCopy() *DashboardUser {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalConfig)
+	out := new(DashboardUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortalConfig) DeepCopyObject() runtime.Object {
+func (in *DashboardUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -608,31 +652,58 @@ func (in *PortalConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalConfigList) DeepCopyInto(out *PortalConfigList) {
+func (in *DashboardUserGroup) DeepCopyInto(out *DashboardUserGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserGroup.
+func (in *DashboardUserGroup) DeepCopy() *DashboardUserGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUserGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardUserGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUserGroupList) DeepCopyInto(out *DashboardUserGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PortalConfig, len(*in))
+		*out = make([]DashboardUserGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigList.
-func (in *PortalConfigList) DeepCopy() *PortalConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserGroupList.
+func (in *DashboardUserGroupList) DeepCopy() *DashboardUserGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalConfigList)
+	out := new(DashboardUserGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortalConfigList) DeepCopyObject() runtime.Object {
+func (in *DashboardUserGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -640,37 +711,808 @@ func (in *PortalConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalConfigSpec) DeepCopyInto(out *PortalConfigSpec) {
+func (in *DashboardUserGroupSpec) DeepCopyInto(out *DashboardUserGroupSpec) {
 	*out = *in
-	in.PortalModelPortalConfig.DeepCopyInto(&out.PortalModelPortalConfig)
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = new(model.Target)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UserPermissions != nil {
+		in, out := &in.UserPermissions, &out.UserPermissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigSpec.
-func (in *PortalConfigSpec) DeepCopy() *PortalConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserGroupSpec.
+func (in *DashboardUserGroupSpec) DeepCopy() *DashboardUserGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalConfigSpec)
+	out := new(DashboardUserGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalConfigStatus) DeepCopyInto(out *PortalConfigStatus) {
+func (in *DashboardUserGroupStatus) DeepCopyInto(out *DashboardUserGroupStatus) {
 	*out = *in
-}
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserGroupStatus.
+func (in *DashboardUserGroupStatus) DeepCopy() *DashboardUserGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUserGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUserList) DeepCopyInto(out *DashboardUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DashboardUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserList.
+func (in *DashboardUserList) DeepCopy() *DashboardUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUserSpec) DeepCopyInto(out *DashboardUserSpec) {
+	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserPermissions != nil {
+		in, out := &in.UserPermissions, &out.UserPermissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UserGroupRef != nil {
+		in, out := &in.UserGroupRef, &out.UserGroupRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserSpec.
+func (in *DashboardUserSpec) DeepCopy() *DashboardUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardUserStatus) DeepCopyInto(out *DashboardUserStatus) {
+	*out = *in
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserStatus.
+func (in *DashboardUserStatus) DeepCopy() *DashboardUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Environment) DeepCopyInto(out *Environment) {
+	*out = *in
+	out.Ingress = in.Ingress
+	if in.UserOwners != nil {
+		in, out := &in.UserOwners, &out.UserOwners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserGroupOwners != nil {
+		in, out := &in.UserGroupOwners, &out.UserGroupOwners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HotReloadWindow != nil {
+		in, out := &in.HotReloadWindow, &out.HotReloadWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AdminSecretRef != nil {
+		in, out := &in.AdminSecretRef, &out.AdminSecretRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReloadVerification != nil {
+		in, out := &in.ReloadVerification, &out.ReloadVerification
+		*out = new(ReloadVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateExpiryWarnings != nil {
+		in, out := &in.CertificateExpiryWarnings, &out.CertificateExpiryWarnings
+		*out = make([]v1.Duration, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = new(Gateways)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Environment.
+func (in *Environment) DeepCopy() *Environment {
+	if in == nil {
+		return nil
+	}
+	out := new(Environment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollection) DeepCopyInto(out *GarbageCollection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollection.
+func (in *GarbageCollection) DeepCopy() *GarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollectionStatus) DeepCopyInto(out *GarbageCollectionStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]OrphanedObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollectionStatus.
+func (in *GarbageCollectionStatus) DeepCopy() *GarbageCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gateways) DeepCopyInto(out *Gateways) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gateways.
+func (in *Gateways) DeepCopy() *Gateways {
+	if in == nil {
+		return nil
+	}
+	out := new(Gateways)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedOperatorContext) DeepCopyInto(out *GeneratedOperatorContext) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedOperatorContext.
+func (in *GeneratedOperatorContext) DeepCopy() *GeneratedOperatorContext {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedOperatorContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ingress) DeepCopyInto(out *Ingress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
+func (in *Ingress) DeepCopy() *Ingress {
+	if in == nil {
+		return nil
+	}
+	out := new(Ingress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailure) DeepCopyInto(out *NodeFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailure.
+func (in *NodeFailure) DeepCopy() *NodeFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodesStatus) DeepCopyInto(out *NodesStatus) {
+	*out = *in
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]NodeFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifyingSince != nil {
+		in, out := &in.VerifyingSince, &out.VerifyingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesStatus.
+func (in *NodesStatus) DeepCopy() *NodesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorContext) DeepCopyInto(out *OperatorContext) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContext.
+func (in *OperatorContext) DeepCopy() *OperatorContext {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorContext) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorContextList) DeepCopyInto(out *OperatorContextList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperatorContext, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextList.
+func (in *OperatorContextList) DeepCopy() *OperatorContextList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorContextList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorContextList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorContextSpec) DeepCopyInto(out *OperatorContextSpec) {
+	*out = *in
+	if in.FromSecret != nil {
+		in, out := &in.FromSecret, &out.FromSecret
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(Environment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextSpec.
+func (in *OperatorContextSpec) DeepCopy() *OperatorContextSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorContextSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorContextStatus) DeepCopyInto(out *OperatorContextStatus) {
+	*out = *in
+	if in.LinkedApiDefinitions != nil {
+		in, out := &in.LinkedApiDefinitions, &out.LinkedApiDefinitions
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedApiDescriptions != nil {
+		in, out := &in.LinkedApiDescriptions, &out.LinkedApiDescriptions
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedPortalAPICatalogues != nil {
+		in, out := &in.LinkedPortalAPICatalogues, &out.LinkedPortalAPICatalogues
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedSecurityPolicies != nil {
+		in, out := &in.LinkedSecurityPolicies, &out.LinkedSecurityPolicies
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedPortalConfigs != nil {
+		in, out := &in.LinkedPortalConfigs, &out.LinkedPortalConfigs
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedTykOasApiDefinitions != nil {
+		in, out := &in.LinkedTykOasApiDefinitions, &out.LinkedTykOasApiDefinitions
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedApiKeys != nil {
+		in, out := &in.LinkedApiKeys, &out.LinkedApiKeys
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedDashboardUsers != nil {
+		in, out := &in.LinkedDashboardUsers, &out.LinkedDashboardUsers
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedDashboardUserGroups != nil {
+		in, out := &in.LinkedDashboardUserGroups, &out.LinkedDashboardUserGroups
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedTykOrganisations != nil {
+		in, out := &in.LinkedTykOrganisations, &out.LinkedTykOrganisations
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkedApiEventWebhooks != nil {
+		in, out := &in.LinkedApiEventWebhooks, &out.LinkedApiEventWebhooks
+		*out = make([]model.Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorContextStatus.
+func (in *OperatorContextStatus) DeepCopy() *OperatorContextStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorContextStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedObject) DeepCopyInto(out *OrphanedObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedObject.
+func (in *OrphanedObject) DeepCopy() *OrphanedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalAPICatalogue) DeepCopyInto(out *PortalAPICatalogue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogue.
+func (in *PortalAPICatalogue) DeepCopy() *PortalAPICatalogue {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalAPICatalogue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortalAPICatalogue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalAPICatalogueList) DeepCopyInto(out *PortalAPICatalogueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PortalAPICatalogue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueList.
+func (in *PortalAPICatalogueList) DeepCopy() *PortalAPICatalogueList {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalAPICatalogueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortalAPICatalogueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalAPICatalogueSpec) DeepCopyInto(out *PortalAPICatalogueSpec) {
+	*out = *in
+	if in.APIDescriptionList != nil {
+		in, out := &in.APIDescriptionList, &out.APIDescriptionList
+		*out = make([]*PortalCatalogueDescription, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PortalCatalogueDescription)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueSpec.
+func (in *PortalAPICatalogueSpec) DeepCopy() *PortalAPICatalogueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalAPICatalogueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalAPICatalogueStatus) DeepCopyInto(out *PortalAPICatalogueStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalAPICatalogueStatus.
+func (in *PortalAPICatalogueStatus) DeepCopy() *PortalAPICatalogueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalAPICatalogueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalCatalogueDescription) DeepCopyInto(out *PortalCatalogueDescription) {
+	*out = *in
+	in.APIDescriptionBase.DeepCopyInto(&out.APIDescriptionBase)
+	if in.APIDescriptionRef != nil {
+		in, out := &in.APIDescriptionRef, &out.APIDescriptionRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalCatalogueDescription.
+func (in *PortalCatalogueDescription) DeepCopy() *PortalCatalogueDescription {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalCatalogueDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalConfig) DeepCopyInto(out *PortalConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfig.
+func (in *PortalConfig) DeepCopy() *PortalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortalConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalConfigList) DeepCopyInto(out *PortalConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PortalConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigList.
+func (in *PortalConfigList) DeepCopy() *PortalConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortalConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalConfigSpec) DeepCopyInto(out *PortalConfigSpec) {
+	*out = *in
+	in.PortalModelPortalConfig.DeepCopyInto(&out.PortalModelPortalConfig)
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigSpec.
+func (in *PortalConfigSpec) DeepCopy() *PortalConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalConfigStatus) DeepCopyInto(out *PortalConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigStatus.
+func (in *PortalConfigStatus) DeepCopy() *PortalConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfigStatus.
-func (in *PortalConfigStatus) DeepCopy() *PortalConfigStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReloadVerification) DeepCopyInto(out *ReloadVerification) {
+	*out = *in
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadVerification.
+func (in *ReloadVerification) DeepCopy() *ReloadVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalConfigStatus)
+	out := new(ReloadVerification)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -765,6 +1607,18 @@ func (in *SecurityPolicyStatus) DeepCopyInto(out *SecurityPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(NodesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyStatus.
@@ -783,7 +1637,7 @@ func (in *SubGraph) DeepCopyInto(out *SubGraph) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubGraph.
@@ -856,6 +1710,13 @@ func (in *SubGraphSpec) DeepCopy() *SubGraphSpec {
 func (in *SubGraphStatus) DeepCopyInto(out *SubGraphStatus) {
 	*out = *in
 	out.SubGraphStatus = in.SubGraphStatus
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubGraphStatus.
@@ -874,7 +1735,7 @@ func (in *SuperGraph) DeepCopyInto(out *SuperGraph) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuperGraph.
@@ -946,6 +1807,13 @@ func (in *SuperGraphSpec) DeepCopy() *SuperGraphSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuperGraphStatus) DeepCopyInto(out *SuperGraphStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuperGraphStatus.
@@ -958,6 +1826,61 @@ func (in *SuperGraphStatus) DeepCopy() *SuperGraphStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timeouts) DeepCopyInto(out *Timeouts) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Dial != nil {
+		in, out := &in.Dial, &out.Dial
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLSHandshake != nil {
+		in, out := &in.TLSHandshake, &out.TLSHandshake
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timeouts.
+func (in *Timeouts) DeepCopy() *Timeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransactionInfo) DeepCopyInto(out *TransactionInfo) {
 	*out = *in
@@ -973,3 +1896,265 @@ func (in *TransactionInfo) DeepCopy() *TransactionInfo {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOASReference) DeepCopyInto(out *TykOASReference) {
+	*out = *in
+	if in.ConfigmapRef != nil {
+		in, out := &in.ConfigmapRef, &out.ConfigmapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOASReference.
+func (in *TykOASReference) DeepCopy() *TykOASReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOASReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOasApiDefinition) DeepCopyInto(out *TykOasApiDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOasApiDefinition.
+func (in *TykOasApiDefinition) DeepCopy() *TykOasApiDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOasApiDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TykOasApiDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOasApiDefinitionList) DeepCopyInto(out *TykOasApiDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TykOasApiDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOasApiDefinitionList.
+func (in *TykOasApiDefinitionList) DeepCopy() *TykOasApiDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOasApiDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TykOasApiDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOasApiDefinitionSpec) DeepCopyInto(out *TykOasApiDefinitionSpec) {
+	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TykOAS.DeepCopyInto(&out.TykOAS)
+	if in.CertificateSecretNames != nil {
+		in, out := &in.CertificateSecretNames, &out.CertificateSecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCertificateRefs != nil {
+		in, out := &in.ClientCertificateRefs, &out.ClientCertificateRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpstreamCertificateRefs != nil {
+		in, out := &in.UpstreamCertificateRefs, &out.UpstreamCertificateRefs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOasApiDefinitionSpec.
+func (in *TykOasApiDefinitionSpec) DeepCopy() *TykOasApiDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOasApiDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOasApiDefinitionStatus) DeepCopyInto(out *TykOasApiDefinitionStatus) {
+	*out = *in
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOasApiDefinitionStatus.
+func (in *TykOasApiDefinitionStatus) DeepCopy() *TykOasApiDefinitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOasApiDefinitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOrganisation) DeepCopyInto(out *TykOrganisation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOrganisation.
+func (in *TykOrganisation) DeepCopy() *TykOrganisation {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOrganisation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TykOrganisation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOrganisationList) DeepCopyInto(out *TykOrganisationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TykOrganisation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOrganisationList.
+func (in *TykOrganisationList) DeepCopy() *TykOrganisationList {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOrganisationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TykOrganisationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOrganisationSpec) DeepCopyInto(out *TykOrganisationSpec) {
+	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(model.Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorContext != nil {
+		in, out := &in.OperatorContext, &out.OperatorContext
+		*out = new(GeneratedOperatorContext)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOrganisationSpec.
+func (in *TykOrganisationSpec) DeepCopy() *TykOrganisationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOrganisationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TykOrganisationStatus) DeepCopyInto(out *TykOrganisationStatus) {
+	*out = *in
+	in.LatestTransaction.DeepCopyInto(&out.LatestTransaction)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TykOrganisationStatus.
+func (in *TykOrganisationStatus) DeepCopy() *TykOrganisationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TykOrganisationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookServiceReference) DeepCopyInto(out *WebhookServiceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookServiceReference.
+func (in *WebhookServiceReference) DeepCopy() *WebhookServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
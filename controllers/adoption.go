@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrAdoptionFailed is returned when a resource can't take over the object on
+// Tyk named by its adopt-id annotation. The operator never creates an object
+// for such a resource, nor changes the id of the adopted one.
+var ErrAdoptionFailed = errors.New("cannot adopt object on Tyk")
+
+// adoptID returns the id of the existing object on Tyk the resource o takes
+// over, set by the tyk.io/adopt-id annotation.
+func adoptID(o metav1.Object) (string, bool) {
+	id := o.GetAnnotations()[keys.AdoptIDAnnotation]
+	return id, id != ""
+}
+
+// adoptionSummary describes in a single line the fields of an adopted object
+// updated to match its resource.
+func adoptionSummary(fields []string) string {
+	if len(fields) == 0 {
+		return "it already matches the resource"
+	}
+
+	return driftSummary(fields)
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionAdoption(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	id, org := "5f1c7b3e", fake.Org
+	_, err := klient.Universal.Api().Create(s.Context(ctx), &model.APIDefinitionSpec{
+		Name: "legacy", APIID: &id, OrgID: &org,
+	})
+	is.NoErr(err)
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "httpbin",
+			Namespace:   "default",
+			Annotations: map[string]string{keys.AdoptIDAnnotation: id},
+		},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	missing := api.DeepCopy()
+	missing.Name = "missing"
+	missing.Annotations = map[string]string{keys.AdoptIDAnnotation: "unknown"}
+
+	cl, err := NewFakeClient([]runtime.Object{api, missing})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	apis := s.APIs()
+	is.Equal(len(apis), 1)
+	is.Equal(*apis[0].APIID, id)
+	is.Equal(apis[0].Name, "httpbin")
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.ApiID, id)
+	is.Equal(<-recorder.Events, "Normal Adopted Adopted ApiDefinition 5f1c7b3e from Tyk, name changed")
+
+	// The id of an adopted ApiDefinition never changes.
+	api.Annotations[keys.AdoptIDAnnotation] = "other"
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrAdoptionFailed))
+
+	// Removing the annotation keeps managing the adopted ApiDefinition.
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	delete(api.Annotations, keys.AdoptIDAnnotation)
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	apis = s.APIs()
+	is.Equal(len(apis), 1)
+	is.Equal(*apis[0].APIID, id)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.ApiID, id)
+
+	// An ApiDefinition missing on Tyk is not created.
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(missing)})
+	is.True(errors.Is(err, ErrAdoptionFailed))
+	is.Equal(len(s.APIs()), 1)
+}
+
+func TestSecurityPolicyAdoption(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	id := "gold-legacy"
+	is.NoErr(klient.Universal.Portal().Policy().Create(s.Context(ctx), &tykv1alpha1.SecurityPolicySpec{
+		SecurityPolicySpec: model.SecurityPolicySpec{Name: "legacy", ID: &id},
+	}))
+
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "gold",
+			Namespace:   "default",
+			Annotations: map[string]string{keys.AdoptIDAnnotation: id},
+		},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{Name: "gold"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{policy})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	policies := s.Policies()
+	is.Equal(len(policies), 1)
+	is.Equal(*policies[0].ID, id)
+	is.Equal(policies[0].Name, "gold")
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal Adopted"))
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	is.Equal(policy.Status.PolID, id)
+	is.Equal(*policy.Spec.ID, id)
+
+	// The adopted policy is not created again once deleted from Tyk.
+	is.NoErr(klient.Universal.Portal().Policy().Delete(s.Context(ctx), id))
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrAdoptionFailed))
+	is.Equal(len(s.Policies()), 0)
+
+	// Once the annotation is removed, the policy is created again with its id.
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	delete(policy.Annotations, keys.AdoptIDAnnotation)
+	is.NoErr(cl.Update(ctx, policy))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	policies = s.Policies()
+	is.Equal(len(policies), 1)
+	is.Equal(*policies[0].ID, id)
+}
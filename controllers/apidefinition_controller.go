@@ -21,7 +21,6 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,10 +33,12 @@ import (
 	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -58,6 +59,10 @@ import (
 const (
 	queueAfter = time.Second * 3
 	GraphKey   = "graph_ref"
+
+	// EventWebhookKey indexes ApiDefinition resources by the namespace/name of
+	// the ApiEventWebhook resources they reference.
+	EventWebhookKey = "event_webhook_refs"
 )
 
 var ErrMultipleLinkSubGraph = errors.New("linking one SubGraph to multiple ApiDefinition is forbidden")
@@ -77,6 +82,10 @@ type ApiDefinitionReconciler struct {
 // +kubebuilder:rbac:groups=tyk.tyk.io,resources=apidefinitions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=tyk.tyk.io,resources=subgraphs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;update;create
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusers;dashboardusergroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apieventwebhooks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 
 func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -100,6 +109,14 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// A paused ApiDefinition being deleted leaves its API on Tyk, unless it is
+	// retained: it must be unstamped first.
+	reason, paused := pauseReason(desired, env)
+	if paused && (desired.DeletionTimestamp.IsZero() || retained(desired)) {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
 	if desired.GetLabels()["template"] == "true" {
 		log.Info("Syncing template", "template", desired.Name)
 
@@ -112,6 +129,8 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		log.Info("Synced template", "template", desired.Name)
 
 		desired.Status.OrgID = env.Org
+		recordSync(r.Recorder, desired, desired.Status.Conditions, nil)
+		setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, nil)
 
 		if err := r.Status().Update(ctx, desired); err != nil {
 			log.Info("Failed to update template ApiDefinition status")
@@ -124,14 +143,30 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	var queueA time.Duration
 
 	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if err := checkDeletionPolicy(desired); err != nil {
+			return err
+		}
+
 		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
-			e, err := r.delete(ctx, desired)
+			e, err := r.delete(ctx, desired, reason)
 			queueA = e
 			return err
 		}
 
-		if desired.Spec.APIID == nil || *desired.Spec.APIID == "" {
-			apiID := EncodeNS(req.NamespacedName.String())
+		if id, ok := adoptID(desired); ok {
+			if err := checkAdoptedAPIID(desired, id); err != nil {
+				return err
+			}
+
+			upstreamRequestStruct.Spec.APIID = &id
+		} else if desired.Spec.APIID == nil || *desired.Spec.APIID == "" {
+			// Keep the id of the ApiDefinition already applied, it was adopted if
+			// the annotation has been removed since.
+			apiID := desired.Status.ApiID
+			if apiID == "" {
+				apiID = EncodeNS(req.NamespacedName.String())
+			}
+
 			upstreamRequestStruct.Spec.APIID = &apiID
 		}
 
@@ -140,6 +175,8 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			upstreamRequestStruct.Spec.OrgID = &orgID
 		}
 
+		stampAPI(&upstreamRequestStruct.Spec.APIDefinitionSpec, env.OperatorID)
+
 		util.AddFinalizer(desired, keys.ApiDefFinalizerName)
 
 		if err := r.processCertificateReferences(ctx, &env, log, upstreamRequestStruct); err != nil {
@@ -201,16 +238,57 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		upstreamRequestStruct.Spec.CollectLoopingTarget()
 
+		if err := r.processEventWebhookReferences(ctx, &env, upstreamRequestStruct); err != nil {
+			return err
+		}
+
+		ownersCtx, err := r.processOwnerReferences(ctx, upstreamRequestStruct)
+		if err != nil {
+			return err
+		}
+
 		//  If this is not set, means it is a new object, set it first
 		if desired.Status.ApiID == "" {
-			return r.create(ctx, upstreamRequestStruct)
+			if _, ok := adoptID(desired); ok {
+				return r.adopt(ownersCtx, upstreamRequestStruct)
+			}
+
+			return r.create(ownersCtx, upstreamRequestStruct)
 		}
 
-		return r.update(ctx, upstreamRequestStruct)
+		return r.update(ownersCtx, upstreamRequestStruct)
 	})
 
+	err = reloadResult(ctx, err)
+
+	nodes := nodesStatus(ctx, err)
+	if errors.Is(err, ErrReloadPending) {
+		nodes = desired.Status.Nodes
+	}
+
+	if err == nil && desired.ObjectMeta.DeletionTimestamp.IsZero() &&
+		env.Mode == "ce" && env.ReloadVerification != nil {
+		nodes, err = r.verifyReload(ctx, env, desired, upstreamRequestStruct)
+	}
+
+	certs := desired.Status.Certificates
+
+	if err == nil && desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		var next time.Duration
+
+		certs, next = r.observeCertificates(ctx, env, desired, &upstreamRequestStruct.Spec.APIDefinitionSpec)
+
+		// Check the certificates again when they cross an expiry warning.
+		if next > 0 && (queueA == 0 || next < queueA) {
+			queueA = next
+		}
+
+		// Compare the ApiDefinition with Tyk again to detect changes made there.
+		queueA = resyncAfter(env, queueA)
+	}
+
 	var transactionInfo *tykv1alpha1.TransactionInfo
-	if err == nil {
+	if err == nil || errors.Is(err, ErrReloadPending) {
 		log.Info("Completed reconciling ApiDefinition instance")
 
 		transactionInfo = &tykv1alpha1.TransactionInfo{
@@ -219,7 +297,6 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			Error:  "",
 		}
 	} else {
-		queueA = queueAfter
 		transactionInfo = &tykv1alpha1.TransactionInfo{
 			Time:   metav1.Now(),
 			Status: tykv1alpha1.Failed,
@@ -227,6 +304,8 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	recordSync(r.Recorder, desired, desired.Status.Conditions, err)
+
 	// Reconciler must return the error observed by CreateOrUpdate() function since the mutator given to CreateOrUpdate
 	// returns special custom error such as ErrMultipleLinkSubGraph.
 	errK8s := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -252,6 +331,10 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					status.LatestTykSpecHash = calculateHash(apiOnTyk)
 					status.LatestCRDSpecHash = calculateHash(upstreamRequestStruct.Spec)
 					status.LatestTransaction = *transactionInfo
+					setDrifted(&status.Conditions, desired.Generation, false, nil)
+					status.Nodes = nodes
+					status.Certificates = certs
+					setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
 				},
 			)
 		}
@@ -261,14 +344,172 @@ func (r *ApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			desired.Namespace,
 			target,
 			true,
-			func(status *tykv1alpha1.ApiDefinitionStatus) { status.LatestTransaction = *transactionInfo },
+			func(status *tykv1alpha1.ApiDefinitionStatus) {
+				status.LatestTransaction = *transactionInfo
+				status.Nodes = nodes
+				status.Certificates = certs
+				setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+			},
 		)
 	})
 	if errK8s != nil && err == nil {
 		err = errK8s
 	}
 
-	return ctrl.Result{RequeueAfter: queueA}, err
+	// Tyk rejected the ApiDefinition, retrying won't help until it is modified.
+	// The reason is recorded in the latest transaction of the status.
+	if errK8s == nil && tykClient.IsValidation(err) {
+		log.Info("ApiDefinition was rejected by Tyk", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return requeue(queueA, err)
+}
+
+// verifyReload checks that every gateway of the group loaded applied, the
+// definition of desired just applied to Tyk, and returns how many did.
+func (r *ApiDefinitionReconciler) verifyReload(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.ApiDefinition,
+	applied *tykv1alpha1.ApiDefinition,
+) (*tykv1alpha1.NodesStatus, error) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.verifyReload")
+	defer span.End()
+
+	nodes, err := verifyReload(ctx, r.Client, env, *applied.Spec.APIID, applied.Spec.APIDefinitionSpec,
+		desired.Status.Nodes, time.Now())
+	if err != nil && !errors.Is(err, ErrReloadPending) {
+		r.Log.Info("Failed to verify the reload of the gateways", "error", err.Error())
+	}
+
+	return nodes, err
+}
+
+// processOwnerReferences returns a copy of ctx whose environment has the
+// dashboard ids of the DashboardUser and DashboardUserGroup resources referenced
+// by upstreamRequestStruct added to the owners of the API.
+func (r *ApiDefinitionReconciler) processOwnerReferences(
+	ctx context.Context,
+	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
+) (context.Context, error) {
+	spec := upstreamRequestStruct.Spec
+	if len(spec.UserOwnerRefs) == 0 && len(spec.UserGroupOwnerRefs) == 0 {
+		return ctx, nil
+	}
+
+	rctx := tykClient.GetContext(ctx)
+	users := append([]string{}, rctx.Env.UserOwners...)
+	groups := append([]string{}, rctx.Env.UserGroupOwners...)
+
+	for _, t := range spec.UserOwnerRefs {
+		key := t.NS(upstreamRequestStruct.Namespace)
+
+		var user tykv1alpha1.DashboardUser
+		if err := r.Get(ctx, key, &user); err != nil {
+			return nil, fmt.Errorf("failed to get owner DashboardUser %s: %w", key, err)
+		}
+
+		if user.Status.UserID == "" {
+			return nil, fmt.Errorf("owner DashboardUser %s is not created on the dashboard yet", key)
+		}
+
+		users = append(users, user.Status.UserID)
+	}
+
+	for _, t := range spec.UserGroupOwnerRefs {
+		key := t.NS(upstreamRequestStruct.Namespace)
+
+		var group tykv1alpha1.DashboardUserGroup
+		if err := r.Get(ctx, key, &group); err != nil {
+			return nil, fmt.Errorf("failed to get owner DashboardUserGroup %s: %w", key, err)
+		}
+
+		if group.Status.UserGroupID == "" {
+			return nil, fmt.Errorf("owner DashboardUserGroup %s is not created on the dashboard yet", key)
+		}
+
+		groups = append(groups, group.Status.UserGroupID)
+	}
+
+	rctx.Env.UserOwners = users
+	rctx.Env.UserGroupOwners = groups
+
+	return tykClient.SetContext(ctx, rctx), nil
+}
+
+// processEventWebhookReferences adds the webhooks of the ApiEventWebhook
+// resources referenced by upstreamRequestStruct to its event handlers. On the
+// dashboard, webhooks are referenced by the id they were created with.
+func (r *ApiDefinitionReconciler) processEventWebhookReferences(
+	ctx context.Context,
+	env *environment.Env,
+	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
+) error {
+	spec := &upstreamRequestStruct.Spec
+	if len(spec.EventWebhookRefs) == 0 {
+		return nil
+	}
+
+	handlers := &model.EventHandlerMetaConfig{Events: map[model.TykEvent]model.EventHandlerTriggerConfigs{}}
+	if spec.EventHandlers != nil {
+		for e, triggers := range spec.EventHandlers.Events {
+			handlers.Events[e] = append(model.EventHandlerTriggerConfigs{}, triggers...)
+		}
+	}
+
+	for _, t := range spec.EventWebhookRefs {
+		key := t.NS(upstreamRequestStruct.Namespace)
+
+		var hook tykv1alpha1.ApiEventWebhook
+		if err := r.Get(ctx, key, &hook); err != nil {
+			return fmt.Errorf("failed to get ApiEventWebhook %s: %w", key, err)
+		}
+
+		targetURL, err := webhookURL(ctx, r.Client, &hook)
+		if err != nil {
+			return fmt.Errorf("failed to resolve ApiEventWebhook %s: %w", key, err)
+		}
+
+		conf := webhookConf(&hook, targetURL)
+
+		if env.Mode == "pro" {
+			if hook.Status.WebhookID == "" {
+				return fmt.Errorf("ApiEventWebhook %s is not created on the dashboard yet", key)
+			}
+
+			conf.ID = hook.Status.WebhookID
+		}
+
+		for _, e := range hook.Spec.Events {
+			handlers.Events[e] = append(handlers.Events[e], model.EventHandlerTriggerConfig{
+				Handler:     model.WebHookHandler,
+				HandlerMeta: conf,
+			})
+		}
+	}
+
+	spec.EventHandlers = handlers
+
+	return nil
+}
+
+func (r *ApiDefinitionReconciler) findApiDefinitionsForEventWebhook(hook client.Object) []reconcile.Request {
+	apiDefs := &tykv1alpha1.ApiDefinitionList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(EventWebhookKey, client.ObjectKeyFromObject(hook).String()),
+	}
+
+	if err := r.List(context.TODO(), apiDefs, listOps); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(apiDefs.Items))
+	for i := range apiDefs.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&apiDefs.Items[i])}
+	}
+
+	return requests
 }
 
 func (r *ApiDefinitionReconciler) processClientCertificateReferences(
@@ -277,13 +518,16 @@ func (r *ApiDefinitionReconciler) processClientCertificateReferences(
 	log logr.Logger,
 	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
 ) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processClientCertificateReferences")
+	defer span.End()
+
 	log.Info("Processing client certificate references")
 
 	if len(upstreamRequestStruct.Spec.ClientCertificateRefs) != 0 {
 		clientCerts := make([]string, 0)
 
 		for _, secretName := range upstreamRequestStruct.Spec.ClientCertificateRefs {
-			tykCertID, err := r.checkSecretAndUpload(ctx, secretName, upstreamRequestStruct.Namespace, log, env)
+			tykCertID, err := r.checkSecretAndUpload(ctx, secretName, upstreamRequestStruct, log, env)
 			if err != nil {
 				// we should log the missing secret, but we should still create the API definition
 				log.Error(
@@ -309,10 +553,13 @@ func (r *ApiDefinitionReconciler) processCertificateReferences(
 	log logr.Logger,
 	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
 ) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processCertificateReferences")
+	defer span.End()
+
 	// we support only one certificate secret name for mvp
 	if len(upstreamRequestStruct.Spec.CertificateSecretNames) != 0 {
 		if certName := upstreamRequestStruct.Spec.CertificateSecretNames[0]; certName != "" {
-			tykCertID, err := r.checkSecretAndUpload(ctx, certName, upstreamRequestStruct.Namespace, log, env)
+			tykCertID, err := r.checkSecretAndUpload(ctx, certName, upstreamRequestStruct, log, env)
 			if err != nil {
 				return err
 			}
@@ -332,6 +579,9 @@ func (r *ApiDefinitionReconciler) processPinnedPublicKeyReferences(
 	log logr.Logger,
 	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
 ) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processPinnedPublicKeyReferences")
+	defer span.End()
+
 	if len(upstreamRequestStruct.Spec.PinnedPublicKeysRefs) != 0 {
 		if upstreamRequestStruct.Spec.PinnedPublicKeys == nil {
 			upstreamRequestStruct.Spec.PinnedPublicKeys = map[string]string{}
@@ -339,7 +589,7 @@ func (r *ApiDefinitionReconciler) processPinnedPublicKeyReferences(
 
 		for domain, secretName := range upstreamRequestStruct.Spec.PinnedPublicKeysRefs {
 			// Set the namespace for referenced secret to the current namespace where ApiDefinition lives.
-			tykCertID, err := r.checkSecretAndUpload(ctx, secretName, upstreamRequestStruct.Namespace, log, env)
+			tykCertID, err := r.checkSecretAndUpload(ctx, secretName, upstreamRequestStruct, log, env)
 			if err != nil {
 				// we should log the missing secret, but we should still create the API definition
 				log.Error(
@@ -364,9 +614,12 @@ func (r *ApiDefinitionReconciler) processUpstreamCertificateReferences(
 	log logr.Logger,
 	upstreamRequestStruct *tykv1alpha1.ApiDefinition,
 ) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processUpstreamCertificateReferences")
+	defer span.End()
+
 	if len(upstreamRequestStruct.Spec.UpstreamCertificateRefs) != 0 {
 		for domain, certName := range upstreamRequestStruct.Spec.UpstreamCertificateRefs {
-			tykCertID, err := r.checkSecretAndUpload(ctx, certName, upstreamRequestStruct.Namespace, log, env)
+			tykCertID, err := r.checkSecretAndUpload(ctx, certName, upstreamRequestStruct, log, env)
 			if err != nil {
 				// we should log the missing secret, but we should still create the API definition
 				log.Info(fmt.Sprintf("cert name %s is missing", certName), "error", err)
@@ -400,7 +653,15 @@ func decodeID(encodedID string) (namespace, name string) {
 	return namespacedName[0], namespacedName[1]
 }
 
-func uploadCert(ctx context.Context, orgID string, pemKeyBytes, pemCrtBytes []byte) (tykCertID string, err error) {
+// uploadCert uploads the certificate to Tyk, unless it is already there, and
+// returns its Tyk ID. The upload is recorded as an event of o.
+func uploadCert(
+	ctx context.Context,
+	recorder record.EventRecorder,
+	o runtime.Object,
+	orgID string,
+	pemKeyBytes, pemCrtBytes []byte,
+) (tykCertID string, err error) {
 	fingerprint, err := cert.CalculateFingerPrint(pemCrtBytes)
 	if err != nil {
 		return "", err
@@ -415,6 +676,8 @@ func uploadCert(ctx context.Context, orgID string, pemKeyBytes, pemCrtBytes []by
 		if err != nil {
 			return "", err
 		}
+
+		recordEvent(recorder, o, v1.EventTypeNormal, ReasonCertificateUploaded, "Uploaded certificate %s to Tyk", tykCertID)
 	}
 
 	return tykCertID, nil
@@ -423,13 +686,13 @@ func uploadCert(ctx context.Context, orgID string, pemKeyBytes, pemCrtBytes []by
 func (r *ApiDefinitionReconciler) checkSecretAndUpload(
 	ctx context.Context,
 	certName string,
-	ns string,
+	desired *tykv1alpha1.ApiDefinition,
 	log logr.Logger,
 	env *environment.Env,
 ) (string, error) {
 	secret := v1.Secret{}
 
-	err := r.Get(ctx, types.NamespacedName{Name: certName, Namespace: ns}, &secret)
+	err := r.Get(ctx, types.NamespacedName{Name: certName, Namespace: desired.Namespace}, &secret)
 	if err != nil {
 		log.Error(err, "requeueing because secret not found")
 		return "", err
@@ -451,10 +714,13 @@ func (r *ApiDefinitionReconciler) checkSecretAndUpload(
 		return "", err
 	}
 
-	return uploadCert(ctx, env.Org, pemKeyBytes, pemCrtBytes)
+	return uploadCert(ctx, r.Recorder, desired, env.Org, pemKeyBytes, pemCrtBytes)
 }
 
 func (r *ApiDefinitionReconciler) create(ctx context.Context, desired *tykv1alpha1.ApiDefinition) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.create")
+	defer span.End()
+
 	r.Log.Info("Creating a new ApiDefinition",
 		"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
 	)
@@ -470,7 +736,7 @@ func (r *ApiDefinitionReconciler) create(ctx context.Context, desired *tykv1alph
 		return err
 	}
 
-	err = klient.Universal.HotReload(ctx)
+	err = hotReload(ctx)
 	if err != nil {
 		r.Log.Error(
 			err,
@@ -484,18 +750,95 @@ func (r *ApiDefinitionReconciler) create(ctx context.Context, desired *tykv1alph
 	return nil
 }
 
+// checkAdoptedAPIID returns an error if the ApiDefinition id of desired, adopting
+// the ApiDefinition id on Tyk, differs from id.
+func checkAdoptedAPIID(desired *tykv1alpha1.ApiDefinition, id string) error {
+	if desired.Spec.APIID != nil && *desired.Spec.APIID != "" && *desired.Spec.APIID != id {
+		return fmt.Errorf("api_id %q differs from the adopted ApiDefinition %q: %w",
+			*desired.Spec.APIID, id, ErrAdoptionFailed)
+	}
+
+	if desired.Status.ApiID != "" && desired.Status.ApiID != id {
+		return fmt.Errorf("ApiDefinition %q was applied, it can't adopt ApiDefinition %q: %w",
+			desired.Status.ApiID, id, ErrAdoptionFailed)
+	}
+
+	return nil
+}
+
+// adopt takes over the ApiDefinition on Tyk with the id of desired, updating it
+// to match desired. It is never created.
+func (r *ApiDefinitionReconciler) adopt(ctx context.Context, desired *tykv1alpha1.ApiDefinition) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.adopt")
+	defer span.End()
+
+	id := *desired.Spec.APIID
+
+	r.Log.Info("Adopting ApiDefinition",
+		"ApiDefinition", client.ObjectKeyFromObject(desired).String(), "api_id", id,
+	)
+
+	apiDefOnTyk, err := klient.Universal.Api().Get(ctx, id)
+	if err != nil {
+		if tykClient.IsNotFound(err) {
+			return fmt.Errorf("ApiDefinition %s not found on Tyk: %w", id, ErrAdoptionFailed)
+		}
+
+		return err
+	}
+
+	fields := driftedFields(desired.Spec.APIDefinitionSpec, apiDefOnTyk)
+
+	_, err = klient.Universal.Api().Update(ctx, &desired.Spec.APIDefinitionSpec)
+	if err != nil {
+		r.Log.Error(
+			err, "Failed to update adopted ApiDefinition on Tyk",
+			"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
+		)
+
+		return err
+	}
+
+	err = hotReload(ctx)
+	if err != nil {
+		r.Log.Error(
+			err,
+			"Failed to hot-reload Tyk after adopting the ApiDefinition",
+			"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
+		)
+
+		return err
+	}
+
+	r.Recorder.Eventf(desired, v1.EventTypeNormal, "Adopted",
+		"Adopted ApiDefinition %s from Tyk, %s", id, adoptionSummary(fields))
+
+	return nil
+}
+
 func (r *ApiDefinitionReconciler) update(ctx context.Context, desired *tykv1alpha1.ApiDefinition) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.update")
+	defer span.End()
+
 	r.Log.Info("Updating ApiDefinition",
 		"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
 	)
 
 	apiDefOnTyk, err := klient.Universal.Api().Get(ctx, desired.Status.ApiID)
 	if err != nil {
-		if t, ok := err.(*url.Error); ok {
-			r.Log.Info(fmt.Sprintf("Connection error: %s %s: %v", t.Op, t.URL, t.Err))
+		// Creating the ApiDefinition is pointless if Tyk can't be reached or
+		// rejects our credentials, it would fail the same way.
+		if tykClient.IsRetryable(err) || tykClient.IsUnauthorized(err) {
+			r.Log.Info("Failed to get ApiDefinition from Tyk, skipping update", "error", err.Error())
 			return err
 		}
 
+		// The adopted ApiDefinition was deleted from Tyk, creating it again
+		// would lose what was bound to it.
+		if _, ok := adoptID(desired); ok && tykClient.IsNotFound(err) {
+			return fmt.Errorf("ApiDefinition %s was deleted from Tyk: %w", desired.Status.ApiID, ErrAdoptionFailed)
+		}
+
 		_, err = klient.Universal.Api().Create(ctx, &desired.Spec.APIDefinitionSpec)
 		if err != nil {
 			r.Log.Error(
@@ -509,9 +852,32 @@ func (r *ApiDefinitionReconciler) update(ctx context.Context, desired *tykv1alph
 		// If we have same ApiDefinition on Tyk, we do not need to send Update and Hot Reload requests
 		// to Tyk. So, we can simply return to main reconciliation logic.
 		if isSame(desired.Status.LatestTykSpecHash, apiDefOnTyk) && isSame(desired.Status.LatestCRDSpecHash, desired.Spec) {
+			if meta.IsStatusConditionTrue(desired.Status.Conditions, tykv1alpha1.ConditionDrifted) {
+				return r.setDrifted(ctx, desired, false, nil)
+			}
+
 			return nil
 		}
 
+		if drifted(desired.Status.LatestTykSpecHash, desired.Status.LatestCRDSpecHash, desired.Spec, apiDefOnTyk) {
+			fields := driftedFields(desired.Spec.APIDefinitionSpec, apiDefOnTyk)
+
+			switch driftPolicy(ctx) {
+			case tykv1alpha1.DriftIgnore:
+				return nil
+			case tykv1alpha1.DriftReport:
+				r.Log.Info("ApiDefinition was changed on Tyk", "drift", driftSummary(fields))
+				r.Recorder.Eventf(desired, v1.EventTypeWarning, "Drifted",
+					"ApiDefinition was changed on Tyk: %s", driftSummary(fields))
+
+				return r.setDrifted(ctx, desired, true, fields)
+			}
+
+			r.Log.Info("Reverting changes made on Tyk", "drift", driftSummary(fields))
+			r.Recorder.Eventf(desired, v1.EventTypeNormal, "DriftCorrected",
+				"Reverted changes made on Tyk: %s", driftSummary(fields))
+		}
+
 		_, err = klient.Universal.Api().Update(ctx, &desired.Spec.APIDefinitionSpec)
 		if err != nil {
 			r.Log.Error(
@@ -523,7 +889,7 @@ func (r *ApiDefinitionReconciler) update(ctx context.Context, desired *tykv1alph
 		}
 	}
 
-	err = klient.Universal.HotReload(ctx)
+	err = hotReload(ctx)
 	if err != nil {
 		r.Log.Error(
 			err,
@@ -547,6 +913,7 @@ func (r *ApiDefinitionReconciler) update(ctx context.Context, desired *tykv1alph
 		func(status *tykv1alpha1.ApiDefinitionStatus) {
 			status.LatestTykSpecHash = calculateHash(apiOnTyk)
 			status.LatestCRDSpecHash = calculateHash(desired.Spec)
+			setDrifted(&status.Conditions, desired.Generation, false, nil)
 		},
 	)
 	if err != nil {
@@ -562,11 +929,30 @@ func (r *ApiDefinitionReconciler) update(ctx context.Context, desired *tykv1alph
 	return nil
 }
 
+// setDrifted records in the Drifted condition of desired whether its object on
+// Tyk was changed outside of the operator.
+func (r *ApiDefinitionReconciler) setDrifted(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiDefinition,
+	drift bool,
+	fields []string,
+) error {
+	namespace := desired.Namespace
+	target := model.Target{Namespace: &namespace, Name: desired.Name}
+
+	return r.updateStatus(ctx, desired.Namespace, target, false, func(status *tykv1alpha1.ApiDefinitionStatus) {
+		setDrifted(&status.Conditions, desired.Generation, drift, fields)
+	})
+}
+
 // This triggers an update to all ingress resources that have template
 // annotation matching a.Name.
 //
 // We return nil when a is being deleted and do nothing.
 func (r *ApiDefinitionReconciler) syncTemplate(ctx context.Context, ns string, a *tykv1alpha1.ApiDefinition) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.syncTemplate")
+	defer span.End()
+
 	if !a.DeletionTimestamp.IsZero() {
 		if util.ContainsFinalizer(a, keys.ApiDefTemplateFinalizerName) {
 			ls := netv1.IngressList{}
@@ -641,7 +1027,17 @@ func (r *ApiDefinitionReconciler) syncTemplate(ctx context.Context, ns string, a
 	return ctrl.Result{}, nil
 }
 
-func (r *ApiDefinitionReconciler) delete(ctx context.Context, desired *tykv1alpha1.ApiDefinition) (time.Duration, error) {
+// delete deletes desired from Tyk and removes its links with other resources.
+// paused is the reason its reconciliation is paused, empty if it is not, its
+// API is then left on Tyk.
+func (r *ApiDefinitionReconciler) delete(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiDefinition,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.delete")
+	defer span.End()
+
 	r.Log.Info("ApiDefinition being deleted",
 		"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
 	)
@@ -676,40 +1072,19 @@ func (r *ApiDefinitionReconciler) delete(ctx context.Context, desired *tykv1alph
 			return queueAfter, err
 		}
 
-		r.Log.Info("Deleting an ApiDefinition from Tyk", "ApiDefinition ID", desired.Status.ApiID)
+		switch {
+		case paused != "":
+			leaveOnTyk(r.Recorder, r.Log, desired, paused)
+		case retained(desired):
+			r.Log.Info("Retaining ApiDefinition on Tyk", "ApiDefinition ID", desired.Status.ApiID)
 
-		_, err = klient.Universal.Api().Delete(ctx, desired.Status.ApiID)
-		if err != nil && tykClient.IsNotFound(err) {
-			r.Log.Info(
-				"Ignoring nonexistent ApiDefinition on delete",
-				"api_id", desired.Status.ApiID,
-				"err", err,
-			)
-		} else if err != nil {
-			// If the ApiDefinition does not exist on Tyk, no need to reconcile with error.
-			// Older versions of GW does not return 404 while deleting non-existent ApiDefinitions.
-			// Therefore, check if ApiDefinition exists on Tyk before returning with error. If ApiDefinition
-			// exists, which means Get call returns successful response, Operator should reconcile to complete
-			// deletion of the ApiDefinition.
-			_, errTyk := klient.Universal.Api().Get(ctx, desired.Status.ApiID)
-			if errTyk == nil {
-				r.Log.Error(
-					err,
-					"Failed to delete ApiDefinition from Tyk", "api_id", desired.Status.ApiID,
-				)
+			if err := unstampAPI(ctx, desired.Status.ApiID); err != nil {
+				return queueAfter, err
+			}
+		default:
+			if err := r.deleteFromTyk(ctx, desired); err != nil {
 				return queueAfter, err
 			}
-		}
-
-		err = klient.Universal.HotReload(ctx)
-		if err != nil {
-			r.Log.Error(
-				err,
-				"Failed to hot-reload Tyk after deleting the ApiDefinition",
-				"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
-			)
-
-			return queueAfter, err
 		}
 
 		util.RemoveFinalizer(desired, keys.ApiDefFinalizerName)
@@ -723,6 +1098,49 @@ func (r *ApiDefinitionReconciler) delete(ctx context.Context, desired *tykv1alph
 	return 0, nil
 }
 
+// deleteFromTyk deletes the ApiDefinition of desired from Tyk.
+func (r *ApiDefinitionReconciler) deleteFromTyk(ctx context.Context, desired *tykv1alpha1.ApiDefinition) error {
+	r.Log.Info("Deleting an ApiDefinition from Tyk", "ApiDefinition ID", desired.Status.ApiID)
+
+	_, err := klient.Universal.Api().Delete(ctx, desired.Status.ApiID)
+	if err != nil && tykClient.IsNotFound(err) {
+		r.Log.Info(
+			"Ignoring nonexistent ApiDefinition on delete",
+			"api_id", desired.Status.ApiID,
+			"err", err,
+		)
+	} else if err != nil {
+		// If the ApiDefinition does not exist on Tyk, no need to reconcile with error.
+		// Older versions of GW does not return 404 while deleting non-existent ApiDefinitions.
+		// Therefore, check if ApiDefinition exists on Tyk before returning with error. If ApiDefinition
+		// exists, which means Get call returns successful response, Operator should reconcile to complete
+		// deletion of the ApiDefinition.
+		_, errTyk := klient.Universal.Api().Get(ctx, desired.Status.ApiID)
+		if errTyk == nil || tykClient.IsOutOfSync(errTyk) {
+			r.Log.Error(
+				err,
+				"Failed to delete ApiDefinition from Tyk", "api_id", desired.Status.ApiID,
+			)
+			return err
+		}
+	}
+
+	err = hotReload(ctx)
+	if err != nil {
+		r.Log.Error(
+			err,
+			"Failed to hot-reload Tyk after deleting the ApiDefinition",
+			"ApiDefinition", client.ObjectKeyFromObject(desired).String(),
+		)
+
+		return err
+	}
+
+	recordDeleted(r.Recorder, desired, desired.Status.ApiID)
+
+	return nil
+}
+
 // checkLinkedPolicies checks if there are any policies that are still linking to this api definition resource.
 func (r *ApiDefinitionReconciler) checkLinkedPolicies(ctx context.Context, a *tykv1alpha1.ApiDefinition) error {
 	r.Log.Info("checking linked security policies")
@@ -788,6 +1206,9 @@ func (r *ApiDefinitionReconciler) ensureTargets(
 	ns string,
 	targets []model.Target,
 ) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.ensureTargets")
+	defer span.End()
+
 	for _, target := range targets {
 		var api tykv1alpha1.ApiDefinition
 
@@ -802,6 +1223,9 @@ func (r *ApiDefinitionReconciler) ensureTargets(
 func (r *ApiDefinitionReconciler) updateLoopingTargets(ctx context.Context,
 	a *tykv1alpha1.ApiDefinition, links []model.Target,
 ) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.updateLoopingTargets")
+	defer span.End()
+
 	r.Log.Info("Updating looping targets")
 
 	if len(links) == 0 {
@@ -945,6 +1369,9 @@ func (r *ApiDefinitionReconciler) breakSubgraphLink(
 }
 
 func (r *ApiDefinitionReconciler) processSubGraphExec(ctx context.Context, urs *tykv1alpha1.ApiDefinition) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processSubGraphExec")
+	defer span.End()
+
 	if urs == nil || urs.Spec.GraphQL == nil {
 		return nil
 	}
@@ -1038,6 +1465,9 @@ func (r *ApiDefinitionReconciler) processSubGraphExec(ctx context.Context, urs *
 }
 
 func (r *ApiDefinitionReconciler) processSuperGraphExec(ctx context.Context, urs *tykv1alpha1.ApiDefinition) error {
+	ctx, span := tracing.Start(ctx, "ApiDefinition.processSuperGraphExec")
+	defer span.End()
+
 	if urs.Spec.GraphQL.GraphRef == nil || *urs.Spec.GraphQL.GraphRef == "" {
 		return errors.New("GraphRef is not set")
 	}
@@ -1150,21 +1580,56 @@ func (r *ApiDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&tykv1alpha1.ApiDefinition{},
+		EventWebhookKey,
+		func(rawObj client.Object) []string {
+			apiDef, ok := rawObj.(*tykv1alpha1.ApiDefinition)
+			if !ok {
+				return nil
+			}
+
+			var refs []string
+			for _, t := range apiDef.Spec.EventWebhookRefs {
+				refs = append(refs, t.NS(apiDef.Namespace).String())
+			}
+
+			return refs
+		})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		For(&tykv1alpha1.ApiDefinition{}).
-		Owns(&v1.Secret{}).
+		For(
+			&tykv1alpha1.ApiDefinition{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Owns(&v1.Secret{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(
 			&source.Kind{Type: &tykv1alpha1.SubGraph{}},
 			handler.EnqueueRequestsFromMapFunc(r.findGraphsForApiDefinition),
-			builder.WithPredicates(r.ignoreGraphCreationEvents()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.ignoreGraphCreationEvents()),
 		).
 		Watches(
 			&source.Kind{Type: &tykv1alpha1.SuperGraph{}},
 			handler.EnqueueRequestsFromMapFunc(r.findGraphsForApiDefinition),
-			builder.WithPredicates(r.ignoreGraphCreationEvents()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.ignoreGraphCreationEvents()),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.ApiEventWebhook{}},
+			handler.EnqueueRequestsFromMapFunc(r.findApiDefinitionsForEventWebhook),
+			builder.WithPredicates(webhookApplied),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedApiDefinitions
+			}),
+			builder.WithPredicates(contextUpdated),
 		).
-		Complete(r)
+		Complete(tracing.Reconciler("ApiDefinition", r))
 }
 
 func (r *ApiDefinitionReconciler) ignoreGraphCreationEvents() predicate.Predicate {
@@ -5,19 +5,24 @@ import (
 	"encoding/base64"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TykTechnologies/tyk-operator/api/model"
 	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
 
 	"github.com/matryer/is"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestUpdatingLoopingTargets(t *testing.T) {
@@ -420,3 +425,148 @@ func TestProcessSubGraphExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestApiDefinitionReconcile(t *testing.T) {
+	servers := map[string]*fake.Server{
+		"gateway":   fake.NewGateway(),
+		"dashboard": fake.NewDashboard(),
+	}
+
+	for name, s := range servers {
+		s := s
+		defer s.Close()
+
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			api := &tykv1alpha1.ApiDefinition{
+				ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+				Spec: tykv1alpha1.APIDefinitionSpec{
+					APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+				},
+			}
+
+			cl, err := NewFakeClient([]runtime.Object{api})
+			is.NoErr(err)
+
+			r := ApiDefinitionReconciler{
+				Client: cl,
+				Log:    log.NullLogger{},
+				Env:    s.Env(),
+			}
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+
+			_, err = r.Reconcile(context.Background(), req)
+			is.NoErr(err)
+
+			is.NoErr(cl.Get(context.Background(), req.NamespacedName, api))
+			is.Equal(api.Status.ApiID, EncodeNS("default/httpbin"))
+
+			apis := s.APIs()
+			is.Equal(len(apis), 1)
+			is.Equal(*apis[0].APIID, api.Status.ApiID)
+			is.Equal(*apis[0].OrgID, fake.Org)
+
+			is.NoErr(cl.Delete(context.Background(), api))
+
+			_, err = r.Reconcile(context.Background(), req)
+			is.NoErr(err)
+			is.Equal(len(s.APIs()), 0)
+		})
+	}
+}
+
+func TestApiDefinitionBatchedReload(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	var objects []runtime.Object
+
+	for _, name := range []string{"a", "b", "c"} {
+		objects = append(objects, &tykv1alpha1.ApiDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: tykv1alpha1.APIDefinitionSpec{
+				APIDefinitionSpec: model.APIDefinitionSpec{Name: name},
+			},
+		})
+	}
+
+	cl, err := NewFakeClient(objects)
+	is.NoErr(err)
+
+	env := s.Env()
+	env.HotReloadWindow = &v1.Duration{Duration: 50 * time.Millisecond}
+
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+	ctx := context.Background()
+
+	reconcileAll := func(pending bool) {
+		for _, o := range objects {
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o.(client.Object))}
+
+			res, err := r.Reconcile(ctx, req)
+			is.NoErr(err)
+
+			api := &tykv1alpha1.ApiDefinition{}
+			is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+			is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+
+			c := meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionReady)
+			if pending {
+				is.Equal(res.RequeueAfter, reloadVerificationInterval)
+				is.Equal(c.Reason, ReasonReloadPending)
+			} else {
+				is.Equal(c.Status, v1.ConditionTrue)
+			}
+		}
+	}
+
+	// The reconciliations don't wait for the batch, they read its result once
+	// they are requeued.
+	reconcileAll(true)
+	is.Equal(s.Reloads(), 0)
+
+	time.Sleep(100 * time.Millisecond)
+	is.Equal(s.Reloads(), 1)
+
+	reconcileAll(false)
+	is.Equal(s.Reloads(), 1)
+
+	// A failed reload is reported and requested again.
+	s.FailReloads("gateway is shutting down")
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(objects[0].(client.Object))}
+
+	api := &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+
+	api.Spec.Name = "a-v2"
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(err != nil)
+	is.Equal(s.Reloads(), 2)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+	is.True(strings.Contains(api.Status.LatestTransaction.Error, "gateway is shutting down"))
+
+	s.FailReloads("")
+	time.Sleep(100 * time.Millisecond)
+	is.Equal(s.Reloads(), 3)
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(meta.IsStatusConditionTrue(api.Status.Conditions, tykv1alpha1.ConditionReady))
+}
@@ -23,9 +23,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
@@ -33,6 +36,7 @@ import (
 	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 )
 
 // APIDescriptionReconciler reconciles a APIDescription object
@@ -42,6 +46,7 @@ type APIDescriptionReconciler struct {
 	Scheme    *runtime.Scheme
 	Universal universal.Client
 	Env       environment.Env
+	Recorder  record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=apidescriptions,verbs=get;list;watch;create;update;patch;delete
@@ -68,11 +73,18 @@ func (r *APIDescriptionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return
 	}
 	// set context for all api calls inside this reconciliation loop
-	_, ctx, err = HttpContext(ctx, r.Client, &r.Env, desired, log)
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// An APIDescription is deleted without calling Tyk, the pause does not
+	// hold its deletion.
+	if reason, ok := pauseReason(desired, env); ok && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
 	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
 		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
 			return r.delete(ctx, desired, log)
@@ -83,6 +95,15 @@ func (r *APIDescriptionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.sync(ctx, desired, log)
 	})
 
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		recordSync(r.Recorder, desired, desired.Status.Conditions, err)
+		setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, err)
+
+		if errK8s := r.Status().Update(ctx, desired); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
 	return
 }
 
@@ -185,5 +206,12 @@ func (r *APIDescriptionReconciler) sync(ctx context.Context, desired *v1alpha1.A
 func (r *APIDescriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tykv1alpha1.APIDescription{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedApiDescriptions
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("APIDescription", r))
 }
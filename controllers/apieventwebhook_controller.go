@@ -0,0 +1,395 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ApiEventWebhookReconciler reconciles a ApiEventWebhook object
+type ApiEventWebhookReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apieventwebhooks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apieventwebhooks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apieventwebhooks/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+
+func (r *ApiEventWebhookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ApiEventWebhook", req.NamespacedName.String())
+
+	log.Info("Reconciling ApiEventWebhook instance")
+
+	desired := &tykv1alpha1.ApiEventWebhook{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused ApiEventWebhook being deleted leaves its webhook on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var targetURL string
+
+	var hook *model.WebHookHandlerConf
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, env, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		util.AddFinalizer(desired, keys.ApiEventWebhookFinalizerName)
+
+		var err error
+
+		targetURL, err = webhookURL(ctx, r.Client, desired)
+		if err != nil {
+			return err
+		}
+
+		// The gateway reads webhooks from the event handlers of the APIs.
+		if env.Mode != "pro" {
+			return nil
+		}
+
+		conf := webhookConf(desired, targetURL)
+		conf.ID = desired.Status.WebhookID
+		hook = &conf
+
+		return r.createOrUpdate(ctx, desired, hook)
+	})
+
+	if err == nil {
+		log.Info("Completed reconciling ApiEventWebhook instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, targetURL, hook, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	return requeue(queueA, err)
+}
+
+// webhookURL returns the URL called by the webhook of desired, the cluster URL
+// of its Service if one is referenced.
+func webhookURL(ctx context.Context, c client.Client, desired *tykv1alpha1.ApiEventWebhook) (string, error) {
+	ref := desired.Spec.Service
+	if ref == nil {
+		if desired.Spec.TargetURL == "" {
+			return "", fmt.Errorf("one of targetURL or service must be set")
+		}
+
+		return desired.Spec.TargetURL, nil
+	}
+
+	key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = desired.Namespace
+	}
+
+	var svc v1.Service
+	if err := c.Get(ctx, key, &svc); err != nil {
+		return "", fmt.Errorf("failed to get Service %s: %w", key, err)
+	}
+
+	port := ref.Port
+
+	switch {
+	case port == 0 && len(svc.Spec.Ports) == 0:
+		return "", fmt.Errorf("Service %s has no port", key)
+	case port == 0:
+		port = svc.Spec.Ports[0].Port
+	case !hasPort(&svc, port):
+		return "", fmt.Errorf("Service %s has no port %d", key, port)
+	}
+
+	scheme := ref.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s.%s.svc:%d/%s", scheme, key.Name, key.Namespace, port,
+		strings.TrimPrefix(ref.Path, "/")), nil
+}
+
+func hasPort(svc *v1.Service, port int32) bool {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port {
+			return true
+		}
+	}
+
+	return false
+}
+
+// webhookConf returns the configuration of the webhook of desired calling
+// targetURL. It is named after desired on the dashboard.
+func webhookConf(desired *tykv1alpha1.ApiEventWebhook, targetURL string) model.WebHookHandlerConf {
+	method := desired.Spec.Method
+	if method == "" {
+		method = "POST"
+	}
+
+	return model.WebHookHandlerConf{
+		Name:         client.ObjectKeyFromObject(desired).String(),
+		Disabled:     desired.Spec.Disabled,
+		Method:       method,
+		TargetPath:   targetURL,
+		TemplatePath: desired.Spec.TemplatePath,
+		HeaderMap:    desired.Spec.Headers,
+		EventTimeout: desired.Spec.EventTimeout,
+	}
+}
+
+// createOrUpdate creates or updates hook on the dashboard. hook.ID is set to
+// the id of the webhook once created.
+func (r *ApiEventWebhookReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiEventWebhook,
+	hook *model.WebHookHandlerConf,
+) error {
+	ctx, span := tracing.Start(ctx, "ApiEventWebhook.createOrUpdate")
+	defer span.End()
+
+	if hook.ID != "" {
+		_, err := klient.Universal.Webhooks().Get(ctx, hook.ID)
+
+		switch {
+		case err == nil:
+			if isSame(desired.Status.LatestCRDSpecHash, hook) {
+				return nil
+			}
+
+			r.Log.Info("Updating ApiEventWebhook", "id", hook.ID)
+
+			return klient.Universal.Webhooks().Update(ctx, hook)
+		case !tykClient.IsNotFound(err):
+			return err
+		}
+
+		r.Log.Info("ApiEventWebhook not found on the dashboard, creating a new one", "id", hook.ID)
+	}
+
+	r.Log.Info("Creating ApiEventWebhook")
+
+	hook.ID = ""
+
+	id, err := klient.Universal.Webhooks().Create(ctx, hook)
+	if err != nil {
+		r.Log.Error(err, "Failed to create ApiEventWebhook")
+		return err
+	}
+
+	hook.ID = id
+
+	return nil
+}
+
+// referencedBy returns the ApiDefinition resources referencing desired.
+func (r *ApiEventWebhookReconciler) referencedBy(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiEventWebhook,
+) ([]model.Target, error) {
+	key := client.ObjectKeyFromObject(desired)
+
+	var apis tykv1alpha1.ApiDefinitionList
+	if err := r.List(ctx, &apis, client.MatchingFields{EventWebhookKey: key.String()}); err != nil {
+		return nil, err
+	}
+
+	var o []model.Target
+
+	for i := range apis.Items {
+		api := &apis.Items[i]
+
+		for _, t := range api.Spec.EventWebhookRefs {
+			if t.NS(api.Namespace) == key {
+				namespace := api.Namespace
+				o = append(o, model.Target{Name: api.Name, Namespace: &namespace})
+
+				break
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// delete deletes the webhook of desired from the dashboard once no
+// ApiDefinition references it. paused is the reason its reconciliation is
+// paused, empty if it is not, the webhook is then left on Tyk.
+func (r *ApiEventWebhookReconciler) delete(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.ApiEventWebhook,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "ApiEventWebhook.delete")
+	defer span.End()
+
+	r.Log.Info("ApiEventWebhook being deleted",
+		"ApiEventWebhook", client.ObjectKeyFromObject(desired).String(),
+	)
+
+	if !util.ContainsFinalizer(desired, keys.ApiEventWebhookFinalizerName) {
+		return 0, nil
+	}
+
+	apis, err := r.referencedBy(ctx, desired)
+	if err != nil {
+		return queueAfter, err
+	}
+
+	if len(apis) != 0 {
+		err := fmt.Errorf("ApiEventWebhook is still referenced by ApiDefinition %s", apis[0].String())
+		r.Log.Error(err, "Cannot delete ApiEventWebhook")
+
+		return queueAfter, err
+	}
+
+	switch {
+	case env.Mode != "pro" || desired.Status.WebhookID == "":
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+	default:
+		err := klient.Universal.Webhooks().Delete(ctx, desired.Status.WebhookID)
+		if tykClient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "Failed to delete ApiEventWebhook", "id", desired.Status.WebhookID)
+			return queueAfter, err
+		}
+
+		recordDeleted(r.Recorder, desired, desired.Status.WebhookID)
+	}
+
+	util.RemoveFinalizer(desired, keys.ApiEventWebhookFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. hook is the webhook sent to the dashboard, nil if it was not.
+func (r *ApiEventWebhookReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiEventWebhook,
+	targetURL string,
+	hook *model.WebHookHandlerConf,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	if err != nil {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	if targetURL != "" {
+		status.TargetURL = targetURL
+	}
+
+	if hook != nil && hook.ID != "" {
+		status.WebhookID = hook.ID
+
+		if err == nil {
+			status.LatestCRDSpecHash = calculateHash(hook)
+		}
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// webhookApplied lets through the changes of ApiEventWebhooks, and the updates
+// of their status once applied to Tyk, which do not change their generation:
+// the ApiDefinitions using a webhook wait for its id.
+var webhookApplied = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		o, okOld := e.ObjectOld.(*tykv1alpha1.ApiEventWebhook)
+		n, okNew := e.ObjectNew.(*tykv1alpha1.ApiEventWebhook)
+
+		return okOld && okNew && (o.Status.WebhookID != n.Status.WebhookID ||
+			o.Status.TargetURL != n.Status.TargetURL)
+	},
+})
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ApiEventWebhookReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.ApiEventWebhook{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedApiEventWebhooks
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("ApiEventWebhook", r))
+}
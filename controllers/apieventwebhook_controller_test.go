@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiEventWebhookReconcile(t *testing.T) {
+	for _, mode := range []string{"ce", "pro"} {
+		mode := mode
+
+		t.Run(mode, func(t *testing.T) {
+			is := is.New(t)
+
+			s := fake.NewGateway()
+			if mode == "pro" {
+				s = fake.NewDashboard()
+			}
+			defer s.Close()
+
+			svc := &corev1.Service{
+				ObjectMeta: v1.ObjectMeta{Name: "notify", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			}
+			hook := &tykv1alpha1.ApiEventWebhook{
+				ObjectMeta: v1.ObjectMeta{Name: "notify", Namespace: "default"},
+				Spec: tykv1alpha1.ApiEventWebhookSpec{
+					Service: &tykv1alpha1.WebhookServiceReference{Name: "notify", Path: "/events"},
+					Method:  "POST",
+					Headers: map[string]string{"X-Env": "test"},
+					Events:  []model.TykEvent{model.EventQuotaExceeded, model.EventKeyExpired},
+				},
+			}
+			api := &tykv1alpha1.ApiDefinition{
+				ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+				Spec: tykv1alpha1.APIDefinitionSpec{
+					APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+					EventWebhookRefs:  []model.Target{{Name: "notify"}},
+				},
+			}
+
+			cl, err := NewFakeClient([]runtime.Object{svc, hook, api})
+			is.NoErr(err)
+
+			ctx := context.Background()
+
+			hooks := &ApiEventWebhookReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+			apis := &ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+
+			_, err = hooks.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hook)})
+			is.NoErr(err)
+			is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(hook), hook))
+
+			targetURL := "http://notify.default.svc:8080/events"
+			is.Equal(hook.Status.TargetURL, targetURL)
+
+			if mode == "pro" {
+				is.Equal(s.Webhooks()[hook.Status.WebhookID].TargetPath, targetURL)
+			}
+
+			_, err = apis.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)})
+			is.NoErr(err)
+
+			defs := s.APIs()
+			is.Equal(len(defs), 1)
+			is.True(defs[0].EventHandlers != nil)
+
+			for _, e := range hook.Spec.Events {
+				triggers := defs[0].EventHandlers.Events[e]
+				is.Equal(len(triggers), 1)
+				is.Equal(triggers[0].Handler, model.WebHookHandler)
+				is.Equal(triggers[0].HandlerMeta.TargetPath, targetURL)
+				is.Equal(triggers[0].HandlerMeta.HeaderMap, map[string]string{"X-Env": "test"})
+				is.Equal(triggers[0].HandlerMeta.ID, hook.Status.WebhookID)
+			}
+
+			// the webhook can not be deleted while the ApiDefinition references it
+			is.NoErr(cl.Delete(ctx, hook))
+
+			_, err = hooks.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hook)})
+			is.True(err != nil)
+			is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(hook), hook))
+		})
+	}
+}
+
+func TestApiEventWebhookMissingService(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	hook := &tykv1alpha1.ApiEventWebhook{
+		ObjectMeta: v1.ObjectMeta{Name: "notify", Namespace: "default"},
+		Spec: tykv1alpha1.ApiEventWebhookSpec{
+			Service: &tykv1alpha1.WebhookServiceReference{Name: "notify", Port: 9090},
+			Events:  []model.TykEvent{model.EventBreakerTriggered},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{hook})
+	is.NoErr(err)
+
+	r := &ApiEventWebhookReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hook)})
+	is.True(err != nil)
+
+	is.NoErr(cl.Get(context.Background(), client.ObjectKeyFromObject(hook), hook))
+	is.Equal(hook.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+}
+
+func TestWebhookApplied(t *testing.T) {
+	is := is.New(t)
+
+	old := &tykv1alpha1.ApiEventWebhook{ObjectMeta: v1.ObjectMeta{Name: "alerts", Generation: 1}}
+
+	applied := old.DeepCopy()
+	applied.Status.WebhookID = "5e0fac48"
+
+	changed := old.DeepCopy()
+	changed.Generation = 2
+
+	synced := old.DeepCopy()
+	synced.Status.ObservedGeneration = 1
+
+	// The status update of a webhook applied to Tyk reaches the ApiDefinitions
+	// waiting for its id.
+	is.True(webhookApplied.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: applied}))
+	is.True(webhookApplied.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: changed}))
+	is.True(!webhookApplied.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: synced}))
+}
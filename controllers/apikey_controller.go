@@ -0,0 +1,536 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ApiKeyReconciler reconciles a ApiKey object
+type ApiKeyReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=apikeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ApiKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ApiKey", req.NamespacedName.String())
+
+	log.Info("Reconciling ApiKey instance")
+
+	desired := &tykv1alpha1.ApiKey{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused ApiKey being deleted leaves its key on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var session *model.SessionState
+
+	var hash string
+
+	var written bool
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		util.AddFinalizer(desired, keys.ApiKeyFinalizerName)
+
+		session, err = r.session(ctx, &env, desired)
+		if err != nil {
+			return err
+		}
+
+		hash, written, err = r.createOrUpdate(ctx, desired, session)
+
+		return err
+	})
+
+	if err == nil {
+		log.Info("Completed reconciling ApiKey instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, session, hash, written, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	return requeue(queueA, err)
+}
+
+// session returns the key sent to Tyk, with the policies and the APIs desired
+// refers to resolved to their Tyk ids.
+func (r *ApiKeyReconciler) session(
+	ctx context.Context,
+	env *environment.Env,
+	desired *tykv1alpha1.ApiKey,
+) (*model.SessionState, error) {
+	spec := desired.Spec
+
+	session := &model.SessionState{
+		OrgID:            env.Org,
+		Alias:            spec.Alias,
+		Rate:             spec.Rate,
+		Per:              spec.Per,
+		QuotaMax:         spec.QuotaMax,
+		QuotaRenewalRate: spec.QuotaRenewalRate,
+		Tags:             spec.Tags,
+		MetaData:         spec.MetaData,
+	}
+
+	if session.Alias == "" {
+		session.Alias = client.ObjectKeyFromObject(desired).String()
+	}
+
+	if spec.Expires != nil {
+		session.Expires = spec.Expires.Unix()
+	}
+
+	for _, t := range spec.Policies {
+		key := t.NS(desired.Namespace)
+
+		var policy tykv1alpha1.SecurityPolicy
+		if err := r.Get(ctx, key, &policy); err != nil {
+			return nil, fmt.Errorf("failed to get SecurityPolicy %s: %w", key, err)
+		}
+
+		if policy.Status.PolID == "" {
+			return nil, fmt.Errorf("SecurityPolicy %s is not created on Tyk yet", key)
+		}
+
+		session.ApplyPolicies = append(session.ApplyPolicies, policy.Status.PolID)
+	}
+
+	for _, ad := range spec.AccessRights {
+		key := types.NamespacedName{Namespace: ad.Namespace, Name: ad.Name}
+		if key.Namespace == "" {
+			key.Namespace = desired.Namespace
+		}
+
+		var api tykv1alpha1.ApiDefinition
+		if err := r.Get(ctx, key, &api); err != nil {
+			return nil, fmt.Errorf("failed to get ApiDefinition %s: %w", key, err)
+		}
+
+		if api.Status.ApiID == "" {
+			return nil, fmt.Errorf("ApiDefinition %s is not created on Tyk yet", key)
+		}
+
+		access := *ad.DeepCopy()
+		access.APIID = &api.Status.ApiID
+		access.APIName = &api.Spec.Name
+
+		if session.AccessRights == nil {
+			session.AccessRights = make(map[string]model.AccessDefinition)
+		}
+
+		session.AccessRights[api.Status.ApiID] = access
+	}
+
+	return session, nil
+}
+
+// secretName returns the name of the Secret the key of desired is written into.
+func secretName(desired *tykv1alpha1.ApiKey) string {
+	if desired.Spec.SecretName != "" {
+		return desired.Spec.SecretName
+	}
+
+	return desired.Name
+}
+
+// key returns the value of the key held by the Secret of desired, an empty
+// string if there is none, and of the key it rotated out if that one is not
+// revoked yet.
+func (r *ApiKeyReconciler) key(ctx context.Context, desired *tykv1alpha1.ApiKey) (key, previous string, err error) {
+	name := desired.Status.SecretName
+	if name == "" {
+		name = secretName(desired)
+	}
+
+	var secret v1.Secret
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: name}, &secret)
+	if err != nil {
+		return "", "", client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(&secret, desired) {
+		return "", "", nil
+	}
+
+	return string(secret.Data[tykv1alpha1.ApiKeySecretKey]),
+		string(secret.Data[tykv1alpha1.ApiKeyPreviousSecretKey]), nil
+}
+
+// writeKey writes key into the Secret of desired, along with the key it
+// rotated out unless previous is empty, and deletes the Secret previously
+// holding it if its name changed.
+func (r *ApiKeyReconciler) writeKey(ctx context.Context, desired *tykv1alpha1.ApiKey, key, previous string) error {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName(desired), Namespace: desired.Namespace},
+	}
+
+	_, err := util.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		// Never overwrite a Secret the ApiKey did not create.
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, desired) {
+			return fmt.Errorf("secret %s/%s is not owned by the ApiKey", secret.Namespace, secret.Name)
+		}
+
+		if err := util.SetControllerReference(desired, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		secret.Type = v1.SecretTypeOpaque
+		secret.Data = map[string][]byte{tykv1alpha1.ApiKeySecretKey: []byte(key)}
+
+		if previous != "" {
+			secret.Data[tykv1alpha1.ApiKeyPreviousSecretKey] = []byte(previous)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if old := desired.Status.SecretName; old != "" && old != secret.Name {
+		err := r.Delete(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: old, Namespace: desired.Namespace}})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createOrUpdate creates or updates the key of desired on Tyk, and returns the
+// hash of the key held by its Secret, empty if it is not known, and whether
+// the Secret holds a new key.
+func (r *ApiKeyReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiKey,
+	session *model.SessionState,
+) (string, bool, error) {
+	ctx, span := tracing.Start(ctx, "ApiKey.createOrUpdate")
+	defer span.End()
+
+	key, previous, err := r.key(ctx, desired)
+	if err != nil {
+		return "", false, err
+	}
+
+	// The key rotated out by a reconciliation that failed to revoke it is
+	// revoked before the key is rotated again, it would be lost otherwise.
+	if key != "" && previous != "" {
+		if err := r.revokePrevious(ctx, desired, key, previous); err != nil {
+			return "", false, err
+		}
+	}
+
+	if key != "" && desired.Spec.Rotation != desired.Status.Rotation {
+		r.Log.Info("Rotating ApiKey")
+
+		return r.create(ctx, desired, session, key)
+	}
+
+	if key == "" {
+		if err := r.revokeLost(ctx, desired); err != nil {
+			return "", false, err
+		}
+
+		r.Log.Info("Creating ApiKey")
+
+		return r.create(ctx, desired, session, "")
+	}
+
+	_, err = klient.Universal.Keys().Get(ctx, key)
+
+	switch {
+	case tykClient.IsNotFound(err):
+		r.Log.Info("ApiKey not found on Tyk, creating a new one")
+		return r.create(ctx, desired, session, "")
+	case err != nil:
+		return "", false, err
+	case isSame(desired.Status.LatestCRDSpecHash, session) && desired.Status.SecretName == secretName(desired):
+		return desired.Status.KeyHash, false, nil
+	}
+
+	r.Log.Info("Updating ApiKey")
+
+	if err := klient.Universal.Keys().Update(ctx, key, session); err != nil {
+		r.Log.Error(err, "Failed to update ApiKey on Tyk")
+		return "", false, err
+	}
+
+	return desired.Status.KeyHash, false, r.writeKey(ctx, desired, key, "")
+}
+
+// revokeLost deletes from Tyk the key of desired whose Secret was deleted or
+// is no longer owned by desired, before a new key is created: the lost key
+// would otherwise stay valid.
+func (r *ApiKeyReconciler) revokeLost(ctx context.Context, desired *tykv1alpha1.ApiKey) error {
+	if desired.Status.SecretName == "" {
+		return nil
+	}
+
+	if desired.Status.KeyHash == "" {
+		r.Log.Info("The Secret of the ApiKey is lost and Tyk does not hash keys, the previous key can't be revoked")
+		recordEvent(r.Recorder, desired, v1.EventTypeWarning, "KeyNotRevoked",
+			"Secret %s of the key is lost, the previous key is still valid on Tyk", desired.Status.SecretName)
+
+		return nil
+	}
+
+	r.Log.Info("The Secret of the ApiKey is lost, revoking the previous key")
+
+	err := klient.Universal.Keys().DeleteHashed(ctx, desired.Status.KeyHash)
+	if tykClient.IgnoreNotFound(err) != nil {
+		r.Log.Error(err, "Failed to revoke the previous ApiKey on Tyk")
+		return err
+	}
+
+	return nil
+}
+
+// create creates a new key on Tyk and writes it into the Secret of desired.
+// The previous key, if any, is kept in the Secret along with the new one until
+// it is deleted from Tyk. It returns the hash of the new key and whether the
+// Secret holds it.
+func (r *ApiKeyReconciler) create(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiKey,
+	session *model.SessionState,
+	previous string,
+) (string, bool, error) {
+	key, hash, err := klient.Universal.Keys().Create(ctx, session)
+	if err != nil {
+		r.Log.Error(err, "Failed to create ApiKey on Tyk")
+		return "", false, err
+	}
+
+	// A dry run creates no key, the Secret is left as is.
+	if key == "" {
+		return "", false, nil
+	}
+
+	// Tyk returns the key itself when keys are not hashed, it is never
+	// recorded in the status.
+	if hash == key {
+		hash = ""
+	}
+
+	if err := r.writeKey(ctx, desired, key, previous); err != nil {
+		r.Log.Error(err, "Failed to write ApiKey into its secret, deleting it from Tyk")
+
+		if errTyk := klient.Universal.Keys().Delete(ctx, key); errTyk != nil {
+			r.Log.Error(errTyk, "Failed to delete ApiKey from Tyk")
+		}
+
+		return "", false, err
+	}
+
+	if previous != "" {
+		return hash, true, r.revokePrevious(ctx, desired, key, previous)
+	}
+
+	return hash, true, nil
+}
+
+// revokePrevious deletes from Tyk the key rotated out by key, then removes it
+// from the Secret of desired.
+func (r *ApiKeyReconciler) revokePrevious(ctx context.Context, desired *tykv1alpha1.ApiKey, key, previous string) error {
+	if err := klient.Universal.Keys().Delete(ctx, previous); tykClient.IgnoreNotFound(err) != nil {
+		r.Log.Error(err, "Failed to delete the previous ApiKey from Tyk")
+		return err
+	}
+
+	return r.writeKey(ctx, desired, key, "")
+}
+
+// delete deletes the key of desired from Tyk, along with the key it rotated out
+// if that one is not revoked yet. paused is the reason its reconciliation is
+// paused, empty if it is not, the keys are then left on Tyk.
+func (r *ApiKeyReconciler) delete(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiKey,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "ApiKey.delete")
+	defer span.End()
+
+	r.Log.Info("ApiKey being deleted", "ApiKey", client.ObjectKeyFromObject(desired).String())
+
+	if !util.ContainsFinalizer(desired, keys.ApiKeyFinalizerName) {
+		return 0, nil
+	}
+
+	if paused != "" {
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+		util.RemoveFinalizer(desired, keys.ApiKeyFinalizerName)
+
+		return 0, nil
+	}
+
+	key, previous, err := r.key(ctx, desired)
+	if err != nil {
+		return queueAfter, err
+	}
+
+	if previous != "" {
+		err = klient.Universal.Keys().Delete(ctx, previous)
+		if tykClient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "Failed to delete the previous ApiKey from Tyk")
+			return queueAfter, err
+		}
+	}
+
+	switch {
+	case key != "":
+		err = klient.Universal.Keys().Delete(ctx, key)
+	case desired.Status.KeyHash != "":
+		// The Secret is lost, the key is deleted by its hash.
+		err = klient.Universal.Keys().DeleteHashed(ctx, desired.Status.KeyHash)
+	}
+
+	if tykClient.IgnoreNotFound(err) != nil {
+		r.Log.Error(err, "Failed to delete ApiKey from Tyk")
+		return queueAfter, err
+	}
+
+	if key != "" || desired.Status.KeyHash != "" {
+		recordDeleted(r.Recorder, desired, "")
+	}
+
+	// The Secret is garbage collected with the ApiKey owning it.
+	util.RemoveFinalizer(desired, keys.ApiKeyFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. session is the key sent to Tyk, nil if it could not be built, hash
+// the hash of the key held by the Secret, empty if it is not known, and
+// written whether the Secret holds a new key.
+func (r *ApiKeyReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.ApiKey,
+	session *model.SessionState,
+	hash string,
+	written bool,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	if err != nil {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	// The Secret may hold a new key even if the reconciliation failed later,
+	// the key it rotated out is then revoked before it is rotated again.
+	if written {
+		status.KeyHash = hash
+		status.SecretName = secretName(desired)
+		status.Rotation = desired.Spec.Rotation
+	}
+
+	if err == nil && session != nil {
+		status.KeyHash = hash
+		status.SecretName = secretName(desired)
+		status.PolicyIDs = session.ApplyPolicies
+		status.Rotation = desired.Spec.Rotation
+		status.LatestCRDSpecHash = calculateHash(session)
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ApiKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.ApiKey{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Owns(&v1.Secret{}).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedApiKeys
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("ApiKey", r))
+}
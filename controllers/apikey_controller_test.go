@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// apiKeyFixture returns a reconciler of the ApiKey consumer of the default
+// namespace, with the client holding it and objs, applying it to s.
+func apiKeyFixture(
+	t *testing.T,
+	s *fake.Server,
+	spec tykv1alpha1.ApiKeySpec,
+	objs ...runtime.Object,
+) (ApiKeyReconciler, client.Client, reconcile.Request) {
+	t.Helper()
+
+	apiKey := &tykv1alpha1.ApiKey{
+		ObjectMeta: v1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec:       spec,
+	}
+
+	cl, err := NewFakeClient(append(objs, apiKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := ApiKeyReconciler{Client: cl, Log: log.NullLogger{}, Scheme: cl.Scheme(), Env: s.Env()}
+
+	return r, cl, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(apiKey)}
+}
+
+func TestApiKeyReconcile(t *testing.T) {
+	servers := map[string]func() *fake.Server{
+		"gateway":   fake.NewGateway,
+		"dashboard": fake.NewDashboard,
+	}
+
+	for mode, newServer := range servers {
+		newServer := newServer
+
+		t.Run(mode, func(t *testing.T) {
+			is := is.New(t)
+
+			s := newServer()
+			defer s.Close()
+
+			policy := &tykv1alpha1.SecurityPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+				Status:     tykv1alpha1.SecurityPolicyStatus{PolID: "httpbin-policy"},
+			}
+			spec := tykv1alpha1.ApiKeySpec{Policies: []model.Target{{Name: "httpbin"}}, SecretName: "consumer-key"}
+
+			r, cl, req := apiKeyFixture(t, s, spec, policy)
+			secretKey := types.NamespacedName{Namespace: "default", Name: "consumer-key"}
+
+			_, err := r.Reconcile(context.Background(), req)
+			is.NoErr(err)
+
+			apiKey := &tykv1alpha1.ApiKey{}
+			is.NoErr(cl.Get(context.Background(), req.NamespacedName, apiKey))
+
+			var secret corev1.Secret
+			is.NoErr(cl.Get(context.Background(), secretKey, &secret))
+			is.True(v1.IsControlledBy(&secret, apiKey))
+
+			key := string(secret.Data[tykv1alpha1.ApiKeySecretKey])
+			keys := s.Keys()
+			is.Equal(len(keys), 1)
+			is.Equal(keys[key].ApplyPolicies, []string{"httpbin-policy"})
+			is.Equal(keys[key].Alias, "default/consumer")
+
+			is.NoErr(cl.Get(context.Background(), req.NamespacedName, apiKey))
+			is.Equal(apiKey.Status.SecretName, "consumer-key")
+			is.Equal(apiKey.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+
+			// rotate the key
+			apiKey.Spec.Rotation = "1"
+			is.NoErr(cl.Update(context.Background(), apiKey))
+
+			_, err = r.Reconcile(context.Background(), req)
+			is.NoErr(err)
+
+			is.NoErr(cl.Get(context.Background(), secretKey, &secret))
+
+			rotated := string(secret.Data[tykv1alpha1.ApiKeySecretKey])
+			is.True(rotated != key)
+
+			keys = s.Keys()
+			is.Equal(len(keys), 1)
+			_, ok := keys[rotated]
+			is.True(ok)
+
+			is.NoErr(cl.Delete(context.Background(), apiKey))
+
+			_, err = r.Reconcile(context.Background(), req)
+			is.NoErr(err)
+			is.Equal(len(s.Keys()), 0)
+		})
+	}
+}
+
+func TestApiKeyLostSecret(t *testing.T) {
+	tests := map[string]struct {
+		// lose makes the ApiKey lose its Secret.
+		lose func(ctx context.Context, cl client.Client, secret *corev1.Secret) error
+
+		// keys is the number of keys left on Tyk, the new key is deleted when
+		// it can't be written into the Secret.
+		keys    int
+		wantErr bool
+	}{
+		"deleted": {
+			lose: func(ctx context.Context, cl client.Client, secret *corev1.Secret) error {
+				return cl.Delete(ctx, secret)
+			},
+			keys: 1,
+		},
+		"disowned": {
+			lose: func(ctx context.Context, cl client.Client, secret *corev1.Secret) error {
+				secret.OwnerReferences = nil
+				return cl.Update(ctx, secret)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			s := fake.NewGateway()
+			defer s.Close()
+
+			r, cl, req := apiKeyFixture(t, s, tykv1alpha1.ApiKeySpec{})
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, req)
+			is.NoErr(err)
+
+			var secret corev1.Secret
+			is.NoErr(cl.Get(ctx, req.NamespacedName, &secret))
+
+			lost := string(secret.Data[tykv1alpha1.ApiKeySecretKey])
+
+			apiKey := &tykv1alpha1.ApiKey{}
+			is.NoErr(cl.Get(ctx, req.NamespacedName, apiKey))
+			is.True(apiKey.Status.KeyHash != "")
+
+			is.NoErr(tt.lose(ctx, cl, &secret))
+
+			_, err = r.Reconcile(ctx, req)
+			is.Equal(err != nil, tt.wantErr)
+
+			// The lost key is revoked before a new one is created.
+			keys := s.Keys()
+			is.Equal(len(keys), tt.keys)
+
+			_, ok := keys[lost]
+			is.True(!ok)
+
+			is.NoErr(cl.Get(ctx, req.NamespacedName, apiKey))
+
+			if tt.wantErr {
+				is.Equal(apiKey.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+				return
+			}
+
+			// The key is deleted by its hash once its Secret is lost again.
+			is.NoErr(cl.Get(ctx, req.NamespacedName, &secret))
+			is.NoErr(cl.Delete(ctx, &secret))
+			is.NoErr(cl.Delete(ctx, apiKey))
+
+			_, err = r.Reconcile(ctx, req)
+			is.NoErr(err)
+			is.Equal(len(s.Keys()), 0)
+		})
+	}
+}
+
+func TestApiKeyRotationRevokeFailure(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	r, cl, req := apiKeyFixture(t, s, tykv1alpha1.ApiKeySpec{})
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	var secret corev1.Secret
+	is.NoErr(cl.Get(ctx, req.NamespacedName, &secret))
+
+	previous := string(secret.Data[tykv1alpha1.ApiKeySecretKey])
+
+	apiKey := &tykv1alpha1.ApiKey{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, apiKey))
+
+	previousHash := apiKey.Status.KeyHash
+
+	apiKey.Spec.Rotation = "1"
+	is.NoErr(cl.Update(ctx, apiKey))
+
+	s.FailKeyDeletes("Access to this resource has been disallowed")
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(err != nil)
+
+	// The Secret holds the new key and keeps the previous one until it is
+	// revoked, the rotation is recorded.
+	is.NoErr(cl.Get(ctx, req.NamespacedName, &secret))
+
+	rotated := string(secret.Data[tykv1alpha1.ApiKeySecretKey])
+	is.True(rotated != previous)
+	is.Equal(string(secret.Data[tykv1alpha1.ApiKeyPreviousSecretKey]), previous)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, apiKey))
+	is.Equal(apiKey.Status.Rotation, "1")
+	is.True(apiKey.Status.KeyHash != previousHash)
+	is.Equal(len(s.Keys()), 2)
+
+	s.FailKeyDeletes("")
+
+	// The previous key is revoked, and the key is not rotated again.
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	secret = corev1.Secret{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, &secret))
+	is.Equal(string(secret.Data[tykv1alpha1.ApiKeySecretKey]), rotated)
+
+	_, ok := secret.Data[tykv1alpha1.ApiKeyPreviousSecretKey]
+	is.True(!ok)
+
+	keys := s.Keys()
+	is.Equal(len(keys), 1)
+
+	_, ok = keys[rotated]
+	is.True(ok)
+}
+
+func TestApiKeyFailures(t *testing.T) {
+	tests := map[string]struct {
+		spec tykv1alpha1.ApiKeySpec
+		objs []runtime.Object
+
+		// reason is the reason of the Ready condition of the ApiKey.
+		reason string
+	}{
+		"missing policy": {
+			spec:   tykv1alpha1.ApiKeySpec{Policies: []model.Target{{Name: "httpbin"}}},
+			reason: ReasonDependencyMissing,
+		},
+		"foreign secret": {
+			objs: []runtime.Object{&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "consumer", Namespace: "default"},
+			}},
+			reason: ReasonSyncFailed,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			s := fake.NewGateway()
+			defer s.Close()
+
+			r, cl, req := apiKeyFixture(t, s, tt.spec, tt.objs...)
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, req)
+			is.True(err != nil)
+
+			// No key is left on Tyk without a Secret holding it.
+			is.Equal(len(s.Keys()), 0)
+
+			apiKey := &tykv1alpha1.ApiKey{}
+			is.NoErr(cl.Get(ctx, req.NamespacedName, apiKey))
+			is.Equal(apiKey.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+
+			c := meta.FindStatusCondition(apiKey.Status.Conditions, tykv1alpha1.ConditionReady)
+			is.True(c != nil)
+			is.Equal(c.Reason, tt.reason)
+		})
+	}
+}
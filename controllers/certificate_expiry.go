@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// defaultCertificateExpiryWarnings are used when the environment sets no
+// certificate expiry warnings.
+var defaultCertificateExpiryWarnings = []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+var certificateExpiryDesc = prometheus.NewDesc(
+	"tyk_certificate_expiry_seconds",
+	"Seconds left before the certificates referenced by ApiDefinition resources expire.",
+	[]string{"id", "subject"}, nil,
+)
+
+// certificateExpiry exports the time left before the certificates referenced
+// by ApiDefinition resources expire. It is computed when metrics are scraped.
+type certificateExpiry struct {
+	mu    sync.Mutex
+	certs map[string]tykv1alpha1.CertificateStatus
+}
+
+var certificateExpiries = &certificateExpiry{certs: make(map[string]tykv1alpha1.CertificateStatus)}
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiries)
+}
+
+func (c *certificateExpiry) Describe(ch chan<- *prometheus.Desc) {
+	ch <- certificateExpiryDesc
+}
+
+func (c *certificateExpiry) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, s := range c.certs {
+		ch <- prometheus.MustNewConstMetric(
+			certificateExpiryDesc, prometheus.GaugeValue, time.Until(s.NotAfter.Time).Seconds(), id, s.Subject,
+		)
+	}
+}
+
+// observe exports the expiry of certs.
+func (c *certificateExpiry) observe(certs []tykv1alpha1.CertificateStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range certs {
+		c.certs[s.ID] = s
+	}
+}
+
+// forget stops exporting the expiry of the certificate whose id is id, once it
+// was deleted from Tyk.
+func (c *certificateExpiry) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.certs, id)
+}
+
+// referencedCertificates returns the sorted ids of the certificates referenced
+// by spec.
+func referencedCertificates(spec *model.APIDefinitionSpec) []string {
+	seen := map[string]bool{}
+
+	add := func(id string) {
+		if id != "" {
+			seen[id] = true
+		}
+	}
+
+	for _, id := range spec.Certificates {
+		add(id)
+	}
+
+	for _, id := range spec.ClientCertificates {
+		add(id)
+	}
+
+	for _, id := range spec.UpstreamCertificates {
+		add(id)
+	}
+
+	for _, id := range spec.PinnedPublicKeys {
+		add(id)
+	}
+
+	o := make([]string, 0, len(seen))
+	for id := range seen {
+		o = append(o, id)
+	}
+
+	sort.Strings(o)
+
+	return o
+}
+
+// certificateStatuses returns the status of the certificates whose ids are
+// given, read from their metadata on Tyk. Certificates unknown to Tyk are
+// skipped.
+func certificateStatuses(ctx context.Context, ids []string) ([]tykv1alpha1.CertificateStatus, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	all, err := klient.Universal.Certificate().Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make(map[string]model.CertificateMeta, len(all))
+	for _, m := range all {
+		meta[m.ID] = m
+	}
+
+	var o []tykv1alpha1.CertificateStatus
+
+	for _, id := range ids {
+		m, ok := meta[id]
+		if !ok {
+			continue
+		}
+
+		o = append(o, tykv1alpha1.CertificateStatus{
+			ID:          id,
+			Subject:     m.SubjectCN,
+			Issuer:      m.IssuerCN,
+			DNSNames:    m.DNSNames,
+			Fingerprint: m.Fingerprint,
+			NotAfter:    metav1.NewTime(m.NotAfter),
+		})
+	}
+
+	return o, nil
+}
+
+// certificateExpiryWarnings returns the certificate expiry warnings of env,
+// longest first.
+func certificateExpiryWarnings(env environment.Env) []time.Duration {
+	if len(env.CertificateExpiryWarnings) == 0 {
+		return defaultCertificateExpiryWarnings
+	}
+
+	o := make([]time.Duration, 0, len(env.CertificateExpiryWarnings))
+	for _, d := range env.CertificateExpiryWarnings {
+		o = append(o, d.Duration)
+	}
+
+	sort.Slice(o, func(i, j int) bool { return o[i] > o[j] })
+
+	return o
+}
+
+// nextCertificateCheck returns how long until one of certs crosses one of the
+// warnings or expires, zero if none will.
+func nextCertificateCheck(
+	certs []tykv1alpha1.CertificateStatus,
+	warnings []time.Duration,
+	now time.Time,
+) time.Duration {
+	var next time.Duration
+
+	for _, c := range certs {
+		left := c.NotAfter.Sub(now)
+
+		for _, w := range append(warnings, 0) {
+			if d := left - w; d > 0 && (next == 0 || d < next) {
+				next = d
+			}
+		}
+	}
+
+	return next
+}
+
+// expiryWarning returns the shortest of warnings the certificate c is within,
+// zero if it expired and nil if it is within none.
+func expiryWarning(c tykv1alpha1.CertificateStatus, warnings []time.Duration, now time.Time) *metav1.Duration {
+	left := c.NotAfter.Sub(now)
+	if left <= 0 {
+		return &metav1.Duration{}
+	}
+
+	var o *metav1.Duration
+
+	for _, w := range warnings {
+		if left <= w {
+			o = &metav1.Duration{Duration: w}
+		}
+	}
+
+	return o
+}
+
+// observeCertificates refreshes the status of the certificates referenced by
+// desired and records a Warning event for each of those crossing an expiry
+// warning, or expiring, since it was last reported. It returns when the
+// certificates must be checked again, zero if they don't.
+func (r *ApiDefinitionReconciler) observeCertificates(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.ApiDefinition,
+	spec *model.APIDefinitionSpec,
+) ([]tykv1alpha1.CertificateStatus, time.Duration) {
+	certs, err := certificateStatuses(ctx, referencedCertificates(spec))
+	if err != nil {
+		r.Log.Info("Failed to get the metadata of certificates", "error", err.Error())
+		return desired.Status.Certificates, 0
+	}
+
+	certificateExpiries.observe(certs)
+
+	reported := make(map[string]*metav1.Duration, len(desired.Status.Certificates))
+	for _, c := range desired.Status.Certificates {
+		reported[c.ID] = c.ExpiryWarning
+	}
+
+	warnings := certificateExpiryWarnings(env)
+	now := time.Now()
+
+	for i := range certs {
+		c := &certs[i]
+		c.ExpiryWarning = expiryWarning(*c, warnings, now)
+
+		if c.ExpiryWarning == nil {
+			continue
+		}
+
+		if last := reported[c.ID]; last != nil && last.Duration == c.ExpiryWarning.Duration {
+			continue
+		}
+
+		if c.ExpiryWarning.Duration == 0 {
+			r.Recorder.Eventf(desired, v1.EventTypeWarning, "CertificateExpired",
+				"Certificate %s (%s) expired on %s", c.ID, c.Subject, c.NotAfter.Format(time.RFC3339))
+		} else {
+			r.Recorder.Eventf(desired, v1.EventTypeWarning, "CertificateExpiring",
+				"Certificate %s (%s) expires in %s, on %s", c.ID, c.Subject,
+				c.NotAfter.Sub(now).Round(time.Minute), c.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	return certs, nextCertificateCheck(certs, warnings, now)
+}
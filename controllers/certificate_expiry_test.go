@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionCertificateExpiry(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	key, crt := tlsCertificate(t, "httpbin.tyk.io", 72*time.Hour)
+
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin-tls", Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": crt, "tls.key": key},
+	}
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{
+				Name:                   "httpbin",
+				CertificateSecretNames: []string{"httpbin-tls"},
+			},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{secret, api})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+	ctx := context.Background()
+
+	res, err := r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	// The certificate is checked again once it expired.
+	is.True(res.RequeueAfter > 71*time.Hour && res.RequeueAfter <= 72*time.Hour)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(len(api.Status.Certificates), 1)
+
+	c := api.Status.Certificates[0]
+	is.Equal(c.ID, s.Certificates()[0])
+	is.Equal(c.Subject, "httpbin.tyk.io")
+	is.Equal(c.DNSNames, []string{"httpbin.tyk.io"})
+	is.True(strings.HasSuffix(c.ID, c.Fingerprint))
+
+	is.Equal(<-recorder.Events, "Normal CertificateUploaded Uploaded certificate "+c.ID+" to Tyk")
+
+	select {
+	case e := <-recorder.Events:
+		is.True(strings.HasPrefix(e, "Warning CertificateExpiring Certificate "+c.ID))
+	default:
+		t.Fatal("expected a CertificateExpiring event")
+	}
+
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal Synced"))
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Certificates[0].ExpiryWarning, &v1.Duration{Duration: 7 * 24 * time.Hour})
+
+	// The warning is recorded once, not on every reconciliation.
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	for len(recorder.Events) != 0 {
+		is.True(!strings.Contains(<-recorder.Events, "CertificateExpiring"))
+	}
+}
+
+func TestExpiryWarning(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	warnings := []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+	expiring := func(d time.Duration) tykv1alpha1.CertificateStatus {
+		return tykv1alpha1.CertificateStatus{NotAfter: v1.NewTime(now.Add(d))}
+	}
+
+	is.Equal(expiryWarning(expiring(40*24*time.Hour), warnings, now), nil)
+	is.Equal(expiryWarning(expiring(10*24*time.Hour), warnings, now), &v1.Duration{Duration: 30 * 24 * time.Hour})
+	is.Equal(expiryWarning(expiring(time.Hour), warnings, now), &v1.Duration{Duration: 7 * 24 * time.Hour})
+	is.Equal(expiryWarning(expiring(-time.Hour), warnings, now), &v1.Duration{})
+}
+
+func TestNextCertificateCheck(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	warnings := []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+	expiring := func(in ...time.Duration) []tykv1alpha1.CertificateStatus {
+		var o []tykv1alpha1.CertificateStatus
+		for _, d := range in {
+			o = append(o, tykv1alpha1.CertificateStatus{NotAfter: v1.NewTime(now.Add(d))})
+		}
+
+		return o
+	}
+
+	is.Equal(nextCertificateCheck(nil, warnings, now), time.Duration(0))
+	is.Equal(nextCertificateCheck(expiring(40*24*time.Hour), warnings, now), 10*24*time.Hour)
+	is.Equal(nextCertificateCheck(expiring(10*24*time.Hour), warnings, now), 3*24*time.Hour)
+	is.Equal(nextCertificateCheck(expiring(time.Hour, 40*24*time.Hour), warnings, now), time.Hour)
+	is.Equal(nextCertificateCheck(expiring(-time.Hour), warnings, now), time.Duration(0))
+}
+
+// tlsCertificate returns a PEM encoded key and self-signed certificate for
+// dnsName, valid for validity.
+func tlsCertificate(t *testing.T, dnsName string, validity time.Duration) (key, crt []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(validity),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	crt = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	return key, crt
+}
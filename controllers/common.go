@@ -3,17 +3,22 @@ package controllers
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/go-logr/logr"
 	"github.com/mitchellh/hashstructure/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -38,6 +43,71 @@ func isSame(latestHash string, i interface{}) bool {
 	return latestHash == calculateHash(i)
 }
 
+// requeueAfter returns how long to wait before reconciling again after err.
+// While calls to Tyk are short-circuited by an open circuit breaker, there is no
+// point in retrying before the breaker lets a probe request through.
+func requeueAfter(err error) time.Duration {
+	var open *tykClient.CircuitOpenError
+	if errors.As(err, &open) {
+		if d := time.Until(open.Until); d > queueAfter {
+			return d
+		}
+	}
+
+	return queueAfter
+}
+
+// hotReload requests a hot reload of the gateways. When hot reloads are
+// batched, it returns without waiting for the batch, whose result is read by
+// reloadResult at the end of the reconciliation.
+func hotReload(ctx context.Context) error {
+	return klient.Universal.HotReload(ctx)
+}
+
+// reloadResult returns err, or if it is nil, the result of the batched hot
+// reload requested for the resource reconciled with ctx. While the batch is
+// not done, it returns ErrReloadPending and the resource is reconciled again
+// to read it. A failed reload is requested again, the next reconciliation
+// reads the result of the new one.
+func reloadResult(ctx context.Context, err error) error {
+	if err != nil {
+		return err
+	}
+
+	err = klient.Universal.HotReloadResult(ctx)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, tykClient.ErrHotReloadPending):
+		return fmt.Errorf("%w: %v", ErrReloadPending, err)
+	}
+
+	if errR := klient.Universal.HotReload(ctx); errR != nil {
+		return errR
+	}
+
+	return err
+}
+
+// requeue returns the result of a reconciliation which ended with err, once err
+// is recorded in the status of the resource. controller-runtime ignores the
+// result returned along with an error and requeues with its rate limiter, so
+// errors expected to go away by themselves, such as an open circuit breaker,
+// are not returned: the request is requeued after requeueAfter(err) instead. A
+// pending hot reload, or reload verification, is checked again after
+// reloadVerificationInterval.
+func requeue(queueA time.Duration, err error) (ctrl.Result, error) {
+	switch {
+	case tykClient.IsRetryable(err):
+		return ctrl.Result{RequeueAfter: requeueAfter(err)}, nil
+	case errors.Is(err, ErrReloadPending):
+		return ctrl.Result{RequeueAfter: reloadVerificationInterval}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: queueA}, err
+}
+
 // containsString is a helper function to check string exists in a slice of strings.
 func containsString(slice []string, s string) bool {
 	for _, item := range slice {
@@ -86,6 +156,25 @@ func HttpContext(
 	log logr.Logger,
 ) (environment.Env, context.Context, error) {
 	e := *env
+
+	// key and namespace identify the OperatorContext in use, they are empty when
+	// the environment of the operator is used.
+	var key, namespace string
+
+	// ref is the OperatorContext in use, nil when the environment of the
+	// operator is used.
+	var ref *model.Target
+
+	// use switches to the environment of the OperatorContext opCtx, referenced
+	// by opCtxRef.
+	use := func(opCtx *v1alpha1.OperatorContext, opCtxRef *model.Target) {
+		e.Environment = *opCtx.Spec.Env
+		e.Paused = opCtx.Spec.Paused
+		key = client.ObjectKeyFromObject(opCtx).String()
+		namespace = opCtx.Namespace
+		ref = opCtxRef
+	}
+
 	get := func(opCtxRef *model.Target) error {
 		if opCtxRef == nil {
 			// To handle the case where operator context was used previously
@@ -122,7 +211,7 @@ func HttpContext(
 
 		log.Info("Successful acquired context", "contextRef", opCtxRef.String())
 
-		e.Environment = *env.Spec.Env
+		use(env, opCtxRef)
 
 		if err := updateOperatorContextStatus(ctx, rClient, object, log, opCtxRef); err != nil {
 			log.Error(err, "Failed to update status of operator contexts")
@@ -144,16 +233,84 @@ func HttpContext(
 		err = get(o.Spec.Context)
 	case *v1alpha1.PortalConfig:
 		err = get(o.Spec.Context)
+	case *v1alpha1.TykOasApiDefinition:
+		err = get(o.Spec.Context)
+	case *v1alpha1.ApiKey:
+		err = get(o.Spec.Context)
+	case *v1alpha1.DashboardUser:
+		err = get(o.Spec.Context)
+	case *v1alpha1.DashboardUserGroup:
+		err = get(o.Spec.Context)
+	case *v1alpha1.TykOrganisation:
+		err = get(o.Spec.Context)
+	case *v1alpha1.ApiEventWebhook:
+		err = get(o.Spec.Context)
+	case *v1alpha1.OperatorContext:
+		// An OperatorContext reaches Tyk with its own environment, it is not
+		// linked to itself.
+		self := &model.Target{Name: o.Name, Namespace: &o.Namespace}
+
+		var env *v1alpha1.OperatorContext
+		if env, err = GetContext(ctx, o.Namespace, rClient, self, log); err == nil {
+			use(env, self)
+		}
 	}
 
 	if err != nil {
 		return environment.Env{}, nil, err
 	}
 
-	return e, tykClient.SetContext(ctx, tykClient.Context{
-		Env: e,
-		Log: log,
-	}), nil
+	// The gateways of the reload verification default to the namespace of the
+	// OperatorContext, like the secrets it references.
+	v := e.ReloadVerification
+	if v != nil && namespace != "" && (v.Service.Namespace == nil || *v.Service.Namespace == "") {
+		rv := *v
+		rv.Service.Namespace = &namespace
+		e.ReloadVerification = &rv
+	}
+
+	if g := e.Gateways; g != nil && namespace != "" && g.Namespace == "" {
+		gw := *g
+		gw.Namespace = namespace
+		e.Gateways = &gw
+	}
+
+	hc, err := httpClient(ctx, rClient, key, namespace, e.Environment)
+	if err != nil {
+		log.Error(err, "Failed to create HTTP client", "key", key)
+		return environment.Env{}, nil, err
+	}
+
+	rctx := tykClient.Context{
+		Env:        e,
+		Log:        log,
+		HTTPClient: hc,
+		Object:     object,
+	}
+
+	// Gateways that do not share their storage each get the resources.
+	if e.Mode == "ce" && e.Gateways != nil {
+		rctx.Nodes, err = gatewayURLs(ctx, rClient, e)
+		if err != nil {
+			log.Error(err, "Failed to get the gateways")
+			return environment.Env{}, nil, err
+		}
+	}
+
+	// Credentials of an OperatorContext can be rotated while it is in use, they
+	// are read again when Tyk rejects a call.
+	if ref != nil {
+		rctx.ReadAuth = func(ctx context.Context) (string, error) {
+			env, err := GetContext(ctx, object.GetNamespace(), rClient, ref, log)
+			if err != nil {
+				return "", err
+			}
+
+			return env.Spec.Env.Auth, nil
+		}
+	}
+
+	return e, tykClient.SetContext(ctx, rctx), nil
 }
 
 // updateOperatorContextStatus updates links defined in the status of OperatorContext identified by ctxRef
@@ -256,6 +413,96 @@ func updateOperatorContextStatus(
 
 			opCtxList.Items[i].Status.RemoveLinkedPortalConfig(objectTarget)
 
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.TykOasApiDefinition:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if TykOasApiDefinition is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedTykOasApiDefinition(objectTarget)
+
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.ApiKey:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if ApiKey is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedApiKey(objectTarget)
+
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.DashboardUser:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if DashboardUser is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedDashboardUser(objectTarget)
+
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.DashboardUserGroup:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if DashboardUserGroup is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedDashboardUserGroup(objectTarget)
+
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.TykOrganisation:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if TykOrganisation is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedTykOrganisation(objectTarget)
+
+			err := client.Status().Update(ctx, &opCtxList.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	case *v1alpha1.ApiEventWebhook:
+		for i := 0; i < len(opCtxList.Items); i++ {
+			// do not remove link if ApiEventWebhook is still referring to context and is not marked for deletion.
+			if ctxRef != nil && opCtxList.Items[i].Name == ctxRef.Name &&
+				ctxRef.NamespaceMatches(opCtxList.Items[i].Namespace) && object.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			opCtxList.Items[i].Status.RemoveLinkedApiEventWebhook(objectTarget)
+
 			err := client.Status().Update(ctx, &opCtxList.Items[i])
 			if err != nil {
 				return err
@@ -294,6 +541,18 @@ func updateOperatorContextStatus(
 			operatorContext.Status.AddLinkedApiDescriptions(objectTarget)
 		case *v1alpha1.PortalConfig:
 			operatorContext.Status.AddLinkedPortalConfig(objectTarget)
+		case *v1alpha1.TykOasApiDefinition:
+			operatorContext.Status.AddLinkedTykOasApiDefinition(objectTarget)
+		case *v1alpha1.ApiKey:
+			operatorContext.Status.AddLinkedApiKey(objectTarget)
+		case *v1alpha1.DashboardUser:
+			operatorContext.Status.AddLinkedDashboardUser(objectTarget)
+		case *v1alpha1.DashboardUserGroup:
+			operatorContext.Status.AddLinkedDashboardUserGroup(objectTarget)
+		case *v1alpha1.TykOrganisation:
+			operatorContext.Status.AddLinkedTykOrganisation(objectTarget)
+		case *v1alpha1.ApiEventWebhook:
+			operatorContext.Status.AddLinkedApiEventWebhook(objectTarget)
 		}
 
 		return client.Status().Update(ctx, &operatorContext)
@@ -424,6 +683,16 @@ func GetContext(
 			}
 		}
 
+		if e.ProxyURL == "" {
+			err := value(v1alpha1.TykProxyURL, func(s string) (err error) {
+				e.ProxyURL = s
+				return
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if e.Ingress.HTTPPort == 0 {
 			err = value(v1alpha1.IngressHTTPPort, func(s string) (err error) {
 				e.Ingress.HTTPPort, err = strconv.Atoi(s)
@@ -1,9 +1,43 @@
 package controllers
 
 import (
+	"errors"
 	"testing"
+	"time"
+
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
 )
 
+func TestRequeueAfter(t *testing.T) {
+	if got := requeueAfter(errors.New("boom")); got != queueAfter {
+		t.Errorf("expected %v got %v", queueAfter, got)
+	}
+
+	open := &tykClient.CircuitOpenError{URL: "http://tyk", Until: time.Now().Add(time.Minute)}
+	if got := requeueAfter(open); got <= queueAfter || got > time.Minute {
+		t.Errorf("expected requeue until the breaker closes, got %v", got)
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	boom := errors.New("boom")
+
+	// controller-runtime ignores the result returned with an error.
+	if res, err := requeue(time.Hour, boom); err != boom || res.RequeueAfter != time.Hour {
+		t.Errorf("expected the error to be returned, got %v %v", res, err)
+	}
+
+	open := &tykClient.CircuitOpenError{URL: "http://tyk", Until: time.Now().Add(time.Minute)}
+	if res, err := requeue(0, open); err != nil || res.RequeueAfter <= queueAfter {
+		t.Errorf("expected a requeue once the breaker lets a probe through, got %v %v", res, err)
+	}
+
+	unavailable := &tykClient.TykAPIError{StatusCode: 503}
+	if res, err := requeue(0, unavailable); err != nil || res.RequeueAfter != queueAfter {
+		t.Errorf("expected a requeue after %v, got %v %v", queueAfter, res, err)
+	}
+}
+
 func TestDecodeID(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrDependencyNotReady is returned when a resource references an object that
+// exists but is not applied to Tyk yet.
+var ErrDependencyNotReady = errors.New("referenced resource is not applied to Tyk yet")
+
+// dependencyMissing returns true if err was caused by an object referenced by
+// the reconciled resource that does not exist, or is not applied to Tyk yet.
+func dependencyMissing(err error) bool {
+	return k8sErrors.IsNotFound(err) || errors.Is(err, ErrDependencyNotReady)
+}
+
+// setConditions records the reconciliation of generation of a resource, which
+// ended with err, in its observed generation and its Ready, Synced,
+// DependenciesResolved and Degraded conditions. A resource drifted on Tyk is
+// Degraded but still Ready, the drift policy kept the change.
+func setConditions(conditions *[]metav1.Condition, observedGeneration *int64, generation int64, err error) {
+	wasSynced := meta.IsStatusConditionTrue(*conditions, v1alpha1.ConditionSynced)
+
+	*observedGeneration = generation
+
+	// The resource is reconciled again, it is no longer paused. A dry run
+	// marks its status again when it is written.
+	meta.RemoveStatusCondition(conditions, v1alpha1.ConditionPaused)
+	meta.RemoveStatusCondition(conditions, v1alpha1.ConditionDryRun)
+
+	set := func(conditionType string, status bool, reason, message string) {
+		c := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		}
+
+		if status {
+			c.Status = metav1.ConditionTrue
+		}
+
+		meta.SetStatusCondition(conditions, c)
+	}
+
+	switch {
+	case err == nil:
+		set(v1alpha1.ConditionSynced, true, ReasonSynced, "The latest generation of the resource is reconciled")
+	case errors.Is(err, ErrReloadPending):
+		set(v1alpha1.ConditionSynced, false, ReasonReloadPending, err.Error())
+	default:
+		set(v1alpha1.ConditionSynced, false, ReasonSyncFailed, err.Error())
+	}
+
+	switch {
+	case errors.Is(err, ErrCrossShardReference):
+		set(v1alpha1.ConditionDependenciesResolved, false, "CrossShardReference", err.Error())
+	case dependencyMissing(err):
+		set(v1alpha1.ConditionDependenciesResolved, false, ReasonDependencyMissing, err.Error())
+	default:
+		set(v1alpha1.ConditionDependenciesResolved, true, "Resolved", "Every referenced resource exists")
+	}
+
+	degraded, reason, message := degradation(*conditions, wasSynced, err)
+	set(v1alpha1.ConditionDegraded, degraded, reason, message)
+
+	switch {
+	case errors.Is(err, ErrCrossShardReference):
+		set(v1alpha1.ConditionReady, false, "CrossShardReference", err.Error())
+	case dependencyMissing(err):
+		set(v1alpha1.ConditionReady, false, ReasonDependencyMissing, err.Error())
+	case errors.Is(err, ErrCompositionFailed):
+		set(v1alpha1.ConditionReady, false, ReasonCompositionFailed, err.Error())
+	case errors.Is(err, ErrInvalidDeletionPolicy):
+		set(v1alpha1.ConditionReady, false, ReasonInvalidDeletionPolicy, err.Error())
+	case errors.Is(err, ErrReloadPending):
+		set(v1alpha1.ConditionReady, false, ReasonReloadPending, err.Error())
+	case err != nil:
+		set(v1alpha1.ConditionReady, false, ReasonSyncFailed, err.Error())
+	default:
+		set(v1alpha1.ConditionReady, true, "Ready", "The resource is ready")
+	}
+}
+
+// degradation returns whether a resource, whose reconciliation ended with err,
+// is served by Tyk but not as desired, along with the reason and message of
+// its Degraded condition. wasSynced is true if the previous reconciliation of
+// the resource succeeded.
+func degradation(conditions []metav1.Condition, wasSynced bool, err error) (bool, string, string) {
+	var nodes *tykClient.NodesError
+
+	switch {
+	case errors.As(err, &nodes) && len(nodes.Errors) < nodes.Total:
+		return true, "PartiallyApplied", err.Error()
+	case errors.Is(err, ErrReloadNotVerified):
+		return true, "ReloadNotVerified", err.Error()
+	case errors.Is(err, ErrReloadPending):
+		return false, ReasonReloadPending, err.Error()
+	case err != nil && wasSynced:
+		return true, "SyncFailed", "A previous version of the resource is still served by Tyk: " + err.Error()
+	}
+
+	if c := meta.FindStatusCondition(conditions, v1alpha1.ConditionDrifted); c != nil &&
+		c.Status == metav1.ConditionTrue {
+		return true, "Drifted", c.Message
+	}
+
+	return false, "AsExpected", "The resource is served as desired"
+}
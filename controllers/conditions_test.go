@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSetConditions(t *testing.T) {
+	notFound := k8sErrors.NewNotFound(schema.GroupResource{Resource: "apidefinitions"}, "httpbin")
+	partial := &tykClient.NodesError{Total: 2, Errors: map[string]error{"http://a": errors.New("failed")}}
+
+	tests := []struct {
+		name         string
+		synced       bool
+		drifted      bool
+		err          error
+		ready        v1.ConditionStatus
+		dependencies v1.ConditionStatus
+		degraded     string
+	}{
+		{name: "synced", ready: v1.ConditionTrue, dependencies: v1.ConditionTrue, degraded: "AsExpected"},
+		{name: "missing", err: notFound, dependencies: v1.ConditionFalse, degraded: "AsExpected"},
+		{name: "not ready", err: ErrDependencyNotReady, dependencies: v1.ConditionFalse, degraded: "AsExpected"},
+		{name: "failed", err: errors.New("failed"), dependencies: v1.ConditionTrue, degraded: "AsExpected"},
+		{name: "stale", synced: true, err: errors.New("failed"), dependencies: v1.ConditionTrue, degraded: "SyncFailed"},
+		{name: "partial", err: partial, dependencies: v1.ConditionTrue, degraded: "PartiallyApplied"},
+		{name: "reload", err: ErrReloadNotVerified, dependencies: v1.ConditionTrue, degraded: "ReloadNotVerified"},
+		{name: "drifted", drifted: true, ready: v1.ConditionTrue, dependencies: v1.ConditionTrue, degraded: "Drifted"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var conditions []v1.Condition
+
+			var observed int64
+
+			if tt.synced {
+				setConditions(&conditions, &observed, 1, nil)
+			}
+
+			setDrifted(&conditions, 2, tt.drifted, nil)
+			setConditions(&conditions, &observed, 2, tt.err)
+
+			is.Equal(observed, int64(2))
+
+			ready := meta.FindStatusCondition(conditions, tykv1alpha1.ConditionReady)
+			if tt.ready == "" {
+				tt.ready = v1.ConditionFalse
+			}
+
+			is.Equal(ready.Status, tt.ready)
+			is.Equal(ready.ObservedGeneration, int64(2))
+			is.Equal(meta.IsStatusConditionTrue(conditions, tykv1alpha1.ConditionSynced), tt.err == nil)
+			is.Equal(meta.FindStatusCondition(conditions, tykv1alpha1.ConditionDependenciesResolved).Status, tt.dependencies)
+			is.Equal(meta.FindStatusCondition(conditions, tykv1alpha1.ConditionDegraded).Reason, tt.degraded)
+		})
+	}
+}
+
+func TestSecurityPolicyConditions(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Name: "gold", Namespace: "default", Generation: 1},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{
+				Name: "gold",
+				AccessRightsArray: []*model.AccessDefinition{
+					{Name: "httpbin", Namespace: "default"},
+				},
+			},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{policy})
+	is.NoErr(err)
+
+	r := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(k8sErrors.IsNotFound(err))
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	is.True(!meta.IsStatusConditionTrue(policy.Status.Conditions, tykv1alpha1.ConditionReady))
+	is.True(meta.IsStatusConditionFalse(policy.Status.Conditions, tykv1alpha1.ConditionDependenciesResolved))
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+	is.NoErr(cl.Create(ctx, api))
+
+	// The ApiDefinition exists but is not on Tyk yet.
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrDependencyNotReady))
+
+	api.Status.ApiID = "httpbin"
+	is.NoErr(cl.Status().Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	is.True(meta.IsStatusConditionTrue(policy.Status.Conditions, tykv1alpha1.ConditionReady))
+	is.True(meta.IsStatusConditionTrue(policy.Status.Conditions, tykv1alpha1.ConditionDependenciesResolved))
+	is.True(meta.IsStatusConditionFalse(policy.Status.Conditions, tykv1alpha1.ConditionDegraded))
+	is.Equal(policy.Status.ObservedGeneration, policy.Generation)
+}
@@ -0,0 +1,398 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DashboardUserPasswordSecretKey indexes DashboardUsers by the namespace/name
+// of the secret holding their password.
+const DashboardUserPasswordSecretKey = "dashboard_user_password_secret"
+
+// DashboardUserReconciler reconciles a DashboardUser object
+type DashboardUserReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// password is the password of a DashboardUser read from its secret.
+type password struct {
+	value string
+
+	// version is the resource version of the secret.
+	version string
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusergroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *DashboardUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("DashboardUser", req.NamespacedName.String())
+
+	log.Info("Reconciling DashboardUser instance")
+
+	desired := &tykv1alpha1.DashboardUser{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused DashboardUser being deleted leaves its user on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var (
+		user *model.DashboardUser
+		pass password
+	)
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		if env.Mode != "pro" {
+			return ErrDashboardOnly
+		}
+
+		util.AddFinalizer(desired, keys.DashboardUserFinalizerName)
+
+		user, err = r.user(ctx, &env, desired)
+		if err != nil {
+			return err
+		}
+
+		pass, err = r.password(ctx, desired)
+		if err != nil {
+			return err
+		}
+
+		return r.createOrUpdate(ctx, desired, user, pass)
+	})
+
+	if err == nil {
+		log.Info("Completed reconciling DashboardUser instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, user, pass, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	// Retrying won't help until the OperatorContext is changed.
+	if errors.Is(err, ErrDashboardOnly) {
+		log.Info("DashboardUser can not be reconciled", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return requeue(queueA, err)
+}
+
+// user returns the user sent to the dashboard, with its user group resolved to
+// its dashboard id.
+func (r *DashboardUserReconciler) user(
+	ctx context.Context,
+	env *environment.Env,
+	desired *tykv1alpha1.DashboardUser,
+) (*model.DashboardUser, error) {
+	user := &model.DashboardUser{
+		ID:              desired.Status.UserID,
+		OrgID:           env.Org,
+		FirstName:       desired.Spec.FirstName,
+		LastName:        desired.Spec.LastName,
+		EmailAddress:    desired.Spec.EmailAddress,
+		Active:          desired.Spec.Active,
+		UserPermissions: desired.Spec.UserPermissions,
+	}
+
+	if ref := desired.Spec.UserGroupRef; ref != nil {
+		key := ref.NS(desired.Namespace)
+
+		var group tykv1alpha1.DashboardUserGroup
+		if err := r.Get(ctx, key, &group); err != nil {
+			return nil, fmt.Errorf("failed to get DashboardUserGroup %s: %w", key, err)
+		}
+
+		if group.Status.UserGroupID == "" {
+			return nil, fmt.Errorf("DashboardUserGroup %s is not created on the dashboard yet", key)
+		}
+
+		user.GroupID = group.Status.UserGroupID
+	}
+
+	return user, nil
+}
+
+// password returns the password of desired, empty if it has none.
+func (r *DashboardUserReconciler) password(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUser,
+) (password, error) {
+	ref := desired.Spec.PasswordSecretRef
+	if ref == nil {
+		return password{}, nil
+	}
+
+	key := ref.NS(desired.Namespace)
+
+	var secret v1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return password{}, fmt.Errorf("failed to get password secret %s: %w", key, err)
+	}
+
+	value, ok := secret.Data[tykv1alpha1.DashboardUserPasswordKey]
+	if !ok {
+		return password{}, fmt.Errorf("secret %s has no %s", key, tykv1alpha1.DashboardUserPasswordKey)
+	}
+
+	return password{value: string(value), version: secret.ResourceVersion}, nil
+}
+
+// createOrUpdate creates or updates user on the dashboard. user.ID is set to
+// the id of the user once created.
+func (r *DashboardUserReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUser,
+	user *model.DashboardUser,
+	pass password,
+) error {
+	ctx, span := tracing.Start(ctx, "DashboardUser.createOrUpdate")
+	defer span.End()
+
+	if user.ID != "" {
+		_, err := klient.Universal.Users().Get(ctx, user.ID)
+
+		switch {
+		case err == nil:
+			if !isSame(desired.Status.LatestCRDSpecHash, user) {
+				r.Log.Info("Updating DashboardUser", "id", user.ID)
+
+				if err := klient.Universal.Users().Update(ctx, user); err != nil {
+					return err
+				}
+			}
+
+			if pass.value != "" && pass.version != desired.Status.PasswordSecretVersion {
+				r.Log.Info("Setting password of DashboardUser", "id", user.ID)
+				return klient.Universal.Users().SetPassword(ctx, user.ID, pass.value)
+			}
+
+			return nil
+		case !tykClient.IsNotFound(err):
+			return err
+		}
+
+		r.Log.Info("DashboardUser not found on the dashboard, creating a new one", "id", user.ID)
+	}
+
+	r.Log.Info("Creating DashboardUser")
+
+	create := *user
+	create.ID = ""
+	create.Password = pass.value
+
+	id, err := klient.Universal.Users().Create(ctx, &create)
+	if err != nil {
+		r.Log.Error(err, "Failed to create DashboardUser")
+		return err
+	}
+
+	user.ID = id
+
+	return nil
+}
+
+// delete deletes the user of desired from the dashboard. paused is the reason
+// its reconciliation is paused, empty if it is not, the user is then left on
+// Tyk.
+func (r *DashboardUserReconciler) delete(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUser,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "DashboardUser.delete")
+	defer span.End()
+
+	r.Log.Info("DashboardUser being deleted", "DashboardUser", client.ObjectKeyFromObject(desired).String())
+
+	if !util.ContainsFinalizer(desired, keys.DashboardUserFinalizerName) {
+		return 0, nil
+	}
+
+	switch {
+	case desired.Status.UserID == "":
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+	default:
+		err := klient.Universal.Users().Delete(ctx, desired.Status.UserID)
+		if tykClient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "Failed to delete DashboardUser", "id", desired.Status.UserID)
+			return queueAfter, err
+		}
+
+		recordDeleted(r.Recorder, desired, desired.Status.UserID)
+	}
+
+	util.RemoveFinalizer(desired, keys.DashboardUserFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. user is the user sent to the dashboard, nil if it was not.
+func (r *DashboardUserReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUser,
+	user *model.DashboardUser,
+	pass password,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	if err != nil {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	if user != nil && user.ID != "" {
+		status.UserID = user.ID
+
+		if err == nil {
+			status.UserGroupID = user.GroupID
+			status.PasswordSecretVersion = pass.version
+			status.LatestCRDSpecHash = calculateHash(user)
+		}
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// findUsersForSecret returns the DashboardUsers whose password is held by the
+// secret.
+func (r *DashboardUserReconciler) findUsersForSecret(secret client.Object) []reconcile.Request {
+	var list tykv1alpha1.DashboardUserList
+
+	err := r.List(context.Background(), &list, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(
+			DashboardUserPasswordSecretKey, client.ObjectKeyFromObject(secret).String(),
+		),
+	})
+	if err != nil {
+		r.Log.Error(err, "Failed to list DashboardUsers of secret", "secret", secret.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+		})
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DashboardUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&tykv1alpha1.DashboardUser{},
+		DashboardUserPasswordSecretKey,
+		func(rawObj client.Object) []string {
+			o, ok := rawObj.(*tykv1alpha1.DashboardUser)
+			if !ok || o.Spec.PasswordSecretRef == nil {
+				return nil
+			}
+
+			return []string{o.Spec.PasswordSecretRef.NS(o.Namespace).String()}
+		})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.DashboardUser{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.findUsersForSecret),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedDashboardUsers
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("DashboardUser", r))
+}
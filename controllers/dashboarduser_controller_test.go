@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDashboardUserReconcile(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	group := &tykv1alpha1.DashboardUserGroup{
+		ObjectMeta: v1.ObjectMeta{Name: "readers", Namespace: "default"},
+		Spec: tykv1alpha1.DashboardUserGroupSpec{
+			Name:            "readers",
+			UserPermissions: map[string]string{"apis": "read"},
+		},
+	}
+	user := &tykv1alpha1.DashboardUser{
+		ObjectMeta: v1.ObjectMeta{Name: "john", Namespace: "default"},
+		Spec: tykv1alpha1.DashboardUserSpec{
+			FirstName:         "John",
+			LastName:          "Doe",
+			EmailAddress:      "john@example.com",
+			Active:            true,
+			UserGroupRef:      &model.Target{Name: "readers"},
+			PasswordSecretRef: &model.Target{Name: "john"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "john", Namespace: "default"},
+		Data:       map[string][]byte{tykv1alpha1.DashboardUserPasswordKey: []byte("secret")},
+	}
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec:  model.APIDefinitionSpec{Name: "httpbin"},
+			UserOwnerRefs:      []model.Target{{Name: "john"}},
+			UserGroupOwnerRefs: []model.Target{{Name: "readers"}},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{group, user, secret, api})
+	is.NoErr(err)
+
+	ctx := context.Background()
+	reconcileObject := func(r reconcile.Reconciler, o client.Object) {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+		is.NoErr(err)
+		is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(o), o))
+	}
+
+	groups := &DashboardUserGroupReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+	users := &DashboardUserReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+	apis := &ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+
+	reconcileObject(groups, group)
+	is.True(group.Status.UserGroupID != "")
+	is.Equal(s.UserGroups()[group.Status.UserGroupID].UserPermissions, map[string]string{"apis": "read"})
+
+	reconcileObject(users, user)
+	is.True(user.Status.UserID != "")
+	is.Equal(user.Status.UserGroupID, group.Status.UserGroupID)
+
+	created := s.Users()[user.Status.UserID]
+	is.Equal(created.EmailAddress, "john@example.com")
+	is.Equal(created.GroupID, group.Status.UserGroupID)
+	is.Equal(created.Password, "secret")
+
+	// changing the secret sets the password again
+	secret.Data[tykv1alpha1.DashboardUserPasswordKey] = []byte("rotated")
+	is.NoErr(cl.Update(ctx, secret))
+
+	reconcileObject(users, user)
+	is.Equal(s.Users()[user.Status.UserID].Password, "rotated")
+
+	// the ids are used as owners of the api
+	reconcileObject(apis, api)
+	is.Equal(s.Owners(api.Status.ApiID), fake.Owners{
+		Users:  []string{user.Status.UserID},
+		Groups: []string{group.Status.UserGroupID},
+	})
+
+	is.NoErr(cl.Delete(ctx, user))
+	_, err = users.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(user)})
+	is.NoErr(err)
+	is.Equal(len(s.Users()), 0)
+
+	is.NoErr(cl.Delete(ctx, group))
+	_, err = groups.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(group)})
+	is.NoErr(err)
+	is.Equal(len(s.UserGroups()), 0)
+}
+
+func TestDashboardUserGroupReconcileCE(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	group := &tykv1alpha1.DashboardUserGroup{
+		ObjectMeta: v1.ObjectMeta{Name: "readers", Namespace: "default"},
+		Spec:       tykv1alpha1.DashboardUserGroupSpec{Name: "readers"},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{group})
+	is.NoErr(err)
+
+	r := &DashboardUserGroupReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(group)})
+	is.NoErr(err)
+	is.Equal(res.RequeueAfter, 0*res.RequeueAfter)
+
+	is.NoErr(cl.Get(context.Background(), client.ObjectKeyFromObject(group), group))
+	is.Equal(group.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+	is.Equal(group.Status.LatestTransaction.Error, ErrDashboardOnly.Error())
+}
@@ -0,0 +1,259 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ErrDashboardOnly is returned when reconciling resources that only exist on
+// the Tyk Dashboard with an OperatorContext in ce mode.
+var ErrDashboardOnly = errors.New("this resource requires a Tyk Dashboard, mode must be pro")
+
+// DashboardUserGroupReconciler reconciles a DashboardUserGroup object
+type DashboardUserGroupReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusergroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusergroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=dashboardusergroups/finalizers,verbs=update
+
+func (r *DashboardUserGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("DashboardUserGroup", req.NamespacedName.String())
+
+	log.Info("Reconciling DashboardUserGroup instance")
+
+	desired := &tykv1alpha1.DashboardUserGroup{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused DashboardUserGroup being deleted leaves its user group on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var group *model.UserGroup
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		if env.Mode != "pro" {
+			return ErrDashboardOnly
+		}
+
+		util.AddFinalizer(desired, keys.DashboardUserGroupFinalizerName)
+
+		group = &model.UserGroup{
+			ID:              desired.Status.UserGroupID,
+			OrgID:           env.Org,
+			Name:            desired.Spec.Name,
+			Description:     desired.Spec.Description,
+			UserPermissions: desired.Spec.UserPermissions,
+		}
+
+		return r.createOrUpdate(ctx, desired, group)
+	})
+
+	if err == nil {
+		log.Info("Completed reconciling DashboardUserGroup instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, group, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	// Retrying won't help until the OperatorContext is changed.
+	if errors.Is(err, ErrDashboardOnly) {
+		log.Info("DashboardUserGroup can not be reconciled", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return requeue(queueA, err)
+}
+
+// createOrUpdate creates or updates group on the dashboard. group.ID is set to
+// the id of the user group once created.
+func (r *DashboardUserGroupReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUserGroup,
+	group *model.UserGroup,
+) error {
+	ctx, span := tracing.Start(ctx, "DashboardUserGroup.createOrUpdate")
+	defer span.End()
+
+	if group.ID != "" {
+		_, err := klient.Universal.UserGroups().Get(ctx, group.ID)
+
+		switch {
+		case err == nil:
+			if isSame(desired.Status.LatestCRDSpecHash, group) {
+				return nil
+			}
+
+			r.Log.Info("Updating DashboardUserGroup", "id", group.ID)
+
+			return klient.Universal.UserGroups().Update(ctx, group)
+		case !tykClient.IsNotFound(err):
+			return err
+		}
+
+		r.Log.Info("DashboardUserGroup not found on the dashboard, creating a new one", "id", group.ID)
+	}
+
+	r.Log.Info("Creating DashboardUserGroup")
+
+	group.ID = ""
+
+	id, err := klient.Universal.UserGroups().Create(ctx, group)
+	if err != nil {
+		r.Log.Error(err, "Failed to create DashboardUserGroup")
+		return err
+	}
+
+	group.ID = id
+
+	return nil
+}
+
+// delete deletes the user group of desired from the dashboard. paused is the
+// reason its reconciliation is paused, empty if it is not, the user group is
+// then left on Tyk.
+func (r *DashboardUserGroupReconciler) delete(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUserGroup,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "DashboardUserGroup.delete")
+	defer span.End()
+
+	r.Log.Info("DashboardUserGroup being deleted",
+		"DashboardUserGroup", client.ObjectKeyFromObject(desired).String(),
+	)
+
+	if !util.ContainsFinalizer(desired, keys.DashboardUserGroupFinalizerName) {
+		return 0, nil
+	}
+
+	switch {
+	case desired.Status.UserGroupID == "":
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+	default:
+		err := klient.Universal.UserGroups().Delete(ctx, desired.Status.UserGroupID)
+		if tykClient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "Failed to delete DashboardUserGroup", "id", desired.Status.UserGroupID)
+			return queueAfter, err
+		}
+
+		recordDeleted(r.Recorder, desired, desired.Status.UserGroupID)
+	}
+
+	util.RemoveFinalizer(desired, keys.DashboardUserGroupFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. group is the user group sent to the dashboard, nil if it was not.
+func (r *DashboardUserGroupReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.DashboardUserGroup,
+	group *model.UserGroup,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	if err != nil {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	if group != nil && group.ID != "" {
+		status.UserGroupID = group.ID
+
+		if err == nil {
+			status.LatestCRDSpecHash = calculateHash(group)
+		}
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DashboardUserGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.DashboardUserGroup{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedDashboardUserGroups
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("DashboardUserGroup", r))
+}
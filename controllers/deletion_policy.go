@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrInvalidDeletionPolicy is returned for resources whose tyk.io/deletion-policy
+// annotation is neither Retain nor Delete. They are not deleted from Tyk, nor
+// released, until the annotation is fixed.
+var ErrInvalidDeletionPolicy = errors.New("tyk.io/deletion-policy must be Retain or Delete")
+
+// retained returns true if the object of the resource o on Tyk is kept when o
+// is deleted, as set by its tyk.io/deletion-policy annotation. Objects are
+// deleted by default.
+func retained(o metav1.Object) bool {
+	return strings.EqualFold(o.GetAnnotations()[keys.DeletionPolicyAnnotation], keys.DeletionPolicyRetain)
+}
+
+// checkDeletionPolicy returns ErrInvalidDeletionPolicy if the tyk.io/deletion-policy
+// annotation of o holds an unknown value, so that a typo never deletes an object
+// meant to be retained.
+func checkDeletionPolicy(o metav1.Object) error {
+	v, ok := o.GetAnnotations()[keys.DeletionPolicyAnnotation]
+	if !ok || strings.EqualFold(v, keys.DeletionPolicyRetain) || strings.EqualFold(v, keys.DeletionPolicyDelete) {
+		return nil
+	}
+
+	return fmt.Errorf("%w, got %q", ErrInvalidDeletionPolicy, v)
+}
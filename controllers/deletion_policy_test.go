@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDeletionPolicyRetain(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	retain := map[string]string{keys.DeletionPolicyAnnotation: keys.DeletionPolicyRetain}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default", Annotations: retain},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Name: "gold", Namespace: "default", Annotations: retain},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{Name: "gold"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{api, policy})
+	is.NoErr(err)
+
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+
+	env := s.Env()
+	env.OperatorID = "cluster-a"
+
+	apiReconciler := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env, Recorder: recorder}
+	apiReq := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+
+	policyReconciler := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Env: env, Recorder: recorder}
+	policyReq := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	_, err = apiReconciler.Reconcile(ctx, apiReq)
+	is.NoErr(err)
+
+	_, err = policyReconciler.Reconcile(ctx, policyReq)
+	is.NoErr(err)
+
+	is.Equal(len(s.APIs()), 1)
+	is.Equal(len(s.Policies()), 1)
+	is.Equal(apiStamp(s.APIs()[0].ConfigData), "cluster-a")
+	is.Equal(s.Policies()[0].MetaData[keys.OperatorIDKey], "cluster-a")
+
+	is.NoErr(cl.Get(ctx, apiReq.NamespacedName, api))
+	is.NoErr(cl.Delete(ctx, api))
+
+	_, err = apiReconciler.Reconcile(ctx, apiReq)
+	is.NoErr(err)
+
+	is.NoErr(cl.Get(ctx, policyReq.NamespacedName, policy))
+	is.NoErr(cl.Delete(ctx, policy))
+
+	_, err = policyReconciler.Reconcile(ctx, policyReq)
+	is.NoErr(err)
+
+	// The resources are gone, their objects are still served by Tyk.
+	is.True(k8sErrors.IsNotFound(cl.Get(ctx, apiReq.NamespacedName, &tykv1alpha1.ApiDefinition{})))
+	is.True(k8sErrors.IsNotFound(cl.Get(ctx, policyReq.NamespacedName, &tykv1alpha1.SecurityPolicy{})))
+	is.Equal(len(s.APIs()), 1)
+	is.Equal(len(s.Policies()), 1)
+
+	// They are no longer stamped, the garbage collection leaves them alone.
+	is.Equal(apiStamp(s.APIs()[0].ConfigData), "")
+	is.Equal(s.Policies()[0].MetaData[keys.OperatorIDKey], "")
+
+	gc := OperatorContextReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+	orphans, err := gc.orphans(s.Context(ctx), env)
+	is.NoErr(err)
+	is.Equal(len(orphans), 0)
+}
+
+func TestDeletionPolicyInvalid(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Name: "gold", Namespace: "default"},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{Name: "gold"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{policy})
+	is.NoErr(err)
+
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+	r := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(<-recorder.Events, "Normal Synced The latest generation of the resource is reconciled")
+
+	// A typo in the annotation neither deletes the policy nor releases the
+	// resource.
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	policy.Annotations = map[string]string{keys.DeletionPolicyAnnotation: "Retian"}
+	is.NoErr(cl.Update(ctx, policy))
+	is.NoErr(cl.Delete(ctx, policy))
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrInvalidDeletionPolicy))
+	is.Equal(<-recorder.Events, `Warning InvalidDeletionPolicy tyk.io/deletion-policy must be Retain or Delete, got "Retian"`)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	is.True(util.ContainsFinalizer(policy, policyFinalizer))
+	is.Equal(meta.FindStatusCondition(policy.Status.Conditions, tykv1alpha1.ConditionReady).Reason,
+		ReasonInvalidDeletionPolicy)
+	is.Equal(len(s.Policies()), 1)
+
+	policy.Annotations[keys.DeletionPolicyAnnotation] = keys.DeletionPolicyRetain
+	is.NoErr(cl.Update(ctx, policy))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	is.True(k8sErrors.IsNotFound(cl.Get(ctx, req.NamespacedName, &tykv1alpha1.SecurityPolicy{})))
+	is.Equal(len(s.Policies()), 1)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/jsondiff"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxDriftedFields is the maximum number of fields named in a drift summary.
+const maxDriftedFields = 5
+
+// driftPolicy returns the drift policy of the environment of ctx.
+func driftPolicy(ctx context.Context) v1alpha1.DriftPolicy {
+	if p := tykClient.GetTykMode(ctx).DriftPolicy; p != "" {
+		return p
+	}
+
+	return v1alpha1.DriftCorrect
+}
+
+// resyncAfter returns how long to wait before comparing a resource of env with
+// Tyk again, when it would otherwise be reconciled after queueA.
+func resyncAfter(env environment.Env, queueA time.Duration) time.Duration {
+	if r := env.ResyncInterval; r != nil && r.Duration > 0 && (queueA == 0 || r.Duration < queueA) {
+		return r.Duration
+	}
+
+	return queueA
+}
+
+// drifted returns true if the object of a resource on Tyk, whose hash is
+// tykHash, was changed outside of the operator since the resource was last
+// applied. Changes of the resource itself are applied whatever the drift
+// policy, they are not drifts.
+func drifted(latestTykHash, latestCRDHash string, spec, onTyk interface{}) bool {
+	return latestTykHash != "" && isSame(latestCRDHash, spec) && !isSame(latestTykHash, onTyk)
+}
+
+// driftedFields returns the sorted JSON paths of the fields of want whose value
+// differs in got. Fields only set in got, such as those filled by Tyk, are
+// ignored.
+func driftedFields(want, got interface{}) []string {
+	changes, err := jsondiff.Diff(want, got)
+	if err != nil {
+		return nil
+	}
+
+	return jsondiff.Paths(changes)
+}
+
+// driftSummary describes the drifted fields in a single line.
+func driftSummary(fields []string) string {
+	switch {
+	case len(fields) == 0:
+		return "fields not managed by the operator changed"
+	case len(fields) > maxDriftedFields:
+		return fmt.Sprintf("%s changed and %d more fields",
+			strings.Join(fields[:maxDriftedFields], ", "), len(fields)-maxDriftedFields)
+	}
+
+	return strings.Join(fields, ", ") + " changed"
+}
+
+// setDrifted sets the Drifted condition in conditions, True with a summary of
+// the drifted fields when drift is true.
+func setDrifted(conditions *[]metav1.Condition, generation int64, drift bool, fields []string) {
+	c := metav1.Condition{
+		Type:               v1alpha1.ConditionDrifted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "InSync",
+		Message:            "The object on Tyk matches the resource",
+	}
+
+	if drift {
+		c.Status = metav1.ConditionTrue
+		c.Reason = "ChangedOnTyk"
+		c.Message = driftSummary(fields)
+	}
+
+	meta.SetStatusCondition(conditions, c)
+}
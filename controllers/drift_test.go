@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/matryer/is"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionDrift(t *testing.T) {
+	tests := []struct {
+		policy tykv1alpha1.DriftPolicy
+		name   string
+		event  string
+		status v1.ConditionStatus
+	}{
+		{policy: tykv1alpha1.DriftCorrect, name: "httpbin", event: "Normal DriftCorrected", status: v1.ConditionFalse},
+		{policy: tykv1alpha1.DriftReport, name: "changed", event: "Warning Drifted", status: v1.ConditionTrue},
+		{policy: tykv1alpha1.DriftIgnore, name: "changed", status: v1.ConditionFalse},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(string(tt.policy), func(t *testing.T) {
+			is := is.New(t)
+
+			s := fake.NewGateway()
+			defer s.Close()
+
+			api := &tykv1alpha1.ApiDefinition{
+				ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+				Spec: tykv1alpha1.APIDefinitionSpec{
+					APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+				},
+			}
+
+			cl, err := NewFakeClient([]runtime.Object{api})
+			is.NoErr(err)
+
+			env := s.Env()
+			env.DriftPolicy = tt.policy
+			env.ResyncInterval = &v1.Duration{Duration: 10 * time.Minute}
+
+			recorder := record.NewFakeRecorder(10)
+			r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env, Recorder: recorder}
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+			ctx := context.Background()
+
+			res, err := r.Reconcile(ctx, req)
+			is.NoErr(err)
+			is.Equal(res.RequeueAfter, 10*time.Minute)
+			is.True(strings.HasPrefix(<-recorder.Events, "Normal Synced"))
+
+			// The API is changed on the gateway, outside of the operator.
+			def := s.APIs()[0]
+			def.Name = "changed"
+			_, err = klient.Universal.Api().Update(s.Context(ctx), &def)
+			is.NoErr(err)
+
+			res, err = r.Reconcile(ctx, req)
+			is.NoErr(err)
+			is.Equal(res.RequeueAfter, 10*time.Minute)
+			is.Equal(s.APIs()[0].Name, tt.name)
+
+			select {
+			case e := <-recorder.Events:
+				is.True(strings.HasPrefix(e, tt.event))
+				is.True(strings.Contains(e, "name changed"))
+			default:
+				is.Equal(tt.event, "")
+			}
+
+			is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+
+			c := meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionDrifted)
+			is.True(c != nil)
+			is.Equal(c.Status, tt.status)
+		})
+	}
+}
+
+func TestDriftedFields(t *testing.T) {
+	is := is.New(t)
+
+	want := map[string]interface{}{
+		"name":  "httpbin",
+		"proxy": map[string]interface{}{"listen_path": "/httpbin", "strip_listen_path": true},
+		"tags":  []string{"a"},
+	}
+	got := map[string]interface{}{
+		"name":   "httpbin",
+		"proxy":  map[string]interface{}{"listen_path": "/changed", "strip_listen_path": true},
+		"tags":   []string{"a", "b"},
+		"api_id": "set by tyk",
+	}
+
+	fields := driftedFields(want, got)
+	is.Equal(fields, []string{"proxy.listen_path", "tags"})
+	is.Equal(driftSummary(fields), "proxy.listen_path, tags changed")
+	is.Equal(driftSummary(nil), "fields not managed by the operator changed")
+	is.Equal(driftSummary([]string{"a", "b", "c", "d", "e", "f", "g"}), "a, b, c, d, e changed and 2 more fields")
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewDryRunClient returns the client of an operator in dry run mode, which
+// writes to the cluster with c. The finalizers of deleted resources are kept,
+// their objects are still on Tyk, and the statuses written are marked with the
+// DryRun condition.
+func NewDryRunClient(c client.Client) client.Client {
+	return dryRunClient{Client: c}
+}
+
+type dryRunClient struct {
+	client.Client
+}
+
+// Update leaves deleted objects as they are, it would remove their finalizer.
+func (c dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch leaves deleted objects as they are, it would remove their finalizer.
+func (c dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c dryRunClient) Status() client.StatusWriter {
+	return dryRunStatusWriter{StatusWriter: c.Client.Status(), reader: c.Client}
+}
+
+type dryRunStatusWriter struct {
+	client.StatusWriter
+	reader client.Reader
+}
+
+func (w dryRunStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w.setDryRun(ctx, obj)
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w dryRunStatusWriter) Patch(
+	ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+) error {
+	w.setDryRun(ctx, obj)
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// setDryRun sets the DryRun condition in the status of obj, if it has
+// conditions. setConditions removes it, so its transition time is read from
+// the stored object: a status left unchanged by a reconciliation is not
+// changed, and does not trigger another one.
+func (w dryRunStatusWriter) setDryRun(ctx context.Context, obj client.Object) {
+	conditions := statusConditions(obj)
+	if conditions == nil {
+		return
+	}
+
+	c := metav1.Condition{
+		Type:               v1alpha1.ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             "DryRun",
+		Message:            "Changes of the resource are recorded as events and not applied to Tyk",
+	}
+
+	if stored, ok := obj.DeepCopyObject().(client.Object); ok &&
+		w.reader.Get(ctx, client.ObjectKeyFromObject(obj), stored) == nil {
+		if o := meta.FindStatusCondition(*statusConditions(stored), c.Type); o != nil && o.Status == c.Status {
+			c.LastTransitionTime = o.LastTransitionTime
+		}
+	}
+
+	meta.SetStatusCondition(conditions, c)
+}
+
+// statusConditions returns the conditions of the status of o, nil if it has
+// none.
+func statusConditions(o client.Object) *[]metav1.Condition {
+	v := reflect.ValueOf(o)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	status := v.Elem().FieldByName("Status")
+	if status.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := status.FieldByName("Conditions")
+	if !field.IsValid() {
+		return nil
+	}
+
+	conditions, _ := field.Addr().Interface().(*[]metav1.Condition)
+
+	return conditions
+}
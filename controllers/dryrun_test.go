@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/dryrun"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionDryRun(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	recorder := record.NewFakeRecorder(10)
+
+	klient.DryRunRecorder = dryrun.NewRecorder(recorder)
+	defer func() { klient.DryRunRecorder = nil }()
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{api})
+	is.NoErr(err)
+
+	env := s.Env()
+	env.DryRun = true
+
+	r := ApiDefinitionReconciler{
+		Client:   NewDryRunClient(cl),
+		Log:      log.NullLogger{},
+		Env:      env,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	// Tyk did not change, the status of the resource records the dry run.
+	is.Equal(len(s.APIs()), 0)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(meta.IsStatusConditionTrue(api.Status.Conditions, tykv1alpha1.ConditionDryRun))
+
+	created := <-recorder.Events
+	is.True(strings.HasPrefix(created, "Normal "+dryrun.ReasonCreate+" Would create API "))
+	is.True(strings.Contains(created, `{"path":"name","current":null,"desired":"httpbin"}`))
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal "+dryrun.ReasonHotReload))
+
+	// Reconciling the unchanged resource again records nothing new, nor does it
+	// change its status.
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(recorder.Events), 0)
+
+	again := &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, again))
+	is.Equal(again.Status, api.Status)
+
+	// Changes of an applied ApiDefinition are diffed against Tyk.
+	live := r
+	live.Client = cl
+	live.Env = s.Env()
+
+	_, err = live.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 1)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionDryRun) == nil)
+
+	api.Spec.Name = "httpbin-v2"
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	updated := <-recorder.Events
+	is.True(strings.HasPrefix(updated, "Normal "+dryrun.ReasonUpdate+" Would update API "+api.Status.ApiID))
+	is.True(strings.Contains(updated, `{"path":"name","current":"httpbin","desired":"httpbin-v2"}`))
+	is.Equal(s.APIs()[0].Name, "httpbin")
+
+	// The hot reload was already recorded.
+	is.Equal(len(recorder.Events), 0)
+
+	// The deletion is recorded, the finalizer is kept as the API is still on Tyk.
+	is.NoErr(cl.Delete(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal "+dryrun.ReasonDelete+" Would delete API "+api.Status.ApiID))
+	is.Equal(len(s.APIs()), 1)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(util.ContainsFinalizer(api, keys.ApiDefFinalizerName))
+}
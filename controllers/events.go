@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of the events recorded by every reconciler.
+const (
+	ReasonSynced                = "Synced"
+	ReasonSyncFailed            = "SyncFailed"
+	ReasonDependencyMissing     = "DependencyMissing"
+	ReasonCertificateUploaded   = "CertificateUploaded"
+	ReasonCompositionFailed     = "CompositionFailed"
+	ReasonDeleted               = "Deleted"
+	ReasonInvalidDeletionPolicy = "InvalidDeletionPolicy"
+	ReasonLeftOnTyk             = "LeftOnTyk"
+	ReasonReloadPending         = "ReloadPending"
+)
+
+// ErrCompositionFailed is returned when the SDLs of the subgraphs of a
+// supergraph can't be merged.
+var ErrCompositionFailed = errors.New("cannot compose the supergraph")
+
+// recordEvent records an event of o with recorder. Reconcilers built without a
+// recorder, as in tests, record nothing.
+func recordEvent(
+	recorder record.EventRecorder,
+	o runtime.Object,
+	eventType, reason, messageFmt string,
+	args ...interface{},
+) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.Eventf(o, eventType, reason, messageFmt, args...)
+}
+
+// recordSync records as an event the reconciliation of o, which ended with err,
+// before it is recorded in conditions, the conditions of o. Failures are always
+// recorded, successes only once per generation, as resources are reconciled
+// again periodically.
+func recordSync(recorder record.EventRecorder, o client.Object, conditions []metav1.Condition, err error) {
+	switch {
+	case errors.Is(err, ErrReloadPending):
+		// The gateways are still loading the resource, its outcome is recorded
+		// once they did or the verification timed out.
+	case errors.Is(err, ErrCompositionFailed):
+		recordEvent(recorder, o, v1.EventTypeWarning, ReasonCompositionFailed, "%v", err)
+	case dependencyMissing(err):
+		recordEvent(recorder, o, v1.EventTypeWarning, ReasonDependencyMissing, "%v", err)
+	case errors.Is(err, ErrInvalidDeletionPolicy):
+		recordEvent(recorder, o, v1.EventTypeWarning, ReasonInvalidDeletionPolicy, "%v", err)
+	case err != nil:
+		recordEvent(recorder, o, v1.EventTypeWarning, ReasonSyncFailed, "%v", err)
+	default:
+		c := meta.FindStatusCondition(conditions, v1alpha1.ConditionSynced)
+		if c == nil || c.Status != metav1.ConditionTrue || c.ObservedGeneration != o.GetGeneration() {
+			recordEvent(recorder, o, v1.EventTypeNormal, ReasonSynced, "The latest generation of the resource is reconciled")
+		}
+	}
+}
+
+// recordDeleted records the deletion from Tyk of the object of o with id. The
+// id of objects holding secrets, as keys, is left out.
+func recordDeleted(recorder record.EventRecorder, o client.Object, id string) {
+	kind := reflect.TypeOf(o).Elem().Name()
+	if id != "" {
+		kind += " " + id
+	}
+
+	recordEvent(recorder, o, v1.EventTypeNormal, ReasonDeleted, "Deleted %s from Tyk", kind)
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/matryer/is"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRecordSync(t *testing.T) {
+	is := is.New(t)
+
+	recorder := record.NewFakeRecorder(10)
+	api := &tykv1alpha1.ApiDefinition{ObjectMeta: v1.ObjectMeta{Generation: 1}}
+	status := &api.Status
+
+	sync := func(err error) {
+		recordSync(recorder, api, status.Conditions, err)
+		setConditions(&status.Conditions, &status.ObservedGeneration, api.Generation, err)
+	}
+
+	sync(nil)
+	is.Equal(<-recorder.Events, "Normal Synced The latest generation of the resource is reconciled")
+
+	// Resources are reconciled again periodically, a generation is synced once.
+	sync(nil)
+	is.Equal(len(recorder.Events), 0)
+
+	sync(errors.New("tyk is down"))
+	is.Equal(<-recorder.Events, "Warning SyncFailed tyk is down")
+
+	sync(nil)
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal Synced"))
+
+	api.Generation = 2
+
+	sync(ErrDependencyNotReady)
+	is.True(strings.HasPrefix(<-recorder.Events, "Warning DependencyMissing"))
+
+	// Reconcilers built without a recorder record nothing.
+	recordSync(nil, api, status.Conditions, nil)
+	recordDeleted(nil, api, "httpbin")
+
+	recordDeleted(recorder, api, "httpbin")
+	is.Equal(<-recorder.Events, "Normal Deleted Deleted ApiDefinition httpbin from Tyk")
+
+	recordDeleted(recorder, &tykv1alpha1.ApiKey{}, "")
+	is.Equal(<-recorder.Events, "Normal Deleted Deleted ApiKey from Tyk")
+}
+
+func TestSuperGraphCompositionFailed(t *testing.T) {
+	is := is.New(t)
+
+	subGraph := &tykv1alpha1.SubGraph{
+		ObjectMeta: v1.ObjectMeta{Name: "users", Namespace: "default"},
+		Spec: tykv1alpha1.SubGraphSpec{
+			SubGraphSpec: model.SubGraphSpec{SDL: "type Query {"},
+		},
+	}
+
+	superGraph := &tykv1alpha1.SuperGraph{
+		ObjectMeta: v1.ObjectMeta{Name: "social", Namespace: "default"},
+		Spec: tykv1alpha1.SuperGraphSpec{
+			SuperGraphSpec: model.SuperGraphSpec{
+				SubgraphRefs: []model.Target{{Name: "users"}},
+			},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{subGraph, superGraph})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := SuperGraphReconciler{Client: cl, Log: log.NullLogger{}, Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(superGraph)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrCompositionFailed))
+	is.True(strings.HasPrefix(<-recorder.Events, "Warning CompositionFailed cannot compose the supergraph: "))
+
+	superGraph = &tykv1alpha1.SuperGraph{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, superGraph))
+
+	c := meta.FindStatusCondition(superGraph.Status.Conditions, tykv1alpha1.ConditionReady)
+	is.True(c != nil)
+	is.Equal(c.Reason, ReasonCompositionFailed)
+}
@@ -0,0 +1,396 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/snapshot"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultGarbageCollectionInterval = time.Hour
+
+// owners holds the resources of the cluster objects on Tyk may be created for,
+// by id of these objects and by namespace/name of the resources.
+type owners struct {
+	ids       map[string]bool
+	resources map[types.NamespacedName]bool
+}
+
+func newOwners() *owners {
+	return &owners{ids: make(map[string]bool), resources: make(map[types.NamespacedName]bool)}
+}
+
+// add records the resource o holding the objects identified by ids.
+func (w *owners) add(o client.Object, ids ...*string) {
+	w.resources[client.ObjectKeyFromObject(o)] = true
+
+	for _, id := range ids {
+		if id != nil && *id != "" {
+			w.ids[*id] = true
+		}
+	}
+}
+
+// own returns true if the object identified by id, created for the resource
+// key, is held by a resource of the cluster.
+func (w *owners) own(id string, key types.NamespacedName) bool {
+	return w.ids[id] || w.resources[key]
+}
+
+// ClusterID returns the uid of the kube-system namespace, which identifies the
+// cluster the operator runs in.
+func ClusterID(ctx context.Context, reader client.Reader) (string, error) {
+	var ns v1.Namespace
+	if err := reader.Get(ctx, types.NamespacedName{Name: metav1.NamespaceSystem}, &ns); err != nil {
+		return "", err
+	}
+
+	return string(ns.UID), nil
+}
+
+// stampAPI records operatorID, the identity of the operator creating def, in
+// its config data.
+func stampAPI(def *model.APIDefinitionSpec, operatorID string) {
+	if operatorID == "" {
+		return
+	}
+
+	if def.ConfigData == nil {
+		def.ConfigData = &model.MapStringInterfaceType{}
+	}
+
+	if def.ConfigData.Object == nil {
+		def.ConfigData.Object = make(map[string]interface{})
+	}
+
+	def.ConfigData.Object[keys.OperatorIDKey] = operatorID
+}
+
+// stampPolicy records operatorID, the identity of the operator creating spec,
+// in its meta data.
+func stampPolicy(spec *model.SecurityPolicySpec, operatorID string) {
+	if operatorID == "" {
+		return
+	}
+
+	if spec.MetaData == nil {
+		spec.MetaData = make(map[string]string)
+	}
+
+	spec.MetaData[keys.OperatorIDKey] = operatorID
+}
+
+// apiStamp returns the identity of the operator that created the API whose
+// config data is configData, empty if it was not stamped.
+func apiStamp(configData *model.MapStringInterfaceType) string {
+	if configData == nil {
+		return ""
+	}
+
+	id, _ := configData.Object[keys.OperatorIDKey].(string) //nolint:errcheck
+
+	return id
+}
+
+// unstampAPI removes the stamp of the operator from the API id on Tyk, retained
+// when its resource is deleted, so that it is never collected.
+func unstampAPI(ctx context.Context, id string) error {
+	def, err := klient.Universal.Api().Get(ctx, id)
+
+	switch {
+	case tykClient.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case apiStamp(def.ConfigData) == "":
+		return nil
+	}
+
+	delete(def.ConfigData.Object, keys.OperatorIDKey)
+
+	if _, err := klient.Universal.Api().Update(ctx, def); err != nil {
+		return err
+	}
+
+	return hotReload(ctx)
+}
+
+// unstampPolicy removes the stamp of the operator from the policy id on Tyk,
+// retained when its resource is deleted, so that it is never collected.
+func unstampPolicy(ctx context.Context, id string) error {
+	spec, err := klient.Universal.Portal().Policy().Get(ctx, id)
+
+	switch {
+	case tykClient.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case spec.MetaData[keys.OperatorIDKey] == "":
+		return nil
+	}
+
+	delete(spec.MetaData, keys.OperatorIDKey)
+
+	if err := klient.Universal.Portal().Policy().Update(ctx, spec); err != nil {
+		return err
+	}
+
+	return hotReload(ctx)
+}
+
+// managedResource returns the namespace/name of the resource an object on Tyk
+// was created for, read from the k8sName and k8sNamespace keys of its config
+// data or decoded from its id generated by EncodeNS. ok is false if the object
+// was not created by the operator.
+func managedResource(id string, configData *model.MapStringInterfaceType) (key types.NamespacedName, ok bool) {
+	if configData != nil {
+		name, _ := configData.Object[snapshot.NameKey].(string)           //nolint:errcheck
+		namespace, _ := configData.Object[snapshot.NamespaceKey].(string) //nolint:errcheck
+
+		if name != "" && namespace != "" {
+			return types.NamespacedName{Namespace: namespace, Name: name}, true
+		}
+	}
+
+	namespace, name := decodeID(id)
+
+	// Ids generated by Tyk may decode as well, only those the operator
+	// generates are encoded back to themselves.
+	if len(validation.IsDNS1123Label(namespace)) != 0 || len(validation.IsDNS1123Subdomain(name)) != 0 ||
+		EncodeNS(namespace+"/"+name) != id {
+		return types.NamespacedName{}, false
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// collectGarbage reports or deletes the APIs and policies created on the Tyk of
+// desired for resources which no longer exist, once per interval of its
+// garbage collection.
+func (r *OperatorContextReconciler) collectGarbage(
+	ctx context.Context,
+	desired *v1alpha1.OperatorContext,
+) (ctrl.Result, error) {
+	gc := desired.Spec.GarbageCollection
+
+	interval := defaultGarbageCollectionInterval
+	if gc.Interval != nil && gc.Interval.Duration > 0 {
+		interval = gc.Interval.Duration
+	}
+
+	// The OperatorContext is also reconciled when its status or its secrets
+	// change.
+	if last := desired.Status.GarbageCollection; last != nil {
+		if next := time.Until(last.Time.Add(interval)); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+	}
+
+	log := r.Log.WithValues("OperatorContext", client.ObjectKeyFromObject(desired).String())
+
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctx, span := tracing.Start(ctx, "OperatorContext.collectGarbage")
+	defer span.End()
+
+	orphans, err := r.orphans(ctx, env)
+	if err != nil {
+		log.Error(err, "Failed to list orphaned objects")
+		return ctrl.Result{}, err
+	}
+
+	status := &v1alpha1.GarbageCollectionStatus{Time: metav1.Now()}
+
+	for i := range orphans {
+		o := &orphans[i]
+
+		if gc.DryRun {
+			r.Recorder.Eventf(desired, v1.EventTypeWarning, "Orphaned",
+				"%s %s created for %s has no resource", o.Kind, o.ID, o.Resource)
+
+			status.Orphans = append(status.Orphans, *o)
+
+			continue
+		}
+
+		log.Info("Deleting orphaned object", "kind", o.Kind, "id", o.ID, "resource", o.Resource)
+
+		if err := deleteOrphan(ctx, o); err != nil {
+			log.Error(err, "Failed to delete orphaned object", "kind", o.Kind, "id", o.ID)
+			r.Recorder.Eventf(desired, v1.EventTypeWarning, "OrphanDeletionFailed",
+				"Failed to delete %s %s created for %s: %v", o.Kind, o.ID, o.Resource, err)
+
+			o.Error = err.Error()
+			status.Orphans = append(status.Orphans, *o)
+
+			continue
+		}
+
+		r.Recorder.Eventf(desired, v1.EventTypeNormal, "OrphanDeleted",
+			"Deleted %s %s created for %s", o.Kind, o.ID, o.Resource)
+
+		status.Deleted++
+	}
+
+	if status.Deleted != 0 {
+		if err := hotReload(ctx); err != nil {
+			log.Error(err, "Failed to hot-reload Tyk after deleting orphaned objects")
+			return ctrl.Result{}, err
+		}
+	}
+
+	desired.Status.GarbageCollection = status
+
+	return ctrl.Result{RequeueAfter: interval}, r.Status().Update(ctx, desired)
+}
+
+// orphans returns the APIs and policies on the Tyk of ctx, created by this
+// operator for resources which do not exist in the cluster. Objects stamped by
+// the operator of another cluster, or not stamped at all, are never orphans.
+func (r *OperatorContextReconciler) orphans(
+	ctx context.Context,
+	env environment.Env,
+) ([]v1alpha1.OrphanedObject, error) {
+	operatorID := r.Env.OperatorID
+	if operatorID == "" {
+		return nil, nil
+	}
+
+	apiOwners, policyOwners, err := r.owners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apis, err := klient.Universal.Api().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resources of other namespaces are not seen by an operator watching
+	// some namespaces only.
+	watched := func(key types.NamespacedName) bool {
+		return r.Env.Watches(key.Namespace)
+	}
+
+	var o []v1alpha1.OrphanedObject
+
+	for _, def := range apis.Apis {
+		if def == nil || def.APIID == nil || apiStamp(def.ConfigData) != operatorID {
+			continue
+		}
+
+		id := *def.APIID
+
+		key, ok := managedResource(id, def.ConfigData)
+		if ok && watched(key) && !apiOwners.own(id, key) {
+			o = append(o, v1alpha1.OrphanedObject{Kind: "ApiDefinition", ID: id, Resource: key.String()})
+		}
+	}
+
+	policies, err := klient.Universal.Portal().Policy().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range policies {
+		pol := &policies[i]
+		if pol.ID == nil || pol.MetaData[keys.OperatorIDKey] != operatorID {
+			continue
+		}
+
+		key, ok := managedResource(*pol.ID, nil)
+		if !ok || !watched(key) || policyOwners.own(*pol.ID, key) {
+			continue
+		}
+
+		// Policies are deleted from the dashboard by their mongo id.
+		id := *pol.ID
+		if env.Mode == "pro" && pol.MID != nil && *pol.MID != "" {
+			if policyOwners.ids[*pol.MID] {
+				continue
+			}
+
+			id = *pol.MID
+		}
+
+		o = append(o, v1alpha1.OrphanedObject{Kind: "SecurityPolicy", ID: id, Resource: key.String()})
+	}
+
+	return o, nil
+}
+
+// owners returns the resources of the cluster holding APIs and policies. They
+// are read from all shards, so that the objects of other shards are not taken
+// for orphans.
+func (r *OperatorContextReconciler) owners(ctx context.Context) (apis, policies *owners, err error) {
+	var reader client.Reader = r.Client
+	if r.Reader != nil {
+		reader = r.Reader
+	}
+
+	var apiDefinitions v1alpha1.ApiDefinitionList
+	if err := reader.List(ctx, &apiDefinitions); err != nil {
+		return nil, nil, err
+	}
+
+	var oasDefinitions v1alpha1.TykOasApiDefinitionList
+	if err := reader.List(ctx, &oasDefinitions); err != nil {
+		return nil, nil, err
+	}
+
+	var securityPolicies v1alpha1.SecurityPolicyList
+	if err := reader.List(ctx, &securityPolicies); err != nil {
+		return nil, nil, err
+	}
+
+	apis = newOwners()
+
+	for i := range apiDefinitions.Items {
+		api := &apiDefinitions.Items[i]
+		apis.add(api, api.Spec.APIID, &api.Status.ApiID)
+	}
+
+	for i := range oasDefinitions.Items {
+		def := &oasDefinitions.Items[i]
+		apis.add(def, &def.Status.ApiID)
+	}
+
+	policies = newOwners()
+
+	for i := range securityPolicies.Items {
+		pol := &securityPolicies.Items[i]
+		policies.add(pol, pol.Spec.ID, pol.Spec.MID, &pol.Status.PolID)
+	}
+
+	return apis, policies, nil
+}
+
+// deleteOrphan deletes the orphaned object o from Tyk.
+func deleteOrphan(ctx context.Context, o *v1alpha1.OrphanedObject) error {
+	var err error
+
+	switch o.Kind {
+	case "ApiDefinition":
+		_, err = klient.Universal.Api().Delete(ctx, o.ID)
+	case "SecurityPolicy":
+		err = klient.Universal.Portal().Policy().Delete(ctx, o.ID)
+	}
+
+	return tykClient.IgnoreNotFound(err)
+}
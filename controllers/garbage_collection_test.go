@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestOperatorContextGarbageCollection(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := context.Background()
+	tyk := s.Context(ctx)
+
+	// httpbin still exists, gone was deleted without its finalizer and manual
+	// was not created by the operator.
+	for _, id := range []string{EncodeNS("default/httpbin"), EncodeNS("default/gone"), "manual"} {
+		def := &model.APIDefinitionSpec{Name: id, APIID: &id}
+		if id != "manual" {
+			stampAPI(def, "cluster-a")
+		}
+
+		_, err := klient.Universal.Api().Create(tyk, def)
+		is.NoErr(err)
+	}
+
+	polID := EncodeNS("default/gold")
+	policy := &tykv1alpha1.SecurityPolicySpec{SecurityPolicySpec: model.SecurityPolicySpec{Name: "gold", ID: &polID}}
+	stampPolicy(&policy.SecurityPolicySpec, "cluster-a")
+	is.NoErr(klient.Universal.Portal().Policy().Create(tyk, policy))
+
+	env := s.Env()
+	opCtx := &tykv1alpha1.OperatorContext{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       "tyk",
+			Namespace:  "default",
+			Finalizers: []string{keys.OperatorContextFinalizerName},
+		},
+		Spec: tykv1alpha1.OperatorContextSpec{
+			Env:               &env.Environment,
+			GarbageCollection: &tykv1alpha1.GarbageCollection{DryRun: true},
+		},
+	}
+
+	api := &tykv1alpha1.ApiDefinition{ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"}}
+
+	cl, err := NewFakeClient([]runtime.Object{opCtx, api})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := OperatorContextReconciler{
+		Client:   cl,
+		Log:      log.NullLogger{},
+		Recorder: recorder,
+		Env:      environment.Env{OperatorID: "cluster-a"},
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(opCtx)}
+
+	res, err := r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(res.RequeueAfter, time.Hour)
+	is.Equal(len(s.APIs()), 3)
+	is.Equal(len(s.Policies()), 1)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, opCtx))
+	is.Equal(opCtx.Status.GarbageCollection.Orphans, []tykv1alpha1.OrphanedObject{
+		{Kind: "ApiDefinition", ID: EncodeNS("default/gone"), Resource: "default/gone"},
+		{Kind: "SecurityPolicy", ID: polID, Resource: "default/gold"},
+	})
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal Synced"))
+	is.True(strings.HasPrefix(<-recorder.Events, "Warning Orphaned"))
+	is.True(strings.HasPrefix(<-recorder.Events, "Warning Orphaned"))
+
+	// Objects are collected once per interval.
+	res, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.True(res.RequeueAfter > 0 && res.RequeueAfter <= time.Hour)
+	is.Equal(len(recorder.Events), 0)
+
+	opCtx.Spec.GarbageCollection = &tykv1alpha1.GarbageCollection{Interval: &v1.Duration{Duration: time.Minute}}
+	opCtx.Status.GarbageCollection.Time = v1.NewTime(time.Now().Add(-time.Minute))
+	is.NoErr(cl.Update(ctx, opCtx))
+
+	res, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(res.RequeueAfter, time.Minute)
+	is.Equal(len(s.Policies()), 0)
+
+	var ids []string
+	for _, def := range s.APIs() {
+		ids = append(ids, *def.APIID)
+	}
+
+	is.Equal(ids, []string{EncodeNS("default/httpbin"), "manual"})
+	is.True(strings.HasPrefix(<-recorder.Events, "Normal OrphanDeleted"))
+
+	var collected tykv1alpha1.OperatorContext
+	is.NoErr(cl.Get(ctx, req.NamespacedName, &collected))
+	is.Equal(collected.Status.GarbageCollection.Deleted, 2)
+	is.Equal(len(collected.Status.GarbageCollection.Orphans), 0)
+}
+
+func TestManagedResource(t *testing.T) {
+	is := is.New(t)
+
+	key, ok := managedResource(EncodeNS("default/httpbin"), nil)
+	is.True(ok)
+	is.Equal(key, types.NamespacedName{Namespace: "default", Name: "httpbin"})
+
+	configData := &model.MapStringInterfaceType{}
+	configData.Object = map[string]interface{}{"k8sName": "httpbin", "k8sNamespace": "apis"}
+
+	key, ok = managedResource("5f1c7b3e", configData)
+	is.True(ok)
+	is.Equal(key, types.NamespacedName{Namespace: "apis", Name: "httpbin"})
+
+	_, ok = managedResource("5f1c7b3e", nil)
+	is.True(!ok)
+
+	_, ok = managedResource(EncodeNS("Not A/Namespace"), nil)
+	is.True(!ok)
+}
+
+func TestGarbageCollectionAcrossClusters(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	// cluster applies an ApiDefinition named after itself to the shared Tyk,
+	// and collects the orphans it finds there.
+	cluster := func(operatorID string) (client.Client, *OperatorContextReconciler, reconcile.Request) {
+		env := s.Env()
+		env.OperatorID = operatorID
+
+		opCtx := &tykv1alpha1.OperatorContext{
+			ObjectMeta: v1.ObjectMeta{Name: "tyk", Namespace: "default"},
+			Spec: tykv1alpha1.OperatorContextSpec{
+				Env:               &env.Environment,
+				GarbageCollection: &tykv1alpha1.GarbageCollection{},
+			},
+		}
+		api := &tykv1alpha1.ApiDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: operatorID, Namespace: "default"},
+			Spec: tykv1alpha1.APIDefinitionSpec{
+				APIDefinitionSpec: model.APIDefinitionSpec{Name: operatorID},
+			},
+		}
+
+		cl, err := NewFakeClient([]runtime.Object{opCtx, api})
+		is.NoErr(err)
+
+		apis := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+		_, err = apis.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)})
+		is.NoErr(err)
+
+		r := &OperatorContextReconciler{Client: cl, Log: log.NullLogger{}, Recorder: record.NewFakeRecorder(10), Env: env}
+
+		return cl, r, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(opCtx)}
+	}
+
+	clA, a, reqA := cluster("cluster-a")
+	_, b, reqB := cluster("cluster-b")
+	is.Equal(len(s.APIs()), 2)
+
+	// Each cluster sees the API of the other without a resource, only its own
+	// objects are collected.
+	_, err := b.Reconcile(ctx, reqB)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 2)
+
+	_, err = a.Reconcile(ctx, reqA)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 2)
+
+	// cluster-a loses its ApiDefinition without its finalizer.
+	api := &tykv1alpha1.ApiDefinition{}
+	is.NoErr(clA.Get(ctx, types.NamespacedName{Name: "cluster-a", Namespace: "default"}, api))
+	api.Finalizers = nil
+	is.NoErr(clA.Update(ctx, api))
+	is.NoErr(clA.Delete(ctx, api))
+
+	opCtx := &tykv1alpha1.OperatorContext{}
+	is.NoErr(clA.Get(ctx, reqA.NamespacedName, opCtx))
+	opCtx.Status.GarbageCollection = nil
+	is.NoErr(clA.Status().Update(ctx, opCtx))
+
+	_, err = a.Reconcile(ctx, reqA)
+	is.NoErr(err)
+
+	apis := s.APIs()
+	is.Equal(len(apis), 1)
+	is.Equal(apis[0].Name, "cluster-b")
+}
+
+func TestGarbageCollectionWatchedNamespaces(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := context.Background()
+	tyk := s.Context(ctx)
+
+	// None of the ApiDefinitions exist, only those of the watched namespaces
+	// are known to be gone.
+	for _, ns := range []string{"team-a", "team-b", "other"} {
+		id := EncodeNS(ns + "/httpbin")
+		def := &model.APIDefinitionSpec{Name: "httpbin", APIID: &id}
+		stampAPI(def, "cluster-a")
+
+		_, err := klient.Universal.Api().Create(tyk, def)
+		is.NoErr(err)
+	}
+
+	cl, err := NewFakeClient(nil)
+	is.NoErr(err)
+
+	env := environment.Env{OperatorID: "cluster-a", Namespace: "team-a,team-b"}
+	r := OperatorContextReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+
+	orphans, err := r.orphans(tyk, env)
+	is.NoErr(err)
+
+	var resources []string
+	for _, o := range orphans {
+		resources = append(resources, o.Resource)
+	}
+
+	is.Equal(resources, []string{"team-a/httpbin", "team-b/httpbin"})
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gatewayURLs returns the URLs of the gateways of env, its urls followed by
+// the ready addresses of the Endpoints selected by its selector.
+func gatewayURLs(ctx context.Context, c client.Client, env environment.Env) ([]string, error) {
+	g := env.Gateways
+	nodes := append([]string(nil), g.URLs...)
+
+	if g.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(g.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway selector: %w", err)
+		}
+
+		var list v1.EndpointsList
+
+		err = c.List(ctx, &list, client.InNamespace(g.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, err
+		}
+
+		scheme := urlScheme(env.URL)
+
+		for i := range list.Items {
+			nodes = append(nodes, endpointsURLs(&list.Items[i], scheme, g.Port)...)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("no gateway is selected by the environment")
+	}
+
+	return nodes, nil
+}
+
+// urlScheme returns the scheme of rawURL, http if it has none.
+func urlScheme(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme != "" {
+		return u.Scheme
+	}
+
+	return "http"
+}
+
+// endpointsURLs returns the URLs of the ready addresses of ep exposing port,
+// or their first port when port is zero.
+func endpointsURLs(ep *v1.Endpoints, scheme string, port int32) []string {
+	var o []string
+
+	for _, subset := range ep.Subsets {
+		p, ok := subsetPort(subset, port)
+		if !ok {
+			continue
+		}
+
+		for _, a := range subset.Addresses {
+			o = append(o, scheme+"://"+net.JoinHostPort(a.IP, strconv.Itoa(int(p))))
+		}
+	}
+
+	return o
+}
+
+// subsetPort returns port if subset exposes it, or the first port of subset
+// when port is zero.
+func subsetPort(subset v1.EndpointSubset, port int32) (int32, bool) {
+	for _, p := range subset.Ports {
+		if port == 0 || p.Port == port {
+			return p.Port, true
+		}
+	}
+
+	return 0, false
+}
+
+// nodesStatus returns the status of the gateway nodes of ctx after a
+// reconciliation that ended with err, nil if ctx has no nodes.
+func nodesStatus(ctx context.Context, err error) *v1alpha1.NodesStatus {
+	nodes := tykClient.GetContext(ctx).Nodes
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	status := &v1alpha1.NodesStatus{Synced: len(nodes), Total: len(nodes)}
+
+	var e *tykClient.NodesError
+
+	switch {
+	case err == nil:
+	case errors.As(err, &e):
+		for _, node := range e.Nodes() {
+			status.Failed = append(status.Failed, v1alpha1.NodeFailure{URL: node, Error: e.Errors[node].Error()})
+		}
+
+		status.Synced -= len(status.Failed)
+	default:
+		status.Synced = 0
+	}
+
+	return status
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionGateways(t *testing.T) {
+	is := is.New(t)
+
+	nodes := []*fake.Server{fake.NewGateway(), fake.NewGateway()}
+	for _, s := range nodes {
+		defer s.Close()
+	}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{api})
+	is.NoErr(err)
+
+	env := nodes[0].Env()
+	env.Gateways = &tykv1alpha1.Gateways{URLs: []string{nodes[0].URL, nodes[1].URL}}
+
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	for _, s := range nodes {
+		is.Equal(len(s.APIs()), 1)
+		is.Equal(s.Reloads(), 1)
+	}
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Nodes, &tykv1alpha1.NodesStatus{Synced: 2, Total: 2})
+
+	// A gateway losing the API gets it back.
+	_, err = klient.Universal.Api().Delete(nodes[1].Context(ctx), api.Status.ApiID)
+	is.NoErr(err)
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(nodes[1].APIs()), 1)
+
+	// A gateway rejecting the calls is reported, the others keep the API.
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer rejecting.Close()
+
+	r.Env.Gateways.URLs = append(r.Env.Gateways.URLs, rejecting.URL)
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(err != nil)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+	is.Equal(api.Status.Nodes.Synced, 2)
+	is.Equal(api.Status.Nodes.Total, 3)
+	is.Equal(len(api.Status.Nodes.Failed), 1)
+	is.Equal(api.Status.Nodes.Failed[0].URL, rejecting.URL)
+}
+
+func TestGatewayURLs(t *testing.T) {
+	is := is.New(t)
+
+	ep := &corev1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Name: "gateways", Namespace: "tyk", Labels: map[string]string{"app": "tyk-gateway"}},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+			Ports:     []corev1.EndpointPort{{Name: "metrics", Port: 9090}, {Name: "http", Port: 8080}},
+		}},
+	}
+	other := &corev1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Name: "dashboard", Namespace: "tyk", Labels: map[string]string{"app": "tyk-dashboard"}},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.3"}},
+			Ports:     []corev1.EndpointPort{{Port: 3000}},
+		}},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{ep, other})
+	is.NoErr(err)
+
+	var env environment.Env
+
+	env.URL = "https://tyk.tyk.svc:8080"
+	env.Gateways = &tykv1alpha1.Gateways{
+		URLs:      []string{"https://10.0.1.1:8080"},
+		Selector:  &v1.LabelSelector{MatchLabels: map[string]string{"app": "tyk-gateway"}},
+		Namespace: "tyk",
+		Port:      8080,
+	}
+
+	urls, err := gatewayURLs(context.Background(), cl, env)
+	is.NoErr(err)
+	is.Equal(urls, []string{"https://10.0.1.1:8080", "https://10.0.0.1:8080", "https://10.0.0.2:8080"})
+
+	env.Gateways = &tykv1alpha1.Gateways{
+		Selector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "missing"}},
+	}
+
+	_, err = gatewayURLs(context.Background(), cl, env)
+	is.True(err != nil)
+}
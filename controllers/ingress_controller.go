@@ -26,6 +26,7 @@ import (
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
 	netV1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -70,6 +71,13 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	// Ingresses have no conditions, the pause is only logged. Their deletion
+	// does not call Tyk, the pause does not hold it.
+	if reason, ok := pauseReason(desired, env); ok && desired.DeletionTimestamp.IsZero() {
+		nsl.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, nil
+	}
+
 	key, ok := desired.Annotations[keys.IngressTemplateAnnotation]
 	template := r.keyless()
 
@@ -167,6 +175,7 @@ func (r *IngressReconciler) createAPI(
 					keys.IngressLabel: desired.Name,
 					keys.APIDefLabel:  hash,
 				})
+				inheritShard(api, desired)
 				api.Spec = *template.Spec.DeepCopy()
 				api.Spec.Name = name
 
@@ -329,5 +338,5 @@ func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&netV1.Ingress{}).
 		Owns(&v1alpha1.ApiDefinition{}).
 		WithEventFilter(r.ingressClassEventFilter()).
-		Complete(r)
+		Complete(tracing.Reconciler("Ingress", r))
 }
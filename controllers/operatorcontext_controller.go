@@ -19,16 +19,35 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// OperatorContextSecretKey indexes OperatorContext resources by the
+// namespace/name of the secrets they reference.
+const OperatorContextSecretKey = "operatorcontext_secret_refs"
+
 var ErrOperatorContextIsStillInUse = errors.
 	New("Operator context is used by other resources." +
 		"Please check operator context status to find exact list of resources")
@@ -36,13 +55,28 @@ var ErrOperatorContextIsStillInUse = errors.
 // OperatorContextReconciler reconciles a OperatorContext object
 type OperatorContextReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Env      environment.Env
+	Recorder record.EventRecorder
+
+	// Shard selects the OperatorContexts reconciled by this shard of the
+	// operator, nil for all of them.
+	Shard labels.Selector
+
+	// Reader lists the resources holding objects on Tyk when collecting
+	// orphaned objects. It must read the resources of every shard, it defaults
+	// to the client.
+	Reader client.Reader
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=operatorcontexts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=operatorcontexts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=operatorcontexts/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tyk.tyk.io,resources=apidefinitions;securitypolicies;tykoasapidefinitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -64,8 +98,13 @@ func (r *OperatorContextReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// OperatorContexts are read by every shard, but reconciled by their own.
+	if !inShard(r.Shard, &desired) {
+		return ctrl.Result{}, nil
+	}
+
 	if !desired.DeletionTimestamp.IsZero() {
-		if len(desired.Status.LinkedApiDefinitions) != 0 || len(desired.Status.LinkedApiDescriptions) != 0 || len(desired.Status.LinkedPortalAPICatalogues) != 0 || len(desired.Status.LinkedSecurityPolicies) != 0 || len(desired.Status.LinkedPortalConfigs) != 0 {
+		if isInUse(&desired.Status) {
 			logger.Error(ErrOperatorContextIsStillInUse, "Cannot delete operator context")
 
 			return ctrl.Result{RequeueAfter: queueAfter}, ErrOperatorContextIsStillInUse
@@ -92,12 +131,198 @@ func (r *OperatorContextReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, r.Update(ctx, &desired)
 	}
 
-	return ctrl.Result{}, nil
+	reason, paused := pauseReason(&desired, environment.Env{Paused: desired.Spec.Paused})
+
+	err := r.observeSecrets(ctx, &desired, reason)
+	if err != nil || paused || desired.Spec.GarbageCollection == nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.collectGarbage(ctx, &desired)
+}
+
+// secretRefs returns the secrets referenced by o, holding its credentials,
+// certificates or the admin secret of the dashboard.
+func secretRefs(o *v1alpha1.OperatorContext) []types.NamespacedName {
+	targets := []*model.Target{o.Spec.FromSecret}
+
+	if e := o.Spec.Env; e != nil {
+		targets = append(targets, e.AdminSecretRef)
+
+		if e.TLS != nil {
+			targets = append(targets, e.TLS.CASecretRef, e.TLS.ClientCertSecretRef)
+		}
+	}
+
+	var refs []types.NamespacedName
+
+	for _, t := range targets {
+		if t != nil && t.Name != "" {
+			refs = append(refs, t.NS(o.Namespace))
+		}
+	}
+
+	return refs
+}
+
+// observeSecrets increments the credentials generation of desired when one of
+// the secrets it references changed, so that the resources linked to it are
+// reconciled with the new credentials. desired is not Ready while one of them
+// is missing, and Paused while its reconciliation is paused for pausedFor.
+func (r *OperatorContextReconciler) observeSecrets(
+	ctx context.Context,
+	desired *v1alpha1.OperatorContext,
+	pausedFor string,
+) error {
+	var versions []string
+
+	var missing error
+
+	for _, key := range secretRefs(desired) {
+		var secret v1.Secret
+
+		err := r.Get(ctx, key, &secret)
+		if err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				return err
+			}
+
+			missing = fmt.Errorf("failed to get Secret %s: %w", key, err)
+		}
+
+		versions = append(versions, key.String()+"@"+secret.ResourceVersion)
+	}
+
+	hash := calculateHash(versions)
+
+	status := &desired.Status
+	observed := status.DeepCopy()
+
+	// The secrets are seen for the first time, the linked resources already
+	// use them.
+	if status.LatestSecretsHash != hash && status.LatestSecretsHash != "" {
+		status.CredentialsGeneration++
+
+		r.Log.Info("Secrets referenced by OperatorContext changed",
+			"OperatorContext", client.ObjectKeyFromObject(desired).String(),
+			"credentialsGeneration", status.CredentialsGeneration,
+		)
+	}
+
+	status.LatestSecretsHash = hash
+
+	recordSync(r.Recorder, desired, status.Conditions, missing)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, missing)
+
+	if pausedFor != "" {
+		setPaused(&status.Conditions, desired, pausedFor)
+	}
+
+	if equality.Semantic.DeepEqual(observed, status) {
+		return nil
+	}
+
+	return r.Status().Update(ctx, desired)
+}
+
+func (r *OperatorContextReconciler) findOperatorContextsForSecret(secret client.Object) []reconcile.Request {
+	key := client.ObjectKeyFromObject(secret)
+
+	var list v1alpha1.OperatorContextList
+	if err := r.List(context.TODO(), &list, client.MatchingFields{OperatorContextSecretKey: key.String()}); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+
+	for i := range list.Items {
+		for _, ref := range secretRefs(&list.Items[i]) {
+			if ref == key {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// contextUpdated lets through the updates of OperatorContext resources
+// whose credentials generation changed, or which were paused or resumed.
+var contextUpdated = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		o, okOld := e.ObjectOld.(*v1alpha1.OperatorContext)
+		n, okNew := e.ObjectNew.(*v1alpha1.OperatorContext)
+
+		return okOld && okNew && (o.Status.CredentialsGeneration != n.Status.CredentialsGeneration ||
+			o.Spec.Paused != n.Spec.Paused)
+	},
+}
+
+// linkedResources enqueues the resources returned by linked from the status of
+// an OperatorContext. Used along with contextUpdated, it reconciles the
+// resources of a kind linked to an OperatorContext once its credentials are
+// rotated, or once it is paused or resumed.
+func linkedResources(linked func(*v1alpha1.OperatorContextStatus) []model.Target) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+		opCtx, ok := o.(*v1alpha1.OperatorContext)
+		if !ok {
+			return nil
+		}
+
+		targets := linked(&opCtx.Status)
+
+		requests := make([]reconcile.Request, 0, len(targets))
+		for _, t := range targets {
+			requests = append(requests, reconcile.Request{NamespacedName: t.NS(opCtx.Namespace)})
+		}
+
+		return requests
+	})
+}
+
+// isInUse returns true if resources are still reconciled with the
+// OperatorContext whose status is s.
+func isInUse(s *v1alpha1.OperatorContextStatus) bool {
+	return len(s.LinkedApiDefinitions) != 0 || len(s.LinkedApiDescriptions) != 0 ||
+		len(s.LinkedPortalAPICatalogues) != 0 || len(s.LinkedSecurityPolicies) != 0 ||
+		len(s.LinkedPortalConfigs) != 0 || len(s.LinkedTykOasApiDefinitions) != 0 ||
+		len(s.LinkedApiKeys) != 0 || len(s.LinkedDashboardUsers) != 0 ||
+		len(s.LinkedDashboardUserGroups) != 0 || len(s.LinkedTykOrganisations) != 0 ||
+		len(s.LinkedApiEventWebhooks) != 0
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OperatorContextReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.OperatorContext{},
+		OperatorContextSecretKey,
+		func(rawObj client.Object) []string {
+			o, ok := rawObj.(*v1alpha1.OperatorContext)
+			if !ok {
+				return nil
+			}
+
+			var values []string
+			for _, ref := range secretRefs(o) {
+				values = append(values, ref.String())
+			}
+
+			return values
+		})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.OperatorContext{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.findOperatorContextsForSecret),
+		).
+		Complete(tracing.Reconciler("OperatorContext", r))
 }
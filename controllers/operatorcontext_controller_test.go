@@ -12,9 +12,11 @@ import (
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
 	"github.com/google/uuid"
 	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -153,3 +155,60 @@ func TestOperatorContextDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestOperatorContextCredentialsRotation(t *testing.T) {
+	eval := is.New(t)
+	ctx := context.TODO()
+
+	key := types.NamespacedName{Name: "test", Namespace: "test-ns"}
+
+	secret := corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "tyk-credentials", Namespace: key.Namespace},
+		Data:       map[string][]byte{v1alpha1.TykAuth: []byte("old")},
+	}
+
+	opCtx := v1alpha1.OperatorContext{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       key.Name,
+			Namespace:  key.Namespace,
+			Finalizers: []string{keys.OperatorContextFinalizerName},
+		},
+		Spec: v1alpha1.OperatorContextSpec{
+			FromSecret: &model.Target{Name: secret.Name},
+		},
+	}
+
+	cl, err := controllers.NewFakeClient([]runtime.Object{&opCtx, &secret})
+	eval.NoErr(err)
+
+	r := controllers.OperatorContextReconciler{
+		Client: cl,
+		Scheme: scheme.Scheme,
+		Log:    log.NullLogger{},
+	}
+
+	reconcileAndGet := func() v1alpha1.OperatorContextStatus {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		eval.NoErr(err)
+
+		var result v1alpha1.OperatorContext
+		eval.NoErr(cl.Get(ctx, key, &result))
+
+		return result.Status
+	}
+
+	// The secret is seen for the first time.
+	status := reconcileAndGet()
+	eval.True(status.LatestSecretsHash != "")
+	eval.Equal(status.CredentialsGeneration, int64(0))
+
+	status = reconcileAndGet()
+	eval.Equal(status.CredentialsGeneration, int64(0))
+
+	eval.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(&secret), &secret))
+	secret.Data[v1alpha1.TykAuth] = []byte("new")
+	eval.NoErr(cl.Update(ctx, &secret))
+
+	status = reconcileAndGet()
+	eval.Equal(status.CredentialsGeneration, int64(1))
+}
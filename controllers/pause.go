@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// pauseReason returns the reason of the Paused condition of the resource o,
+// reconciled with env, when its reconciliation is paused by its
+// tyk.io/reconcile annotation or by its OperatorContext.
+func pauseReason(o metav1.Object, env environment.Env) (string, bool) {
+	switch {
+	case pausedByAnnotation(o):
+		return "PausedByAnnotation", true
+	case env.Paused:
+		return "PausedByOperatorContext", true
+	}
+
+	return "", false
+}
+
+func pausedByAnnotation(o metav1.Object) bool {
+	return strings.EqualFold(o.GetAnnotations()[keys.ReconcileAnnotation], keys.ReconcilePaused)
+}
+
+// setPaused sets the Paused condition in conditions of a resource whose
+// reconciliation is paused for reason. The deletion of a paused resource
+// retained on Tyk waits until it is resumed, its object on Tyk can't be
+// unstamped meanwhile.
+func setPaused(conditions *[]metav1.Condition, o metav1.Object, reason string) {
+	message := "Changes of the resource are not applied to Tyk until it is resumed"
+	if !o.GetDeletionTimestamp().IsZero() {
+		message = "The resource is retained on Tyk once it is resumed"
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: o.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// pause records in conditions, the conditions of the status of o, that the
+// reconciliation of o is paused for reason. The status is only updated when
+// the condition changes.
+func pause(ctx context.Context, c client.Client, o client.Object, conditions *[]metav1.Condition, reason string) error {
+	observed := append([]metav1.Condition(nil), *conditions...)
+
+	setPaused(conditions, o, reason)
+
+	if equality.Semantic.DeepEqual(observed, *conditions) {
+		return nil
+	}
+
+	return c.Status().Update(ctx, o)
+}
+
+// leaveOnTyk records that the object on Tyk of the resource o, deleted while
+// its reconciliation is paused for reason, is left there. Only its finalizer
+// and its links with other resources are removed.
+func leaveOnTyk(recorder record.EventRecorder, log logr.Logger, o client.Object, reason string) {
+	log.Info("Reconciliation is paused, leaving the object on Tyk", "reason", reason)
+	recordEvent(recorder, o, v1.EventTypeWarning, ReasonLeftOnTyk,
+		"The resource is deleted while paused (%s), its object is left on Tyk", reason)
+}
+
+// pauseToggled lets through the updates of resources whose tyk.io/reconcile
+// annotation changed, which do not change their generation.
+var pauseToggled = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return pausedByAnnotation(e.ObjectOld) != pausedByAnnotation(e.ObjectNew)
+	},
+}
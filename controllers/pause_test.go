@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionPaused(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "httpbin",
+			Namespace:   "default",
+			Annotations: map[string]string{keys.ReconcileAnnotation: keys.ReconcilePaused},
+		},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{api})
+	is.NoErr(err)
+
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Recorder: record.NewFakeRecorder(10)}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 0)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+
+	c := meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionPaused)
+	is.True(c != nil)
+	is.Equal(c.Status, v1.ConditionTrue)
+	is.Equal(c.Reason, "PausedByAnnotation")
+
+	// Resumed.
+	delete(api.Annotations, keys.ReconcileAnnotation)
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 1)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionPaused) == nil)
+	is.True(meta.IsStatusConditionTrue(api.Status.Conditions, tykv1alpha1.ConditionReady))
+
+	// The deletion of a paused ApiDefinition retained on Tyk waits until it is
+	// resumed, its API must be unstamped.
+	api.Annotations = map[string]string{
+		keys.ReconcileAnnotation:      keys.ReconcilePaused,
+		keys.DeletionPolicyAnnotation: keys.DeletionPolicyRetain,
+	}
+	is.NoErr(cl.Update(ctx, api))
+	is.NoErr(cl.Delete(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 1)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.True(meta.IsStatusConditionTrue(api.Status.Conditions, tykv1alpha1.ConditionPaused))
+
+	// Otherwise it is deleted, and its API is left on Tyk.
+	delete(api.Annotations, keys.DeletionPolicyAnnotation)
+	is.NoErr(cl.Update(ctx, api))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.APIs()), 1)
+
+	err = cl.Get(ctx, req.NamespacedName, api)
+	is.True(k8sErrors.IsNotFound(err))
+}
+
+func TestOperatorContextPaused(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	env := s.Env()
+	opCtx := &tykv1alpha1.OperatorContext{
+		ObjectMeta: v1.ObjectMeta{Name: "tyk", Namespace: "default"},
+		Spec:       tykv1alpha1.OperatorContextSpec{Env: &env.Environment, Paused: true},
+	}
+
+	namespace := "default"
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Name: "gold", Namespace: "default"},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{Name: "gold"},
+			Context:            &model.Target{Name: "tyk", Namespace: &namespace},
+		},
+	}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Status:     tykv1alpha1.ApiDefinitionStatus{ApiID: "httpbin"},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{opCtx, policy, api})
+	is.NoErr(err)
+
+	recorder := record.NewFakeRecorder(10)
+	r := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.Policies()), 0)
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+
+	c := meta.FindStatusCondition(policy.Status.Conditions, tykv1alpha1.ConditionPaused)
+	is.True(c != nil)
+	is.Equal(c.Reason, "PausedByOperatorContext")
+
+	// The policy is applied once the OperatorContext is resumed.
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(opCtx), opCtx))
+	opCtx.Spec.Paused = false
+	is.NoErr(cl.Update(ctx, opCtx))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.Policies()), 1)
+
+	// A policy deleted while paused leaves its policy on Tyk, its links are
+	// removed.
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	policy.Spec.AccessRightsArray = []*model.AccessDefinition{{Name: "httpbin", Namespace: "default"}}
+	is.NoErr(cl.Update(ctx, policy))
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(api), api))
+	is.Equal(len(api.Status.LinkedByPolicies), 1)
+
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(opCtx), opCtx))
+	opCtx.Spec.Paused = true
+	is.NoErr(cl.Update(ctx, opCtx))
+
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+	is.NoErr(cl.Delete(ctx, policy))
+
+	for len(recorder.Events) != 0 {
+		<-recorder.Events
+	}
+
+	_, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(s.Policies()), 1)
+
+	select {
+	case e := <-recorder.Events:
+		is.True(strings.HasPrefix(e, "Warning LeftOnTyk "))
+	default:
+		t.Fatal("expected a LeftOnTyk event")
+	}
+
+	is.True(k8sErrors.IsNotFound(cl.Get(ctx, req.NamespacedName, policy)))
+
+	linked := &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(api), linked))
+	is.Equal(len(linked.Status.LinkedByPolicies), 0)
+
+	linking := &tykv1alpha1.OperatorContext{}
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(opCtx), linking))
+	is.Equal(len(linking.Status.LinkedSecurityPolicies), 0)
+}
+
+func TestPauseToggled(t *testing.T) {
+	is := is.New(t)
+
+	old := &tykv1alpha1.ApiDefinition{}
+	paused := old.DeepCopy()
+	paused.Annotations = map[string]string{keys.ReconcileAnnotation: keys.ReconcilePaused}
+
+	is.True(pauseToggled.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: paused}))
+	is.True(pauseToggled.Update(event.UpdateEvent{ObjectOld: paused, ObjectNew: old}))
+	is.True(!pauseToggled.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: old.DeepCopy()}))
+}
@@ -23,9 +23,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
@@ -34,14 +37,16 @@ import (
 	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 )
 
 // PortalAPICatalogueReconciler reconciles a PortalAPICatalogue object
 type PortalAPICatalogueReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
-	Env    environment.Env
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=portalapicatalogues,verbs=get;list;watch;create;update;patch;delete
@@ -66,7 +71,7 @@ func (r *PortalAPICatalogueReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if err == nil {
 			log.Info("Successfully reconciled PortalAPICatalogue")
 		} else {
-			result = ctrl.Result{RequeueAfter: queueAfter}
+			result, err = requeue(result.RequeueAfter, err)
 		}
 	}()
 
@@ -82,9 +87,20 @@ func (r *PortalAPICatalogueReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	// A paused PortalAPICatalogue being deleted leaves its catalogue on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
 	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if err := checkDeletionPolicy(desired); err != nil {
+			return err
+		}
+
 		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.delete(ctx, desired, &env, log)
+			return r.delete(ctx, desired, &env, log, reason)
 		}
 		if desired.Spec.OrgID == "" {
 			desired.Spec.OrgID = env.Org
@@ -96,6 +112,16 @@ func (r *PortalAPICatalogueReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.create(ctx, desired, &env, log)
 	})
 
+	// A catalogue failing to be deleted still exists, its failure is recorded.
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() || err != nil {
+		recordSync(r.Recorder, desired, desired.Status.Conditions, err)
+		setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, err)
+
+		if errK8s := r.Status().Update(ctx, desired); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
 	return
 }
 
@@ -135,7 +161,7 @@ func (r *PortalAPICatalogueReconciler) model(
 			}
 
 			if sec.Status.PolID == "" {
-				return nil, fmt.Errorf("%q missing policy_id", desc.Name)
+				return nil, fmt.Errorf("%q missing policy_id: %w", desc.Name, ErrDependencyNotReady)
 			}
 
 			desc.PolicyID = *sec.Spec.ID
@@ -283,14 +309,32 @@ func (r *PortalAPICatalogueReconciler) consolidate(ctx context.Context, desired
 	return nil
 }
 
+// delete empties the catalogue of desired on Tyk and deletes its
+// documentation. paused is the reason its reconciliation is paused, empty if it
+// is not, the catalogue is then left on Tyk.
 func (r *PortalAPICatalogueReconciler) delete(
 	ctx context.Context,
 	desired *tykv1alpha1.PortalAPICatalogue,
 	env *environment.Env,
 	log logr.Logger,
+	paused string,
 ) error {
 	log.Info("Deleting PortalAPICatalogue")
 
+	if paused != "" {
+		leaveOnTyk(r.Recorder, log, desired, paused)
+		util.RemoveFinalizer(desired, keys.PortalAPICatalogueFinalizerName)
+
+		return nil
+	}
+
+	if retained(desired) {
+		log.Info("Retaining catalogue and its documentation on Tyk", "ID", desired.Status.ID)
+		util.RemoveFinalizer(desired, keys.PortalAPICatalogueFinalizerName)
+
+		return nil
+	}
+
 	all, err := klient.Universal.Portal().Catalogue().Get(ctx)
 	if err != nil {
 		return err
@@ -320,6 +364,8 @@ func (r *PortalAPICatalogueReconciler) delete(
 		return err
 	}
 
+	recordDeleted(r.Recorder, desired, desired.Status.ID)
+
 	util.RemoveFinalizer(desired, keys.PortalAPICatalogueFinalizerName)
 
 	return nil
@@ -329,5 +375,12 @@ func (r *PortalAPICatalogueReconciler) delete(
 func (r *PortalAPICatalogueReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tykv1alpha1.PortalAPICatalogue{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedPortalAPICatalogues
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("PortalAPICatalogue", r))
 }
@@ -21,23 +21,29 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 )
 
 // PortalConfigReconciler reconciles a PortalConfig object
 type PortalConfigReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
-	Env    environment.Env
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=portalconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -78,6 +84,13 @@ func (r *PortalConfigReconciler) Reconcile(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	// A PortalConfig is deleted without calling Tyk, the pause does not hold
+	// its deletion.
+	if reason, ok := pauseReason(desired, env); ok && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
 	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
 		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
 			return r.delete(desired, log)
@@ -92,6 +105,15 @@ func (r *PortalConfigReconciler) Reconcile(ctx context.Context,
 		return r.update(ctx, desired, &env, log)
 	})
 
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		recordSync(r.Recorder, desired, desired.Status.Conditions, err)
+		setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, err)
+
+		if errK8s := r.Status().Update(ctx, desired); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
 	return
 }
 
@@ -161,5 +183,12 @@ func (r *PortalConfigReconciler) delete(desired *v1alpha1.PortalConfig, log logr
 func (r *PortalConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tykv1alpha1.PortalConfig{}).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedPortalConfigs
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("PortalConfig", r))
 }
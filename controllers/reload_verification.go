@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/jsondiff"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultReloadVerificationTimeout = 30 * time.Second
+	reloadVerificationInterval       = time.Second
+)
+
+// ErrReloadNotVerified is returned when some gateways of the group did not
+// load the latest definition of an API before the verification timed out.
+var ErrReloadNotVerified = errors.New("not all gateways loaded the latest definition of the API")
+
+// ErrReloadPending is returned while the batched hot reload requested for a
+// resource is not done, or while some gateways of the group do not serve the
+// latest definition of an API yet, before the verification times out. The
+// resource is reconciled again after reloadVerificationInterval.
+var ErrReloadPending = errors.New("waiting for the gateways to reload")
+
+// gatewayNodes returns the URLs of the gateways selected by the reload
+// verification of env, built from the ready addresses of the Endpoints of its
+// Service and the scheme of env.URL.
+func gatewayNodes(ctx context.Context, c client.Client, env environment.Env) ([]string, error) {
+	v := env.ReloadVerification
+	key := v.Service.NS("")
+
+	var ep v1.Endpoints
+	if err := c.Get(ctx, key, &ep); err != nil {
+		return nil, fmt.Errorf("failed to get Endpoints %s: %w", key, err)
+	}
+
+	return endpointsURLs(&ep, urlScheme(env.URL), v.Port), nil
+}
+
+// verifyReload polls once the gateways selected by the reload verification of
+// env, and returns how many serve applied, the definition of the API whose id
+// is apiID. If some don't, it returns ErrReloadPending until the verification,
+// started at the time recorded in previous, times out, and then
+// ErrReloadNotVerified.
+func verifyReload(
+	ctx context.Context,
+	c client.Client,
+	env environment.Env,
+	apiID string,
+	applied interface{},
+	previous *v1alpha1.NodesStatus,
+	now time.Time,
+) (*v1alpha1.NodesStatus, error) {
+	nodes, err := gatewayNodes(ctx, c, env)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &v1alpha1.NodesStatus{Total: len(nodes)}
+
+	for _, node := range nodes {
+		if nodeServes(ctx, node, apiID, applied) {
+			status.Synced++
+		}
+	}
+
+	if status.Synced == status.Total {
+		return status, nil
+	}
+
+	timeout := defaultReloadVerificationTimeout
+	if t := env.ReloadVerification.Timeout; t != nil && t.Duration > 0 {
+		timeout = t.Duration
+	}
+
+	since := metav1.NewTime(now)
+	if previous != nil && previous.VerifyingSince != nil {
+		since = *previous.VerifyingSince
+	}
+
+	// The next verification starts over once this one timed out.
+	if now.Sub(since.Time) >= timeout {
+		return status, fmt.Errorf("%w: %d of %d gateways synced after %s",
+			ErrReloadNotVerified, status.Synced, status.Total, timeout)
+	}
+
+	status.VerifyingSince = &since
+
+	return status, fmt.Errorf("%w: %d of %d gateways synced", ErrReloadPending, status.Synced, status.Total)
+}
+
+// nodeServes returns true if the gateway at node serves applied, the
+// definition of the API whose id is apiID. The fields only set by the gateway
+// are ignored.
+func nodeServes(ctx context.Context, node, apiID string, applied interface{}) bool {
+	api, err := klient.Universal.Api().Get(tykClient.OnNode(ctx, node), apiID)
+	if err != nil {
+		tykClient.GetContext(ctx).Log.Info("Gateway did not load the API yet", "node", node, "error", err.Error())
+		return false
+	}
+
+	changes, err := jsondiff.Diff(applied, api)
+
+	return err == nil && len(changes) == 0
+}
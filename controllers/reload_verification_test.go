@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApiDefinitionReloadVerification(t *testing.T) {
+	is := is.New(t)
+
+	nodes := []*fake.Server{fake.NewGateway(), fake.NewGateway()}
+	for _, s := range nodes {
+		defer s.Close()
+	}
+
+	ep := &corev1.Endpoints{ObjectMeta: v1.ObjectMeta{Name: "gateways", Namespace: "tyk"}}
+
+	for _, s := range nodes {
+		u, err := url.Parse(s.URL)
+		is.NoErr(err)
+
+		port, err := strconv.Atoi(u.Port())
+		is.NoErr(err)
+
+		ep.Subsets = append(ep.Subsets, corev1.EndpointSubset{
+			Addresses: []corev1.EndpointAddress{{IP: u.Hostname()}},
+			Ports:     []corev1.EndpointPort{{Port: int32(port)}},
+		})
+	}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{ep, api})
+	is.NoErr(err)
+
+	namespace := "tyk"
+	env := nodes[0].Env()
+	env.ReloadVerification = &tykv1alpha1.ReloadVerification{
+		Service: model.Target{Name: "gateways", Namespace: &namespace},
+		Timeout: &v1.Duration{Duration: 100 * time.Millisecond},
+	}
+
+	r := ApiDefinitionReconciler{Client: cl, Log: log.NullLogger{}, Env: env}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+	ctx := context.Background()
+
+	// Only the gateway behind the url of the environment has the API, it is
+	// polled again until the verification times out.
+	res, err := r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(res.RequeueAfter, reloadVerificationInterval)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Nodes.Synced, 1)
+	is.Equal(api.Status.Nodes.Total, 2)
+	is.True(api.Status.Nodes.VerifyingSince != nil)
+	is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+
+	c := meta.FindStatusCondition(api.Status.Conditions, tykv1alpha1.ConditionReady)
+	is.Equal(c.Reason, ReasonReloadPending)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrReloadNotVerified))
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Nodes, &tykv1alpha1.NodesStatus{Synced: 1, Total: 2})
+	is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+
+	def := nodes[0].APIs()[0]
+	_, err = klient.Universal.Api().Create(nodes[1].Context(ctx), &def)
+	is.NoErr(err)
+
+	res, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.True(res.RequeueAfter != reloadVerificationInterval)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Nodes, &tykv1alpha1.NodesStatus{Synced: 2, Total: 2})
+	is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+	is.True(meta.IsStatusConditionTrue(api.Status.Conditions, tykv1alpha1.ConditionReady))
+
+	// The gateways are compared with the definition just applied, a gateway
+	// still serving the previous one is not synced.
+	api.Spec.Name = "httpbin-v2"
+	is.NoErr(cl.Update(ctx, api))
+
+	res, err = r.Reconcile(ctx, req)
+	is.NoErr(err)
+	is.Equal(res.RequeueAfter, reloadVerificationInterval)
+
+	api = &tykv1alpha1.ApiDefinition{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, api))
+	is.Equal(api.Status.Nodes.Synced, 1)
+}
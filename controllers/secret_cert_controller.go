@@ -23,9 +23,12 @@ import (
 	"github.com/TykTechnologies/tyk-operator/pkg/cert"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -41,9 +44,14 @@ const (
 // SecretCertReconciler reconciles a Cert object
 type SecretCertReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
-	Env    environment.Env
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+
+	// Shard selects the Secrets reconciled by this shard of the operator, nil
+	// for all of them.
+	Shard labels.Selector
 }
 
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
@@ -57,6 +65,11 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
 	}
+
+	// Secrets are read by every shard, but reconciled by their own.
+	if !inShard(r.Shard, desired) {
+		return ctrl.Result{}, nil
+	}
 	// set context for all api calls inside this reconciliation loop
 	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
 	if err != nil {
@@ -70,9 +83,17 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// Secrets have no conditions, the pause is only logged. A paused Secret
+	// being deleted leaves its certificate on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, nil
+	}
+
 	// If object is being deleted
 	if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
-		err = r.delete(ctx, desired, log, env.Org)
+		err = r.delete(ctx, desired, log, env.Org, reason)
 		if err != nil {
 			return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 5}, err
 		}
@@ -126,10 +147,15 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				if !isCertificateAlreadyUploaded(ctx, isCertPreviouslyProcessed, tlsCrt, env.Org) {
 					certID, err = klient.Universal.Certificate().Upload(ctx, tlsKey, tlsCrt)
 					if err != nil {
+						recordEvent(r.Recorder, desired, v1.EventTypeWarning, ReasonSyncFailed, "%v", err)
 						return ctrl.Result{Requeue: true}, err
 					}
 
 					log.Info("uploaded certificate to Tyk", "certID", certID)
+					recordEvent(
+						r.Recorder, desired, v1.EventTypeNormal, ReasonCertificateUploaded,
+						"Uploaded certificate %s to Tyk", certID,
+					)
 
 					isCertPreviouslyProcessed = true
 				}
@@ -163,10 +189,15 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				if !isCertificateAlreadyUploaded(ctx, isCertPreviouslyProcessed, tlsCrt, env.Org) {
 					certID, err = klient.Universal.Certificate().Upload(ctx, tlsKey, tlsCrt)
 					if err != nil {
+						recordEvent(r.Recorder, desired, v1.EventTypeWarning, ReasonSyncFailed, "%v", err)
 						return ctrl.Result{Requeue: true}, err
 					}
 
 					log.Info("uploaded certificate to Tyk", "certID", certID)
+					recordEvent(
+						r.Recorder, desired, v1.EventTypeNormal, ReasonCertificateUploaded,
+						"Uploaded certificate %s to Tyk", certID,
+					)
 
 					isCertPreviouslyProcessed = true
 				}
@@ -198,10 +229,15 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if !isCertificateAlreadyUploaded(ctx, isCertPreviouslyProcessed, tlsCrt, env.Org) {
 				certID, err = klient.Universal.Certificate().Upload(ctx, tlsKey, tlsCrt)
 				if err != nil {
+					recordEvent(r.Recorder, desired, v1.EventTypeWarning, ReasonSyncFailed, "%v", err)
 					return ctrl.Result{Requeue: true}, err
 				}
 
 				log.Info("uploaded certificate to Tyk", "certID", certID)
+				recordEvent(
+					r.Recorder, desired, v1.EventTypeNormal, ReasonCertificateUploaded,
+					"Uploaded certificate %s to Tyk", certID,
+				)
 
 				isCertPreviouslyProcessed = true
 			}
@@ -232,7 +268,16 @@ func (r *SecretCertReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
-func (r *SecretCertReconciler) delete(ctx context.Context, desired *v1.Secret, log logr.Logger, orgID string) error {
+// delete deletes the certificate of desired from Tyk. paused is the reason its
+// reconciliation is paused, empty if it is not, the certificate is then left on
+// Tyk.
+func (r *SecretCertReconciler) delete(
+	ctx context.Context,
+	desired *v1.Secret,
+	log logr.Logger,
+	orgID string,
+	paused string,
+) error {
 	log.Info("secret being deleted")
 	// If our finalizer is present, need to delete from Tyk still
 	if util.ContainsFinalizer(desired, certFinalizerName) {
@@ -243,6 +288,13 @@ func (r *SecretCertReconciler) delete(ctx context.Context, desired *v1.Secret, l
 			return nil
 		}
 
+		if paused != "" {
+			leaveOnTyk(r.Recorder, log, desired, paused)
+			util.RemoveFinalizer(desired, certFinalizerName)
+
+			return r.Update(ctx, desired)
+		}
+
 		certFingerPrint, err := cert.CalculateFingerPrint(certPemBytes)
 		if err != nil {
 			log.Error(err, "Failed to delete Tyk certificate")
@@ -255,10 +307,15 @@ func (r *SecretCertReconciler) delete(ctx context.Context, desired *v1.Secret, l
 
 		if err := klient.Universal.Certificate().Delete(ctx, certID); err != nil {
 			log.Error(err, "unable to delete certificate")
+			recordEvent(r.Recorder, desired, v1.EventTypeWarning, ReasonSyncFailed, "%v", err)
+
 			return err
 		}
 
-		if err := klient.Universal.HotReload(ctx); err != nil {
+		certificateExpiries.forget(certID)
+		recordEvent(r.Recorder, desired, v1.EventTypeNormal, ReasonDeleted, "Deleted certificate %s from Tyk", certID)
+
+		if err := hotReload(ctx); err != nil {
 			return err
 		}
 
@@ -296,7 +353,7 @@ func (r *SecretCertReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.Secret{}).
 		WithEventFilter(r.ignoreNonTLSPredicate()).
-		Complete(r)
+		Complete(tracing.Reconciler("SecretCert", r))
 }
 
 type mySecretType struct {
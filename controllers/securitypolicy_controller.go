@@ -26,15 +26,19 @@ import (
 	opclient "github.com/TykTechnologies/tyk-operator/pkg/client"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const policyFinalizer = "finalizers.tyk.io/securitypolicy"
@@ -70,19 +74,38 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	// A paused SecurityPolicy being deleted leaves its policy on Tyk, unless it
+	// is retained: it must be unstamped first.
+	reason, paused := pauseReason(policy, env)
+	if paused && (policy.DeletionTimestamp.IsZero() || retained(policy)) {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, policy, &policy.Status.Conditions, reason)
+	}
+
 	var reqA time.Duration
 
 	_, err = util.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if err := checkDeletionPolicy(policy); err != nil {
+			return err
+		}
+
 		if !policy.ObjectMeta.DeletionTimestamp.IsZero() {
 			if util.ContainsFinalizer(policy, policyFinalizer) {
-				return r.delete(ctx, policy)
+				return r.delete(ctx, policy, reason)
 			}
 			return nil
 		}
 
 		util.AddFinalizer(policy, policyFinalizer)
 
-		if policy.Spec.ID == nil || *policy.Spec.ID == "" {
+		id, adopting := adoptID(policy)
+		if adopting && policy.Status.PolID != "" && policy.Status.PolID != id &&
+			(policy.Spec.ID == nil || *policy.Spec.ID != id) {
+			return fmt.Errorf("policy %q was applied, it can't adopt policy %q: %w",
+				policy.Status.PolID, id, ErrAdoptionFailed)
+		}
+
+		if !adopting && (policy.Spec.ID == nil || *policy.Spec.ID == "") {
 			if policy.Spec.ID == nil {
 				policy.Spec.ID = new(string)
 			}
@@ -101,6 +124,10 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		policy.Spec.OrgID = &orgID
 
 		if policy.Status.PolID == "" {
+			if adopting {
+				return r.adopt(ctx, policy, id)
+			}
+
 			return r.create(ctx, policy)
 		}
 
@@ -114,13 +141,33 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return nil
 	})
 
+	err = reloadResult(ctx, err)
+
 	if err == nil {
 		r.Log.Info("Completed reconciling SecurityPolicy instance")
-	} else {
-		reqA = queueAfter
+
+		// Compare the SecurityPolicy with Tyk again to detect changes made there.
+		if policy.ObjectMeta.DeletionTimestamp.IsZero() {
+			reqA = resyncAfter(env, reqA)
+		}
 	}
 
-	return ctrl.Result{RequeueAfter: reqA}, err
+	// A policy failing to be deleted still exists, its failure is recorded.
+	if policy.ObjectMeta.DeletionTimestamp.IsZero() || err != nil {
+		// The policy may be applied to some gateways only.
+		if nodes := nodesStatus(ctx, err); nodes != nil {
+			policy.Status.Nodes = nodes
+		}
+
+		recordSync(r.Recorder, policy, policy.Status.Conditions, err)
+		setConditions(&policy.Status.Conditions, &policy.Status.ObservedGeneration, policy.Generation, err)
+
+		if errK8s := r.Status().Update(ctx, policy); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	return requeue(reqA, err)
 }
 
 // spec returns a copy of SecurityPolicySpec with AccessRightsArray updated. As a result, each AccessRightsArray
@@ -147,6 +194,8 @@ func (r *SecurityPolicyReconciler) spec(
 		spec.AccessRights[*spec.AccessRightsArray[i].APIID] = *spec.AccessRightsArray[i]
 	}
 
+	stampPolicy(&spec.SecurityPolicySpec, r.Env.OperatorID)
+
 	return spec, nil
 }
 
@@ -171,11 +220,11 @@ func (r *SecurityPolicyReconciler) updateAccess(ctx context.Context, ad *model.A
 
 	if api.Status.ApiID == "" {
 		r.Log.Error(
-			opclient.ErrNotFound,
+			ErrDependencyNotReady,
 			"Failed to find ApiDefinition on Tyk", "ApiDefinition", client.ObjectKeyFromObject(api),
 		)
 
-		return opclient.ErrNotFound
+		return fmt.Errorf("ApiDefinition %s: %w", client.ObjectKeyFromObject(api), ErrDependencyNotReady)
 	}
 
 	if ad.APIID == nil {
@@ -192,10 +241,17 @@ func (r *SecurityPolicyReconciler) updateAccess(ctx context.Context, ad *model.A
 	return nil
 }
 
-func (r *SecurityPolicyReconciler) delete(ctx context.Context, policy *tykv1.SecurityPolicy) error {
+// delete deletes policy from Tyk and removes its links with ApiDefinitions.
+// paused is the reason its reconciliation is paused, empty if it is not, its
+// policy is then left on Tyk.
+func (r *SecurityPolicyReconciler) delete(ctx context.Context, policy *tykv1.SecurityPolicy, paused string) error {
+	ctx, span := tracing.Start(ctx, "SecurityPolicy.delete")
+	defer span.End()
+
 	r.Log.Info("Deleting a policy", "policy", client.ObjectKeyFromObject(policy))
 
-	if r.Env.Mode == "pro" {
+	// A retained policy, or one left on Tyk, still serves the catalogue.
+	if r.Env.Mode == "pro" && !retained(policy) && paused == "" {
 		all, err := klient.Universal.Portal().Catalogue().Get(ctx)
 		if err != nil {
 			return err
@@ -210,23 +266,17 @@ func (r *SecurityPolicyReconciler) delete(ctx context.Context, policy *tykv1.Sec
 
 	util.RemoveFinalizer(policy, policyFinalizer)
 
-	_, errTyk := klient.Universal.Portal().Policy().Get(ctx, policy.Status.PolID)
-	if !opclient.IsNotFound(errTyk) {
-		err := klient.Universal.Portal().Policy().Delete(ctx, policy.Status.PolID)
-		if err != nil {
-			r.Log.Error(err, "Failed to delete SecurityPolicy from Tyk",
-				"Policy", client.ObjectKeyFromObject(policy).String(),
-			)
+	switch {
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, policy, paused)
+	case retained(policy):
+		r.Log.Info("Retaining policy on Tyk", "Policy ID", policy.Status.PolID)
 
+		if err := unstampPolicy(ctx, policy.Status.PolID); err != nil {
 			return err
 		}
-
-		err = klient.Universal.HotReload(ctx)
-		if err != nil {
-			r.Log.Error(err, "Failed to hot-reload Tyk after deleting a Policy",
-				"Policy", client.ObjectKeyFromObject(policy),
-			)
-
+	default:
+		if err := r.deleteFromTyk(ctx, policy); err != nil {
 			return err
 		}
 	}
@@ -241,9 +291,42 @@ func (r *SecurityPolicyReconciler) delete(ctx context.Context, policy *tykv1.Sec
 	return nil
 }
 
+// deleteFromTyk deletes the policy of policy from Tyk, if it still exists.
+func (r *SecurityPolicyReconciler) deleteFromTyk(ctx context.Context, policy *tykv1.SecurityPolicy) error {
+	_, errTyk := klient.Universal.Portal().Policy().Get(ctx, policy.Status.PolID)
+	if opclient.IsNotFound(errTyk) {
+		return nil
+	}
+
+	err := klient.Universal.Portal().Policy().Delete(ctx, policy.Status.PolID)
+	if err != nil {
+		r.Log.Error(err, "Failed to delete SecurityPolicy from Tyk",
+			"Policy", client.ObjectKeyFromObject(policy).String(),
+		)
+
+		return err
+	}
+
+	err = hotReload(ctx)
+	if err != nil {
+		r.Log.Error(err, "Failed to hot-reload Tyk after deleting a Policy",
+			"Policy", client.ObjectKeyFromObject(policy),
+		)
+
+		return err
+	}
+
+	recordDeleted(r.Recorder, policy, policy.Status.PolID)
+
+	return nil
+}
+
 func (r *SecurityPolicyReconciler) update(ctx context.Context,
 	policy *tykv1.SecurityPolicy,
 ) (*model.SecurityPolicySpec, error) {
+	ctx, span := tracing.Start(ctx, "SecurityPolicy.update")
+	defer span.End()
+
 	r.Log.Info("Updating SecurityPolicy", "Policy ID", policy.Status.PolID)
 
 	updatePolicyMID(ctx, &policy.Spec, &policy.Status.PolID)
@@ -265,7 +348,30 @@ func (r *SecurityPolicyReconciler) update(ctx context.Context,
 				return nil, err
 			}
 
-			return &spec.SecurityPolicySpec, r.updatePolicyStatus(ctx, policy, nil)
+			return &spec.SecurityPolicySpec, r.updatePolicyStatus(ctx, policy, func(status *tykv1.SecurityPolicyStatus) {
+				setDrifted(&status.Conditions, policy.Generation, false, nil)
+			})
+		}
+
+		if drifted(policy.Status.LatestTykSpecHash, policy.Status.LatestCRDSpecHash, spec, specTyk) {
+			fields := driftedFields(spec.SecurityPolicySpec, specTyk)
+
+			switch driftPolicy(ctx) {
+			case tykv1.DriftIgnore:
+				return &spec.SecurityPolicySpec, nil
+			case tykv1.DriftReport:
+				r.Log.Info("SecurityPolicy was changed on Tyk", "drift", driftSummary(fields))
+				r.Recorder.Eventf(policy, v1.EventTypeWarning, "Drifted",
+					"SecurityPolicy was changed on Tyk: %s", driftSummary(fields))
+
+				return &spec.SecurityPolicySpec, r.updatePolicyStatus(ctx, policy, func(status *tykv1.SecurityPolicyStatus) {
+					setDrifted(&status.Conditions, policy.Generation, true, fields)
+				})
+			}
+
+			r.Log.Info("Reverting changes made on Tyk", "drift", driftSummary(fields))
+			r.Recorder.Eventf(policy, v1.EventTypeNormal, "DriftCorrected",
+				"Reverted changes made on Tyk: %s", driftSummary(fields))
 		}
 
 		err = klient.Universal.Portal().Policy().Update(ctx, spec)
@@ -274,7 +380,14 @@ func (r *SecurityPolicyReconciler) update(ctx context.Context,
 			return nil, err
 		}
 	} else {
-		if opclient.IsNotFound(err) {
+		// The adopted policy was deleted from Tyk, creating it again would lose
+		// the keys bound to it.
+		if _, ok := adoptID(policy); ok && opclient.IsNotFound(err) {
+			return nil, fmt.Errorf("policy %s was deleted from Tyk: %w", policy.Status.PolID, ErrAdoptionFailed)
+		}
+
+		// Gateways that lost the policy get it back.
+		if opclient.IsNotFound(err) || opclient.IsOutOfSync(err) {
 			err = klient.Universal.Portal().Policy().Create(ctx, spec)
 			if err != nil {
 				r.Log.Error(err, "Failed to re-create Policy on Tyk",
@@ -293,7 +406,7 @@ func (r *SecurityPolicyReconciler) update(ctx context.Context,
 		}
 	}
 
-	err = klient.Universal.HotReload(ctx)
+	err = hotReload(ctx)
 	if err != nil {
 		r.Log.Error(err, "Failed to hot-reload Tyk after updating the Policy",
 			"SecurityPolicy", client.ObjectKeyFromObject(policy),
@@ -314,6 +427,7 @@ func (r *SecurityPolicyReconciler) update(ctx context.Context,
 	return &spec.SecurityPolicySpec, r.updatePolicyStatus(ctx, policy, func(status *tykv1.SecurityPolicyStatus) {
 		status.LatestTykSpecHash = calculateHash(polOnTyk)
 		status.LatestCRDSpecHash = calculateHash(spec)
+		setDrifted(&status.Conditions, policy.Generation, false, nil)
 	})
 }
 
@@ -336,6 +450,9 @@ func setPolicyStatusPolID(ctx context.Context, policy *tykv1.SecurityPolicy, spe
 }
 
 func (r *SecurityPolicyReconciler) create(ctx context.Context, policy *tykv1.SecurityPolicy) error {
+	ctx, span := tracing.Start(ctx, "SecurityPolicy.create")
+	defer span.End()
+
 	r.Log.Info("Creating a policy")
 
 	spec, err := r.spec(ctx, &policy.Spec)
@@ -375,7 +492,7 @@ func (r *SecurityPolicyReconciler) create(ctx context.Context, policy *tykv1.Sec
 		}
 	}
 
-	err = klient.Universal.HotReload(ctx)
+	err = hotReload(ctx)
 	if err != nil {
 		r.Log.Error(err, "Failed to hot-reload Tyk after creating a Policy",
 			"Policy", client.ObjectKeyFromObject(policy),
@@ -403,6 +520,78 @@ func (r *SecurityPolicyReconciler) create(ctx context.Context, policy *tykv1.Sec
 	return r.updatePolicyStatus(ctx, policy, func(status *tykv1.SecurityPolicyStatus) {
 		status.LatestTykSpecHash = calculateHash(polOnTyk)
 		status.LatestCRDSpecHash = calculateHash(spec)
+		setDrifted(&status.Conditions, policy.Generation, false, nil)
+	})
+}
+
+// adopt takes over the policy on Tyk identified by id, updating it to match
+// policy. The policy keeps its id on Tyk, it is never created.
+func (r *SecurityPolicyReconciler) adopt(ctx context.Context, policy *tykv1.SecurityPolicy, id string) error {
+	ctx, span := tracing.Start(ctx, "SecurityPolicy.adopt")
+	defer span.End()
+
+	r.Log.Info("Adopting policy", "id", id)
+
+	existing, err := klient.Universal.Portal().Policy().Get(ctx, id)
+	if err != nil {
+		if opclient.IsNotFound(err) {
+			return fmt.Errorf("policy %s not found on Tyk: %w", id, ErrAdoptionFailed)
+		}
+
+		return err
+	}
+
+	polID := id
+	if existing.ID != nil && *existing.ID != "" {
+		polID = *existing.ID
+	}
+
+	if policy.Spec.ID != nil && *policy.Spec.ID != "" && *policy.Spec.ID != polID {
+		return fmt.Errorf("id %q differs from the adopted policy %q: %w", *policy.Spec.ID, polID, ErrAdoptionFailed)
+	}
+
+	policy.Spec.ID = &polID
+	updatePolicyMID(ctx, &policy.Spec, existing.MID)
+
+	spec, err := r.spec(ctx, &policy.Spec)
+	if err != nil {
+		return err
+	}
+
+	fields := driftedFields(spec.SecurityPolicySpec, existing)
+
+	err = klient.Universal.Portal().Policy().Update(ctx, spec)
+	if err != nil {
+		r.Log.Error(err, "Failed to update adopted policy on Tyk",
+			"Policy", client.ObjectKeyFromObject(policy),
+		)
+
+		return err
+	}
+
+	err = hotReload(ctx)
+	if err != nil {
+		r.Log.Error(err, "Failed to hot-reload Tyk after adopting a Policy",
+			"Policy", client.ObjectKeyFromObject(policy),
+		)
+
+		return err
+	}
+
+	r.Recorder.Eventf(policy, v1.EventTypeNormal, "Adopted",
+		"Adopted policy %s from Tyk, %s", polID, adoptionSummary(fields))
+
+	err = r.updateStatusOfLinkedAPIs(ctx, policy, false)
+	if err != nil {
+		return err
+	}
+
+	polOnTyk, _ := klient.Universal.Portal().Policy().Get(ctx, polID) //nolint:errcheck
+
+	return r.updatePolicyStatus(ctx, policy, func(status *tykv1.SecurityPolicyStatus) {
+		status.LatestTykSpecHash = calculateHash(polOnTyk)
+		status.LatestCRDSpecHash = calculateHash(spec)
+		setDrifted(&status.Conditions, policy.Generation, false, nil)
 	})
 }
 
@@ -540,6 +729,13 @@ func (r *SecurityPolicyReconciler) updateStatusOfLinkedAPIs(ctx context.Context,
 func (r *SecurityPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tykv1.SecurityPolicy{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		Complete(r)
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled, contextUpdated)).
+		Watches(
+			&source.Kind{Type: &tykv1.OperatorContext{}},
+			linkedResources(func(s *tykv1.OperatorContextStatus) []model.Target {
+				return s.LinkedSecurityPolicies
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("SecurityPolicy", r))
 }
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	netV1 "k8s.io/api/networking/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrCrossShardReference is returned when a resource references an object
+// managed by another shard of the operator. Both must be in the same shard.
+var ErrCrossShardReference = errors.New("referenced resource belongs to another shard")
+
+// ShardSelectors returns the selectors of the cache of the shard selected by
+// selector, for the kinds of resources split between the shards of the
+// operator. OperatorContexts and Secrets are shared by all shards, each one is
+// only reconciled by the shard it belongs to.
+func ShardSelectors(selector labels.Selector) cache.SelectorsByObject {
+	return cache.SelectorsByObject{
+		&v1alpha1.ApiDefinition{}:       {Label: selector},
+		&v1alpha1.APIDescription{}:      {Label: selector},
+		&v1alpha1.ApiEventWebhook{}:     {Label: selector},
+		&v1alpha1.ApiKey{}:              {Label: selector},
+		&v1alpha1.DashboardUser{}:       {Label: selector},
+		&v1alpha1.DashboardUserGroup{}:  {Label: selector},
+		&v1alpha1.PortalAPICatalogue{}:  {Label: selector},
+		&v1alpha1.PortalConfig{}:        {Label: selector},
+		&v1alpha1.SecurityPolicy{}:      {Label: selector},
+		&v1alpha1.SubGraph{}:            {Label: selector},
+		&v1alpha1.SuperGraph{}:          {Label: selector},
+		&v1alpha1.TykOasApiDefinition{}: {Label: selector},
+		&v1alpha1.TykOrganisation{}:     {Label: selector},
+		&netV1.Ingress{}:                {Label: selector},
+	}
+}
+
+var shardedTypes = func() map[reflect.Type]bool {
+	o := make(map[reflect.Type]bool)
+	for obj := range ShardSelectors(labels.Everything()) {
+		o[reflect.TypeOf(obj)] = true
+	}
+
+	return o
+}()
+
+// ShardedCache wraps newCache, such as a multi-namespace cache, so that it
+// only holds the sharded resources matched by selectors, see ShardSelectors.
+// Without another cache, use cache.BuilderWithOptions.
+func ShardedCache(newCache cache.NewCacheFunc, selectors cache.SelectorsByObject) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		opts.SelectorsByObject = selectors
+		return newCache(config, opts)
+	}
+}
+
+// ShardLeaderElectionID returns the leader election id of the shard selected
+// by selector, derived from id.
+func ShardLeaderElectionID(id string, selector labels.Selector) string {
+	sum := sha256.Sum256([]byte(selector.String()))
+	return fmt.Sprintf("%s-shard-%x", id, sum[:4])
+}
+
+// inShard returns true if the resource o belongs to the shard selected by
+// selector. Every resource belongs to an operator without shard.
+func inShard(selector labels.Selector, o metav1.Object) bool {
+	return selector == nil || selector.Matches(labels.Set(o.GetLabels()))
+}
+
+// inheritShard copies the shard label of parent to child, a resource created
+// for parent, so that both belong to the same shard.
+func inheritShard(child, parent metav1.Object) {
+	shard, ok := parent.GetLabels()[keys.ShardLabel]
+	if !ok {
+		return
+	}
+
+	l := child.GetLabels()
+	if l == nil {
+		l = make(map[string]string)
+	}
+
+	l[keys.ShardLabel] = shard
+	child.SetLabels(l)
+}
+
+// crossShardError is returned when the object read by a shard belongs to
+// another shard. It is a NotFound error, as the object is missing from the
+// cache of the shard, and ErrCrossShardReference.
+type crossShardError struct {
+	notFound error
+	key      client.ObjectKey
+	kind     string
+}
+
+func (e *crossShardError) Error() string {
+	return fmt.Sprintf("%s %s belongs to another shard of the operator", e.kind, e.key)
+}
+
+func (e *crossShardError) Unwrap() []error {
+	return []error{ErrCrossShardReference, e.notFound}
+}
+
+// NewShardClient returns a client reading the sharded resources from c, which
+// only caches those of the shard selected by selector. The objects c misses
+// are read again with reader, uncached, to report the references to objects of
+// another shard.
+func NewShardClient(c client.Client, reader client.Reader, selector labels.Selector) client.Client {
+	return &shardClient{Client: c, reader: reader, selector: selector}
+}
+
+type shardClient struct {
+	client.Client
+	reader   client.Reader
+	selector labels.Selector
+}
+
+func (c *shardClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	err := c.Client.Get(ctx, key, obj)
+	if !k8sErrors.IsNotFound(err) || !shardedTypes[reflect.TypeOf(obj)] {
+		return err
+	}
+
+	o, ok := obj.DeepCopyObject().(client.Object)
+	if !ok || c.reader.Get(ctx, key, o) != nil {
+		return err
+	}
+
+	// An object of the shard may not be cached yet.
+	if inShard(c.selector, o) {
+		return err
+	}
+
+	return &crossShardError{notFound: err, key: key, kind: reflect.TypeOf(obj).Elem().Name()}
+}
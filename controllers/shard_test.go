@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCrossShardReference(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	shard := func(name string) map[string]string {
+		return map[string]string{keys.ShardLabel: name}
+	}
+
+	policy := &tykv1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Name: "gold", Namespace: "default", Labels: shard("a")},
+		Spec: tykv1alpha1.SecurityPolicySpec{
+			SecurityPolicySpec: model.SecurityPolicySpec{
+				Name: "gold",
+				AccessRightsArray: []*model.AccessDefinition{
+					{Name: "httpbin", Namespace: "default"},
+				},
+			},
+		},
+	}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default", Labels: shard("b")},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin"},
+		},
+		Status: tykv1alpha1.ApiDefinitionStatus{ApiID: "httpbin"},
+	}
+
+	// uncached is not cached yet by shard a.
+	uncached := api.DeepCopy()
+	uncached.Name = "uncached"
+	uncached.Labels = shard("a")
+
+	// The cache of shard a only holds its own resources.
+	cached, err := NewFakeClient([]runtime.Object{policy})
+	is.NoErr(err)
+
+	reader, err := NewFakeClient([]runtime.Object{policy, api, uncached})
+	is.NoErr(err)
+
+	selector, err := labels.Parse("tyk.io/shard=a")
+	is.NoErr(err)
+
+	cl := NewShardClient(cached, reader, selector)
+	ctx := context.Background()
+
+	err = cl.Get(ctx, client.ObjectKeyFromObject(api), &tykv1alpha1.ApiDefinition{})
+	is.True(errors.Is(err, ErrCrossShardReference))
+	is.True(k8sErrors.IsNotFound(err))
+
+	err = cl.Get(ctx, client.ObjectKeyFromObject(uncached), &tykv1alpha1.ApiDefinition{})
+	is.True(k8sErrors.IsNotFound(err))
+	is.True(!errors.Is(err, ErrCrossShardReference))
+
+	// The policy of shard a references the ApiDefinition of shard b.
+	r := SecurityPolicyReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env()}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	_, err = r.Reconcile(ctx, req)
+	is.True(errors.Is(err, ErrCrossShardReference))
+	is.Equal(len(s.Policies()), 0)
+
+	policy = &tykv1alpha1.SecurityPolicy{}
+	is.NoErr(cl.Get(ctx, req.NamespacedName, policy))
+
+	c := meta.FindStatusCondition(policy.Status.Conditions, tykv1alpha1.ConditionReady)
+	is.True(c != nil)
+	is.Equal(c.Status, v1.ConditionFalse)
+	is.Equal(c.Reason, "CrossShardReference")
+	is.Equal(c.Message, "ApiDefinition default/httpbin belongs to another shard of the operator")
+}
+
+func TestInheritShard(t *testing.T) {
+	is := is.New(t)
+
+	parent := &tykv1alpha1.TykOrganisation{
+		ObjectMeta: v1.ObjectMeta{Labels: map[string]string{keys.ShardLabel: "a"}},
+	}
+	child := &tykv1alpha1.OperatorContext{}
+
+	inheritShard(child, parent)
+	is.Equal(child.Labels[keys.ShardLabel], "a")
+
+	a, err := labels.Parse("tyk.io/shard=a")
+	is.NoErr(err)
+
+	b, err := labels.Parse("tyk.io/shard=b")
+	is.NoErr(err)
+
+	is.True(inShard(a, child))
+	is.True(!inShard(b, child))
+	is.True(inShard(nil, child))
+	is.True(ShardLeaderElectionID("91ad8c6e.tyk.io", a) != ShardLeaderElectionID("91ad8c6e.tyk.io", b))
+}
+
+func TestShardedCache(t *testing.T) {
+	is := is.New(t)
+
+	a, err := labels.Parse("tyk.io/shard=a")
+	is.NoErr(err)
+
+	selectors := ShardSelectors(a)
+	is.Equal(len(selectors), len(shardedTypes))
+
+	var got cache.Options
+
+	newCache := ShardedCache(func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		got = opts
+		return nil, nil
+	}, selectors)
+
+	_, err = newCache(nil, cache.Options{Namespace: "default"})
+	is.NoErr(err)
+	is.Equal(got.Namespace, "default")
+	is.Equal(len(got.SelectorsByObject), len(selectors))
+
+	for obj, s := range got.SelectorsByObject {
+		is.True(shardedTypes[reflect.TypeOf(obj)])
+		is.Equal(s.Label.String(), "tyk.io/shard=a")
+	}
+}
+
+func TestSecretCertShard(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewGateway()
+	defer s.Close()
+
+	key, crt := tlsCertificate(t, "example.com", time.Hour)
+
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "tls",
+			Namespace: "default",
+			Labels:    map[string]string{keys.ShardLabel: "b"},
+		},
+		Type: TLSSecretType,
+		Data: map[string][]byte{"tls.key": key, "tls.crt": crt},
+	}
+
+	api := &tykv1alpha1.ApiDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: tykv1alpha1.APIDefinitionSpec{
+			APIDefinitionSpec: model.APIDefinitionSpec{Name: "httpbin", CertificateSecretNames: []string{"tls"}},
+		},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{secret, api})
+	is.NoErr(err)
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+	ctx := context.Background()
+
+	reconcileIn := func(shard string) {
+		selector, err := labels.Parse("tyk.io/shard=" + shard)
+		is.NoErr(err)
+
+		r := SecretCertReconciler{Client: cl, Log: log.NullLogger{}, Env: s.Env(), Shard: selector}
+		_, err = r.Reconcile(ctx, req)
+		is.NoErr(err)
+	}
+
+	// The Secret is left to its own shard.
+	reconcileIn("a")
+	is.Equal(len(s.Certificates()), 0)
+
+	reconcileIn("b")
+	is.Equal(len(s.Certificates()), 1)
+
+	is.NoErr(cl.Get(ctx, client.ObjectKeyFromObject(api), api))
+	is.Equal(api.Spec.Certificates, s.Certificates())
+}
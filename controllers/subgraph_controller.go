@@ -31,8 +31,10 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -45,9 +47,10 @@ var (
 // SubGraphReconciler reconciles a SubGraph object
 type SubGraphReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Env    environment.Env
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Env      environment.Env
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=subgraphs,verbs=get;list;watch;create;update;patch;delete
@@ -119,18 +122,30 @@ func (r *SubGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
+	// A SubGraph is deleted without calling Tyk, the pause only holds its changes.
+	if reason, ok := pauseReason(desired, r.Env); ok {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
 	if !util.ContainsFinalizer(desired, keys.SubGraphFinalizerName) {
 		util.AddFinalizer(desired, keys.SubGraphFinalizerName)
 	}
 
 	err := r.Update(ctx, desired)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	recordSync(r.Recorder, desired, desired.Status.Conditions, nil)
+	setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, nil)
 
-	return ctrl.Result{}, err
+	return ctrl.Result{}, r.Status().Update(ctx, desired)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SubGraphReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tykv1alpha1.SubGraph{}).
-		Complete(r)
+		Complete(tracing.Reconciler("SubGraph", r))
 }
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -37,7 +38,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -51,9 +54,10 @@ var ErrSuperGraphReference = errors.New("SuperGraph is referenced in ApiDefiniti
 // SuperGraphReconciler reconciles a SuperGraph object
 type SuperGraphReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
-	Env    environment.Env
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tyk.tyk.io,resources=supergraphs,verbs=get;list;watch;create;update;patch;delete
@@ -102,18 +106,46 @@ func (r *SuperGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// A SuperGraph is deleted without calling Tyk, the pause only holds its changes.
+	if reason, ok := pauseReason(desired, r.Env); ok {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	err := r.merge(ctx, req.Namespace, desired)
+	if err == nil {
+		if !util.ContainsFinalizer(desired, keys.SuperGraphFinalizerName) {
+			util.AddFinalizer(desired, keys.SuperGraphFinalizerName)
+		}
+
+		err = r.Update(ctx, desired)
+	}
+
+	recordSync(r.Recorder, desired, desired.Status.Conditions, err)
+	setConditions(&desired.Status.Conditions, &desired.Status.ObservedGeneration, desired.Generation, err)
+
+	if errK8s := r.Status().Update(ctx, desired); errK8s != nil && err == nil {
+		err = errK8s
+	}
+
+	return ctrl.Result{}, err
+}
+
+// merge sets the merged SDL of desired to the SDLs of the SubGraph resources
+// it references merged together.
+func (r *SuperGraphReconciler) merge(ctx context.Context, namespace string, desired *tykv1alpha1.SuperGraph) error {
 	var sdls []string
 
 	for _, subGraphRef := range desired.Spec.SubgraphRefs {
 		// In the subgraph_refs field of the SuperGraph, the namespace for referenced subgraph is optional. If the
-		// namespace is not specified, use req.Namespace.
+		// namespace is not specified, use the namespace of the SuperGraph.
 		ns := subGraphRef.Namespace
 		if ns == nil || *ns == "" {
 			if ns == nil {
 				ns = new(string)
 			}
 
-			*ns = req.Namespace
+			*ns = namespace
 		}
 
 		subGraph := &tykv1alpha1.SubGraph{}
@@ -121,7 +153,7 @@ func (r *SuperGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			Name:      subGraphRef.Name,
 			Namespace: *ns,
 		}, subGraph); err != nil {
-			return ctrl.Result{}, err
+			return err
 		}
 
 		sdls = append(sdls, subGraph.Spec.SDL)
@@ -129,21 +161,12 @@ func (r *SuperGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	mergedSdl, err := graphQlMerge.MergeSDLs(sdls...)
 	if err != nil {
-		return ctrl.Result{}, err
+		return fmt.Errorf("%w: %v", ErrCompositionFailed, err)
 	}
 
 	desired.Spec.MergedSDL = mergedSdl
 
-	if !util.ContainsFinalizer(desired, keys.SuperGraphFinalizerName) {
-		util.AddFinalizer(desired, keys.SuperGraphFinalizerName)
-	}
-
-	err = r.Update(ctx, desired)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	return ctrl.Result{}, nil
+	return nil
 }
 
 func (r *SuperGraphReconciler) findObjectsForSupergraph(subGraph client.Object) []reconcile.Request {
@@ -199,7 +222,7 @@ func (r *SuperGraphReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSupergraph),
 			builder.WithPredicates(r.ignoreSubGraphCreationEvents()),
 		).
-		Complete(r)
+		Complete(tracing.Reconciler("SuperGraph", r))
 }
 
 func (r *SuperGraphReconciler) ignoreSubGraphCreationEvents() predicate.Predicate {
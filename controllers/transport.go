@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// caBundleKey is the key of the PEM encoded CA bundle in the secret referenced
+// by TLS.CASecretRef.
+const caBundleKey = "ca.crt"
+
+var errNoClientForSecrets = errors.New("a kubernetes client is required to read TLS secrets")
+
+// httpClient returns the client used to call Tyk on behalf of the
+// OperatorContext identified by key. Secrets referenced by env without a
+// namespace are looked up in namespace.
+//
+// Secrets are read on every reconciliation, so the client is rebuilt as soon as
+// the CA bundle or the client certificate changes.
+func httpClient(
+	ctx context.Context,
+	rClient client.Client,
+	key, namespace string,
+	env v1alpha1.Environment,
+) (*http.Client, error) {
+	conf, err := transportConfig(ctx, rClient, namespace, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return tykClient.HTTPClient(key, conf)
+}
+
+func transportConfig(
+	ctx context.Context,
+	rClient client.Client,
+	namespace string,
+	env v1alpha1.Environment,
+) (tykClient.TransportConfig, error) {
+	conf := tykClient.TransportConfig{
+		InsecureSkipVerify: env.InsecureSkipVerify,
+		ProxyURL:           env.ProxyURL,
+	}
+
+	if t := env.Timeouts; t != nil {
+		if t.Request != nil {
+			conf.Timeout = t.Request.Duration
+		}
+
+		if t.Dial != nil {
+			conf.DialTimeout = t.Dial.Duration
+		}
+
+		if t.TLSHandshake != nil {
+			conf.TLSHandshakeTimeout = t.TLSHandshake.Duration
+		}
+	}
+
+	if env.TLS == nil {
+		return conf, nil
+	}
+
+	if env.TLS.CASecretRef != nil {
+		secret, err := readSecret(ctx, rClient, namespace, env.TLS.CASecretRef)
+		if err != nil {
+			return conf, err
+		}
+
+		conf.CA = secret.Data[caBundleKey]
+	}
+
+	if env.TLS.ClientCertSecretRef != nil {
+		secret, err := readSecret(ctx, rClient, namespace, env.TLS.ClientCertSecretRef)
+		if err != nil {
+			return conf, err
+		}
+
+		conf.Cert = secret.Data[v1.TLSCertKey]
+		conf.Key = secret.Data[v1.TLSPrivateKeyKey]
+	}
+
+	return conf, nil
+}
+
+func readSecret(ctx context.Context, rClient client.Client, namespace string, ref *model.Target) (*v1.Secret, error) {
+	if rClient == nil {
+		return nil, errNoClientForSecrets
+	}
+
+	var secret v1.Secret
+
+	key := ref.NS(namespace)
+	if err := rClient.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", key, err)
+	}
+
+	return &secret, nil
+}
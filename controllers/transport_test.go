@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTransportConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	other := "other"
+	objs := []runtime.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "tyk"},
+			Data:       map[string][]byte{caBundleKey: []byte("ca")},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: other},
+			Data:       map[string][]byte{v1.TLSCertKey: []byte("cert"), v1.TLSPrivateKeyKey: []byte("key")},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	env := v1alpha1.Environment{
+		InsecureSkipVerify: true,
+		ProxyURL:           "http://proxy:3128",
+		TLS: &v1alpha1.TLS{
+			CASecretRef:         &model.Target{Name: "ca"},
+			ClientCertSecretRef: &model.Target{Name: "client", Namespace: &other},
+		},
+		Timeouts: &v1alpha1.Timeouts{Request: &metav1.Duration{Duration: time.Second}},
+	}
+
+	conf, err := transportConfig(context.Background(), c, "tyk", env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(conf.CA, []byte("ca")) || !bytes.Equal(conf.Cert, []byte("cert")) || !bytes.Equal(conf.Key, []byte("key")) {
+		t.Errorf("expected secrets to be loaded got %+v", conf)
+	}
+
+	if !conf.InsecureSkipVerify || conf.ProxyURL != env.ProxyURL || conf.Timeout != time.Second {
+		t.Errorf("expected environment to be copied got %+v", conf)
+	}
+
+	if _, err := transportConfig(context.Background(), c, "missing", env); err == nil {
+		t.Error("expected missing CA secret to fail")
+	}
+
+	if _, err := transportConfig(context.Background(), nil, "tyk", env); err != errNoClientForSecrets {
+		t.Errorf("expected %v got %v", errNoClientForSecrets, err)
+	}
+}
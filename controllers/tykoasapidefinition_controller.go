@@ -0,0 +1,486 @@
+/*
+
+
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// TykOASConfigMapKey indexes TykOasApiDefinitions by the namespace/name of the
+// ConfigMap holding their document.
+const TykOASConfigMapKey = "tyk_oas_configmap"
+
+var (
+	ErrNoTykOASDocument       = errors.New("one of tykOAS.document or tykOAS.configmapRef must be set")
+	ErrMultipleTykOASDocument = errors.New("only one of tykOAS.document or tykOAS.configmapRef can be set")
+)
+
+// TykOasApiDefinitionReconciler reconciles a TykOasApiDefinition object
+type TykOasApiDefinitionReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykoasapidefinitions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykoasapidefinitions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykoasapidefinitions/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *TykOasApiDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("TykOasApiDefinition", req.NamespacedName.String())
+
+	log.Info("Reconciling TykOasApiDefinition instance")
+
+	desired := &tykv1alpha1.TykOasApiDefinition{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused TykOasApiDefinition being deleted leaves its API on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var def model.TykOAS
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		util.AddFinalizer(desired, keys.TykOasApiDefinitionFinalizerName)
+
+		def, err = r.definition(ctx, &env, log, desired)
+		if err != nil {
+			return err
+		}
+
+		return r.createOrUpdate(ctx, desired, def)
+	})
+
+	err = reloadResult(ctx, err)
+
+	if err == nil {
+		log.Info("Completed reconciling TykOasApiDefinition instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, &env, def, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	// Tyk rejected the API definition, retrying won't help until it is modified.
+	// The reason is recorded in the latest transaction of the status.
+	if tykClient.IsValidation(err) {
+		log.Info("TykOasApiDefinition was rejected by Tyk", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return requeue(queueA, err)
+}
+
+// definition returns the API definition sent to Tyk: the document of desired
+// with its id, organisation and certificates set.
+func (r *TykOasApiDefinitionReconciler) definition(
+	ctx context.Context,
+	env *environment.Env,
+	log logr.Logger,
+	desired *tykv1alpha1.TykOasApiDefinition,
+) (model.TykOAS, error) {
+	ctx, span := tracing.Start(ctx, "TykOasApiDefinition.definition")
+	defer span.End()
+
+	b, err := r.document(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := model.ParseTykOAS(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Tyk OAS API definition: %w", err)
+	}
+
+	if def.ID() == "" {
+		def.SetID(EncodeNS(client.ObjectKeyFromObject(desired).String()))
+	}
+
+	if def.OrgID() == "" {
+		def.SetOrgID(env.Org)
+	}
+
+	if len(desired.Spec.CertificateSecretNames) != 0 {
+		ids := make([]string, 0, len(desired.Spec.CertificateSecretNames))
+
+		for _, name := range desired.Spec.CertificateSecretNames {
+			id, err := r.uploadSecret(ctx, env, desired, name)
+			if err != nil {
+				return nil, err
+			}
+
+			ids = append(ids, id)
+		}
+
+		def.SetCertificates(ids)
+	}
+
+	if len(desired.Spec.ClientCertificateRefs) != 0 {
+		ids := make([]string, 0, len(desired.Spec.ClientCertificateRefs))
+
+		for _, name := range desired.Spec.ClientCertificateRefs {
+			id, err := r.uploadSecret(ctx, env, desired, name)
+			if err != nil {
+				// we should log the missing secret, but we should still create the API definition
+				log.Error(err, "Failed to upload client certificate", "secretName", name)
+				continue
+			}
+
+			ids = append(ids, id)
+		}
+
+		def.SetClientCertificates(ids)
+	}
+
+	if len(desired.Spec.UpstreamCertificateRefs) != 0 {
+		domains := make(map[string]string, len(desired.Spec.UpstreamCertificateRefs))
+
+		for domain, name := range desired.Spec.UpstreamCertificateRefs {
+			id, err := r.uploadSecret(ctx, env, desired, name)
+			if err != nil {
+				// we should log the missing secret, but we should still create the API definition
+				log.Error(err, "Failed to upload upstream certificate", "secretName", name)
+				continue
+			}
+
+			domains[domain] = id
+		}
+
+		def.SetUpstreamCertificates(domains)
+	}
+
+	return def, nil
+}
+
+// document returns the Tyk OAS API definition held by desired or by the
+// ConfigMap it references.
+func (r *TykOasApiDefinitionReconciler) document(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOasApiDefinition,
+) ([]byte, error) {
+	ref := desired.Spec.TykOAS
+
+	switch {
+	case ref.Document != "" && ref.ConfigmapRef != nil:
+		return nil, ErrMultipleTykOASDocument
+	case ref.Document != "":
+		return []byte(ref.Document), nil
+	case ref.ConfigmapRef == nil:
+		return nil, ErrNoTykOASDocument
+	}
+
+	key := configMapKey(desired.Namespace, ref.ConfigmapRef)
+
+	var cm v1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s: %w", key, err)
+	}
+
+	doc, ok := cm.Data[ref.ConfigmapRef.KeyName]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s has no key %q", key, ref.ConfigmapRef.KeyName)
+	}
+
+	return []byte(doc), nil
+}
+
+func configMapKey(namespace string, ref *tykv1alpha1.ConfigMapReference) types.NamespacedName {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// uploadSecret uploads the certificate of the kubernetes.io/tls secret name,
+// in the namespace of desired, to Tyk unless it is already there, and returns
+// its Tyk ID.
+func (r *TykOasApiDefinitionReconciler) uploadSecret(
+	ctx context.Context,
+	env *environment.Env,
+	desired *tykv1alpha1.TykOasApiDefinition,
+	name string,
+) (string, error) {
+	namespace := desired.Namespace
+
+	var secret v1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		return "", err
+	}
+
+	crt, ok := secret.Data[v1.TLSCertKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no %s", namespace, name, v1.TLSCertKey)
+	}
+
+	key, ok := secret.Data[v1.TLSPrivateKeyKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no %s", namespace, name, v1.TLSPrivateKeyKey)
+	}
+
+	return uploadCert(ctx, r.Recorder, desired, env.Org, key, crt)
+}
+
+func (r *TykOasApiDefinitionReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOasApiDefinition,
+	def model.TykOAS,
+) error {
+	ctx, span := tracing.Start(ctx, "TykOasApiDefinition.createOrUpdate")
+	defer span.End()
+
+	onTyk, err := klient.Universal.OAS().Get(ctx, def.ID())
+
+	switch {
+	case err == nil:
+		// Nothing changed on either side since the last update.
+		if isSame(desired.Status.LatestTykSpecHash, onTyk) && isSame(desired.Status.LatestCRDSpecHash, def) {
+			return nil
+		}
+
+		r.Log.Info("Updating TykOasApiDefinition", "api_id", def.ID())
+
+		if _, err := klient.Universal.OAS().Update(ctx, def); err != nil {
+			r.Log.Error(err, "Failed to update TykOasApiDefinition on Tyk", "api_id", def.ID())
+			return err
+		}
+	case tykClient.IsNotFound(err):
+		r.Log.Info("Creating TykOasApiDefinition", "api_id", def.ID())
+
+		if _, err := klient.Universal.OAS().Create(ctx, def); err != nil {
+			r.Log.Error(err, "Failed to create TykOasApiDefinition on Tyk", "api_id", def.ID())
+			return err
+		}
+	default:
+		return err
+	}
+
+	// The API ID can not be changed once the API is created on Tyk.
+	if desired.Status.ApiID != "" && desired.Status.ApiID != def.ID() {
+		if _, err := klient.Universal.OAS().Delete(ctx, desired.Status.ApiID); tykClient.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	err = hotReload(ctx)
+	if err != nil {
+		r.Log.Error(err, "Failed to hot-reload Tyk after updating the TykOasApiDefinition", "api_id", def.ID())
+		return err
+	}
+
+	return nil
+}
+
+// delete deletes the API of desired from Tyk. paused is the reason its
+// reconciliation is paused, empty if it is not, the API is then left on Tyk.
+func (r *TykOasApiDefinitionReconciler) delete(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOasApiDefinition,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "TykOasApiDefinition.delete")
+	defer span.End()
+
+	r.Log.Info("TykOasApiDefinition being deleted",
+		"TykOasApiDefinition", client.ObjectKeyFromObject(desired).String(),
+	)
+
+	if !util.ContainsFinalizer(desired, keys.TykOasApiDefinitionFinalizerName) {
+		return 0, nil
+	}
+
+	switch {
+	case desired.Status.ApiID == "":
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+	default:
+		_, err := klient.Universal.OAS().Delete(ctx, desired.Status.ApiID)
+		if err != nil && !tykClient.IsNotFound(err) {
+			r.Log.Error(err, "Failed to delete TykOasApiDefinition from Tyk", "api_id", desired.Status.ApiID)
+			return queueAfter, err
+		}
+
+		if err := hotReload(ctx); err != nil {
+			r.Log.Error(err, "Failed to hot-reload Tyk after deleting the TykOasApiDefinition",
+				"api_id", desired.Status.ApiID,
+			)
+
+			return queueAfter, err
+		}
+
+		recordDeleted(r.Recorder, desired, desired.Status.ApiID)
+	}
+
+	util.RemoveFinalizer(desired, keys.TykOasApiDefinitionFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. def is the API definition sent to Tyk, nil if it could not be built.
+func (r *TykOasApiDefinitionReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOasApiDefinition,
+	env *environment.Env,
+	def model.TykOAS,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	// The API is applied while the gateways are reloading.
+	applied := err == nil || errors.Is(err, ErrReloadPending)
+
+	if !applied {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	if applied && def != nil {
+		onTyk, _ := klient.Universal.OAS().Get(ctx, def.ID()) //nolint:errcheck
+
+		status.ApiID = def.ID()
+		status.OrgID = env.Org
+		status.Domain = def.Domain()
+		status.ListenPath = def.ListenPath()
+		status.TargetURL = def.TargetURL()
+		status.Enabled = def.Active()
+		status.LatestTykSpecHash = calculateHash(onTyk)
+		status.LatestCRDSpecHash = calculateHash(def)
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// findTykOASForConfigMap returns the TykOasApiDefinitions whose document is
+// held by the ConfigMap cm.
+func (r *TykOasApiDefinitionReconciler) findTykOASForConfigMap(cm client.Object) []reconcile.Request {
+	var list tykv1alpha1.TykOasApiDefinitionList
+
+	err := r.List(context.Background(), &list, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(TykOASConfigMapKey, client.ObjectKeyFromObject(cm).String()),
+	})
+	if err != nil {
+		r.Log.Error(err, "Failed to list TykOasApiDefinitions of ConfigMap", "ConfigMap", cm.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+		})
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TykOasApiDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&tykv1alpha1.TykOasApiDefinition{},
+		TykOASConfigMapKey,
+		func(rawObj client.Object) []string {
+			o, ok := rawObj.(*tykv1alpha1.TykOasApiDefinition)
+			if !ok || o.Spec.TykOAS.ConfigmapRef == nil {
+				return nil
+			}
+
+			return []string{configMapKey(o.Namespace, o.Spec.TykOAS.ConfigmapRef).String()}
+		})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.TykOasApiDefinition{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Watches(
+			&source.Kind{Type: &v1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.findTykOASForConfigMap),
+		).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedTykOasApiDefinitions
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("TykOasApiDefinition", r))
+}
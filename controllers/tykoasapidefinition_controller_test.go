@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const tykOASDocument = `
+openapi: 3.0.3
+info:
+  title: httpbin
+  version: 1.0.0
+paths: {}
+x-tyk-api-gateway:
+  info:
+    name: httpbin
+    state:
+      active: true
+  server:
+    listenPath:
+      value: /httpbin/
+  upstream:
+    url: http://httpbin.org
+`
+
+func TestTykOasApiDefinitionReconcile(t *testing.T) {
+	refs := map[string]tykv1alpha1.TykOASReference{
+		"document": {Document: tykOASDocument},
+		"configmap": {
+			ConfigmapRef: &tykv1alpha1.ConfigMapReference{Name: "httpbin", KeyName: "oas.yaml"},
+		},
+	}
+
+	servers := map[string]func() *fake.Server{
+		"gateway":   fake.NewGateway,
+		"dashboard": fake.NewDashboard,
+	}
+
+	for mode, newServer := range servers {
+		for name, ref := range refs {
+			newServer, ref := newServer, ref
+
+			t.Run(mode+"/"+name, func(t *testing.T) {
+				is := is.New(t)
+
+				s := newServer()
+				defer s.Close()
+
+				api := &tykv1alpha1.TykOasApiDefinition{
+					ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+					Spec:       tykv1alpha1.TykOasApiDefinitionSpec{TykOAS: ref},
+				}
+				cm := &corev1.ConfigMap{
+					ObjectMeta: v1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+					Data:       map[string]string{"oas.yaml": tykOASDocument},
+				}
+
+				cl, err := NewFakeClient([]runtime.Object{api, cm})
+				is.NoErr(err)
+
+				r := TykOasApiDefinitionReconciler{
+					Client: cl,
+					Log:    log.NullLogger{},
+					Env:    s.Env(),
+				}
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(api)}
+
+				_, err = r.Reconcile(context.Background(), req)
+				is.NoErr(err)
+
+				is.NoErr(cl.Get(context.Background(), req.NamespacedName, api))
+				is.Equal(api.Status.ApiID, EncodeNS("default/httpbin"))
+				is.Equal(api.Status.ListenPath, "/httpbin/")
+				is.Equal(api.Status.TargetURL, "http://httpbin.org")
+				is.True(api.Status.Enabled)
+				is.Equal(api.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+
+				defs := s.OAS()
+				is.Equal(len(defs), 1)
+				is.Equal(defs[0].ID(), api.Status.ApiID)
+				is.Equal(defs[0].OrgID(), fake.Org)
+
+				is.NoErr(cl.Delete(context.Background(), api))
+
+				_, err = r.Reconcile(context.Background(), req)
+				is.NoErr(err)
+				is.Equal(len(s.OAS()), 0)
+			})
+		}
+	}
+}
@@ -0,0 +1,571 @@
+/*
+Licensed under the Mozilla Public License (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.mozilla.org/en-US/MPL/2.0/
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	tykClient "github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/keys"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// adminSecretKey is the key of the admin secret of the dashboard in the secret
+// referenced by AdminSecretRef.
+const adminSecretKey = "adminSecret"
+
+// ErrMissingAdminSecret is returned when reconciling a TykOrganisation with an
+// environment that has no AdminSecretRef.
+var ErrMissingAdminSecret = errors.New("adminSecretRef must be set to manage organisations")
+
+// TykOrganisationReconciler reconciles a TykOrganisation object
+type TykOrganisationReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Env      environment.Env
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykorganisations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykorganisations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tyk.tyk.io,resources=tykorganisations/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *TykOrganisationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("TykOrganisation", req.NamespacedName.String())
+
+	log.Info("Reconciling TykOrganisation instance")
+
+	desired := &tykv1alpha1.TykOrganisation{}
+	if err := r.Get(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) // Ignore not-found errors
+	}
+
+	// set context for all api calls inside this reconciliation loop
+	env, ctx, err := HttpContext(ctx, r.Client, &r.Env, desired, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused TykOrganisation being deleted leaves its organisation on Tyk.
+	reason, paused := pauseReason(desired, env)
+	if paused && desired.DeletionTimestamp.IsZero() {
+		log.Info("Reconciliation is paused", "reason", reason)
+		return ctrl.Result{}, pause(ctx, r.Client, desired, &desired.Status.Conditions, reason)
+	}
+
+	var queueA time.Duration
+
+	var org *model.Organisation
+
+	// userID is the id of the dashboard user of the generated OperatorContext.
+	var userID string
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, desired, func() error {
+		if !desired.ObjectMeta.DeletionTimestamp.IsZero() {
+			e, err := r.delete(ctx, env, desired, reason)
+			queueA = e
+
+			return err
+		}
+
+		if env.Mode != "pro" {
+			return ErrDashboardOnly
+		}
+
+		adminCtx, err := r.adminContext(ctx, env, desired)
+		if err != nil {
+			return err
+		}
+
+		util.AddFinalizer(desired, keys.TykOrganisationFinalizerName)
+
+		org = &model.Organisation{
+			ID:           desired.Status.OrgID,
+			OwnerName:    desired.Spec.OwnerName,
+			OwnerSlug:    desired.Spec.OwnerSlug,
+			CNameEnabled: desired.Spec.CName != "",
+			CName:        desired.Spec.CName,
+		}
+
+		if err := r.createOrUpdate(adminCtx, desired, org); err != nil {
+			return err
+		}
+
+		userID, err = r.operatorContext(adminCtx, env, desired, org.ID)
+
+		return err
+	})
+
+	if err == nil {
+		log.Info("Completed reconciling TykOrganisation instance")
+	}
+
+	if desired.ObjectMeta.DeletionTimestamp.IsZero() {
+		if errK8s := r.updateStatus(ctx, desired, org, userID, err); errK8s != nil && err == nil {
+			err = errK8s
+		}
+	}
+
+	// Retrying won't help until the OperatorContext is changed.
+	if errors.Is(err, ErrDashboardOnly) {
+		log.Info("TykOrganisation can not be reconciled", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return requeue(queueA, err)
+}
+
+// contextNamespace returns the namespace in which secrets referenced by the
+// environment of desired are looked up, the namespace of its OperatorContext.
+func contextNamespace(desired *tykv1alpha1.TykOrganisation) string {
+	if desired.Spec.Context == nil {
+		return ""
+	}
+
+	return desired.Spec.Context.NS(desired.Namespace).Namespace
+}
+
+// adminContext returns a copy of ctx whose calls to the admin API of the
+// dashboard are authenticated with the admin secret referenced by env.
+func (r *TykOrganisationReconciler) adminContext(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.TykOrganisation,
+) (context.Context, error) {
+	if env.AdminSecretRef == nil {
+		return nil, ErrMissingAdminSecret
+	}
+
+	secret, err := readSecret(ctx, r.Client, contextNamespace(desired), env.AdminSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	v := secret.Data[adminSecretKey]
+	if len(v) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s key", secret.Namespace, secret.Name, adminSecretKey)
+	}
+
+	rctx := tykClient.GetContext(ctx)
+	rctx.AdminAuth = string(v)
+
+	return tykClient.SetContext(ctx, rctx), nil
+}
+
+// createOrUpdate creates or updates org on the dashboard. org.ID is set to the
+// id of the organisation once created.
+func (r *TykOrganisationReconciler) createOrUpdate(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOrganisation,
+	org *model.Organisation,
+) error {
+	ctx, span := tracing.Start(ctx, "TykOrganisation.createOrUpdate")
+	defer span.End()
+
+	if org.ID != "" {
+		_, err := klient.Universal.Organisations().Get(ctx, org.ID)
+
+		switch {
+		case err == nil:
+			if isSame(desired.Status.LatestCRDSpecHash, org) {
+				return nil
+			}
+
+			r.Log.Info("Updating TykOrganisation", "id", org.ID)
+
+			return klient.Universal.Organisations().Update(ctx, org)
+		case !tykClient.IsNotFound(err):
+			return err
+		}
+
+		r.Log.Info("TykOrganisation not found on the dashboard, creating a new one", "id", org.ID)
+	}
+
+	r.Log.Info("Creating TykOrganisation")
+
+	org.ID = ""
+
+	id, err := klient.Universal.Organisations().Create(ctx, org)
+	if err != nil {
+		r.Log.Error(err, "Failed to create TykOrganisation")
+		return err
+	}
+
+	org.ID = id
+
+	return nil
+}
+
+// operatorContext creates or updates the OperatorContext generated for the
+// organisation orgID, along with the Secret holding the access key of its
+// dashboard user. It returns the id of the user. The OperatorContext generated
+// under a previous name is deleted.
+func (r *TykOrganisationReconciler) operatorContext(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.TykOrganisation,
+	orgID string,
+) (string, error) {
+	ctx, span := tracing.Start(ctx, "TykOrganisation.operatorContext")
+	defer span.End()
+
+	gen := desired.Spec.OperatorContext
+	previous := desired.Status.OperatorContext
+
+	if gen == nil {
+		return "", r.deleteOperatorContext(ctx, desired, previous)
+	}
+
+	userID := desired.Status.UserID
+
+	key, err := r.accessKey(ctx, desired)
+	if err != nil {
+		return userID, err
+	}
+
+	// The Secret of a user created by a previous reconciliation may have failed
+	// to be written or been deleted since. Recover the access key of that user
+	// rather than creating another admin user.
+	if key == "" && userID != "" {
+		key, err = userAccessKey(ctx, userID)
+		if err != nil {
+			return userID, err
+		}
+	}
+
+	if key == "" {
+		r.Log.Info("Creating dashboard user of the generated OperatorContext", "email", gen.UserEmailAddress)
+
+		user, err := klient.Universal.Organisations().CreateUser(ctx, &model.DashboardUser{
+			OrgID:           orgID,
+			FirstName:       "Tyk",
+			LastName:        "Operator",
+			EmailAddress:    gen.UserEmailAddress,
+			Active:          true,
+			UserPermissions: map[string]string{"IsAdmin": "admin"},
+		})
+		if err != nil {
+			return userID, err
+		}
+
+		userID, key = user.ID, user.AccessKey
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: gen.Name, Namespace: desired.Namespace},
+	}
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		// Never overwrite a Secret the TykOrganisation did not create.
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, desired) {
+			return fmt.Errorf("secret %s/%s is not owned by the TykOrganisation", secret.Namespace, secret.Name)
+		}
+
+		if err := util.SetControllerReference(desired, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		secret.Type = v1.SecretTypeOpaque
+		secret.Data = map[string][]byte{tykv1alpha1.TykAuth: []byte(key)}
+
+		return nil
+	})
+	if err != nil {
+		return userID, err
+	}
+
+	opCtx := &tykv1alpha1.OperatorContext{
+		ObjectMeta: metav1.ObjectMeta{Name: gen.Name, Namespace: desired.Namespace},
+	}
+
+	_, err = util.CreateOrUpdate(ctx, r.Client, opCtx, func() error {
+		if opCtx.ResourceVersion != "" && !metav1.IsControlledBy(opCtx, desired) {
+			return fmt.Errorf("OperatorContext %s/%s is not owned by the TykOrganisation", opCtx.Namespace, opCtx.Name)
+		}
+
+		if err := util.SetControllerReference(desired, opCtx, r.Scheme); err != nil {
+			return err
+		}
+
+		inheritShard(opCtx, desired)
+
+		namespace := desired.Namespace
+		opCtx.Spec = tykv1alpha1.OperatorContextSpec{
+			FromSecret: &model.Target{Name: secret.Name, Namespace: &namespace},
+			Env:        generatedEnv(env, contextNamespace(desired), orgID),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return userID, err
+	}
+
+	if previous != gen.Name {
+		return userID, r.deleteOperatorContext(ctx, desired, previous)
+	}
+
+	return userID, nil
+}
+
+// generatedEnv returns the environment of the OperatorContext generated for the
+// organisation orgID, reaching the dashboard of env. Secrets referenced by env
+// are looked up in namespace.
+func generatedEnv(env environment.Env, namespace, orgID string) *tykv1alpha1.Environment {
+	e := &tykv1alpha1.Environment{
+		Mode:               env.Mode,
+		URL:                env.URL,
+		Org:                orgID,
+		InsecureSkipVerify: env.InsecureSkipVerify,
+		ProxyURL:           env.ProxyURL,
+		Timeouts:           env.Timeouts,
+	}
+
+	if env.TLS != nil {
+		e.TLS = &tykv1alpha1.TLS{
+			CASecretRef:         inNamespace(env.TLS.CASecretRef, namespace),
+			ClientCertSecretRef: inNamespace(env.TLS.ClientCertSecretRef, namespace),
+		}
+	}
+
+	return e
+}
+
+// inNamespace returns a copy of ref whose namespace defaults to namespace.
+func inNamespace(ref *model.Target, namespace string) *model.Target {
+	if ref == nil {
+		return nil
+	}
+
+	key := ref.NS(namespace)
+
+	return &model.Target{Name: key.Name, Namespace: &key.Namespace}
+}
+
+// accessKey returns the access key of the dashboard user of the generated
+// OperatorContext, read from the Secret created along with it. It returns an
+// empty key if there is no such Secret.
+func (r *TykOrganisationReconciler) accessKey(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOrganisation,
+) (string, error) {
+	name := desired.Status.OperatorContext
+	if name == "" {
+		return "", nil
+	}
+
+	var secret v1.Secret
+
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: desired.Namespace}, &secret)
+
+	switch {
+	case apierrors.IsNotFound(err):
+		r.Log.Info("Secret of the generated OperatorContext not found, looking up its user", "name", name)
+		return "", nil
+	case err != nil:
+		return "", err
+	case !metav1.IsControlledBy(&secret, desired):
+		return "", nil
+	}
+
+	return string(secret.Data[tykv1alpha1.TykAuth]), nil
+}
+
+// userAccessKey returns the access key of the dashboard user id. It returns an
+// empty key if the user no longer exists.
+func userAccessKey(ctx context.Context, id string) (string, error) {
+	user, err := klient.Universal.Organisations().GetUser(ctx, id)
+
+	switch {
+	case tykClient.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+
+	return user.AccessKey, nil
+}
+
+// deleteOperatorContext deletes the OperatorContext generated under name and
+// its Secret.
+func (r *TykOrganisationReconciler) deleteOperatorContext(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOrganisation,
+	name string,
+) error {
+	if name == "" {
+		return nil
+	}
+
+	meta := metav1.ObjectMeta{Name: name, Namespace: desired.Namespace}
+
+	for _, o := range []client.Object{&tykv1alpha1.OperatorContext{ObjectMeta: meta}, &v1.Secret{ObjectMeta: meta}} {
+		if err := r.Delete(ctx, o); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delete deletes the organisation of desired from the dashboard once no
+// resource is reconciled with its OperatorContext. paused is the reason its
+// reconciliation is paused, empty if it is not, the organisation is then left
+// on Tyk.
+func (r *TykOrganisationReconciler) delete(
+	ctx context.Context,
+	env environment.Env,
+	desired *tykv1alpha1.TykOrganisation,
+	paused string,
+) (time.Duration, error) {
+	ctx, span := tracing.Start(ctx, "TykOrganisation.delete")
+	defer span.End()
+
+	r.Log.Info("TykOrganisation being deleted",
+		"TykOrganisation", client.ObjectKeyFromObject(desired).String(),
+	)
+
+	if !util.ContainsFinalizer(desired, keys.TykOrganisationFinalizerName) {
+		return 0, nil
+	}
+
+	// Resources reconciled with the generated OperatorContext must be deleted
+	// before the organisation they belong to.
+	if name := desired.Status.OperatorContext; name != "" {
+		var opCtx tykv1alpha1.OperatorContext
+
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: desired.Namespace}, &opCtx)
+
+		switch {
+		case err == nil && isInUse(&opCtx.Status):
+			r.Log.Error(ErrOperatorContextIsStillInUse, "Cannot delete TykOrganisation", "OperatorContext", name)
+			return queueAfter, ErrOperatorContextIsStillInUse
+		case err != nil && !apierrors.IsNotFound(err):
+			return queueAfter, err
+		}
+	}
+
+	switch {
+	case desired.Status.OrgID == "":
+	case paused != "":
+		leaveOnTyk(r.Recorder, r.Log, desired, paused)
+	default:
+		adminCtx, err := r.adminContext(ctx, env, desired)
+		if err != nil {
+			return queueAfter, err
+		}
+
+		err = klient.Universal.Organisations().Delete(adminCtx, desired.Status.OrgID)
+		if tykClient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "Failed to delete TykOrganisation", "id", desired.Status.OrgID)
+			return queueAfter, err
+		}
+
+		recordDeleted(r.Recorder, desired, desired.Status.OrgID)
+	}
+
+	util.RemoveFinalizer(desired, keys.TykOrganisationFinalizerName)
+
+	return 0, nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of
+// desired. org is the organisation sent to the dashboard, nil if it was not.
+func (r *TykOrganisationReconciler) updateStatus(
+	ctx context.Context,
+	desired *tykv1alpha1.TykOrganisation,
+	org *model.Organisation,
+	userID string,
+	err error,
+) error {
+	status := &desired.Status
+
+	status.LatestTransaction = tykv1alpha1.TransactionInfo{
+		Time:   metav1.Now(),
+		Status: tykv1alpha1.Successful,
+	}
+
+	if err != nil {
+		status.LatestTransaction.Status = tykv1alpha1.Failed
+		status.LatestTransaction.Error = err.Error()
+	}
+
+	if org != nil && org.ID != "" {
+		status.OrgID = org.ID
+
+		if err == nil {
+			status.LatestCRDSpecHash = calculateHash(org)
+		}
+	}
+
+	if userID != "" {
+		status.UserID = userID
+	}
+
+	if err == nil {
+		status.OperatorContext = ""
+		status.UserID = ""
+
+		if gen := desired.Spec.OperatorContext; gen != nil {
+			status.OperatorContext = gen.Name
+			status.UserID = userID
+		}
+	}
+
+	recordSync(r.Recorder, desired, status.Conditions, err)
+	setConditions(&status.Conditions, &status.ObservedGeneration, desired.Generation, err)
+
+	return r.Status().Update(ctx, desired)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TykOrganisationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&tykv1alpha1.TykOrganisation{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pauseToggled)),
+		).
+		Owns(&tykv1alpha1.OperatorContext{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&v1.Secret{}).
+		Watches(
+			&source.Kind{Type: &tykv1alpha1.OperatorContext{}},
+			linkedResources(func(s *tykv1alpha1.OperatorContextStatus) []model.Target {
+				return s.LinkedTykOrganisations
+			}),
+			builder.WithPredicates(contextUpdated),
+		).
+		Complete(tracing.Reconciler("TykOrganisation", r))
+}
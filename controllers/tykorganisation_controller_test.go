@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/matryer/is"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// organisationFixture returns a TykOrganisation generating an OperatorContext,
+// created through the admin OperatorContext of the dashboard s, and a client
+// holding them along with objs.
+func organisationFixture(
+	t *testing.T,
+	s *fake.Server,
+	objs ...runtime.Object,
+) (*tykv1alpha1.TykOrganisation, client.Client) {
+	t.Helper()
+
+	env := s.Env().Environment
+	env.AdminSecretRef = &model.Target{Name: "tyk-admin"}
+
+	namespace := "default"
+	admin := &tykv1alpha1.OperatorContext{
+		ObjectMeta: v1.ObjectMeta{Name: "admin", Namespace: namespace},
+		Spec:       tykv1alpha1.OperatorContextSpec{Env: &env},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "tyk-admin", Namespace: namespace},
+		Data:       map[string][]byte{adminSecretKey: []byte(fake.AdminSecret)},
+	}
+	org := &tykv1alpha1.TykOrganisation{
+		ObjectMeta: v1.ObjectMeta{Name: "acme", Namespace: "tenants"},
+		Spec: tykv1alpha1.TykOrganisationSpec{
+			Context:   &model.Target{Name: "admin", Namespace: &namespace},
+			OwnerName: "Acme",
+			OperatorContext: &tykv1alpha1.GeneratedOperatorContext{
+				Name:             "acme",
+				UserEmailAddress: "operator@acme.com",
+			},
+		},
+	}
+
+	cl, err := NewFakeClient(append([]runtime.Object{admin, secret, org}, objs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return org, cl
+}
+
+func TestTykOrganisationReconcile(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	org, cl := organisationFixture(t, s)
+
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(org)
+
+	r := &TykOrganisationReconciler{Client: cl, Log: log.NullLogger{}, Scheme: cl.Scheme(), Env: s.Env()}
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	is.NoErr(err)
+	is.NoErr(cl.Get(ctx, key, org))
+
+	is.Equal(org.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+	is.Equal(s.Organisations()[org.Status.OrgID].OwnerName, "Acme")
+	is.Equal(org.Status.OperatorContext, "acme")
+
+	user := s.Users()[org.Status.UserID]
+	is.Equal(user.OrgID, org.Status.OrgID)
+	is.Equal(user.EmailAddress, "operator@acme.com")
+
+	// the generated OperatorContext reaches the dashboard as the new user
+	generated, err := GetContext(ctx, org.Namespace, cl, &model.Target{Name: "acme"}, log.NullLogger{})
+	is.NoErr(err)
+	is.Equal(generated.Spec.Env.URL, s.URL)
+	is.Equal(generated.Spec.Env.Org, org.Status.OrgID)
+	is.Equal(generated.Spec.Env.Auth, user.AccessKey)
+	is.True(v1.IsControlledBy(generated, org))
+
+	// renaming the generated OperatorContext keeps the user
+	org.Spec.OwnerName = "Acme Corp"
+	org.Spec.OperatorContext.Name = "acme-corp"
+	is.NoErr(cl.Update(ctx, org))
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	is.NoErr(err)
+	is.NoErr(cl.Get(ctx, key, org))
+
+	is.Equal(org.Status.LatestTransaction.Status, tykv1alpha1.Successful)
+	is.Equal(s.Organisations()[org.Status.OrgID].OwnerName, "Acme Corp")
+	is.Equal(org.Status.OperatorContext, "acme-corp")
+	is.Equal(len(s.Users()), 1)
+
+	err = cl.Get(ctx, types.NamespacedName{Name: "acme", Namespace: org.Namespace}, &tykv1alpha1.OperatorContext{})
+	is.True(err != nil)
+
+	generated, err = GetContext(ctx, org.Namespace, cl, &model.Target{Name: "acme-corp"}, log.NullLogger{})
+	is.NoErr(err)
+	is.Equal(generated.Spec.Env.Auth, user.AccessKey)
+
+	is.NoErr(cl.Delete(ctx, org))
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	is.NoErr(err)
+	is.Equal(len(s.Organisations()), 0)
+}
+
+func TestTykOrganisationMissingAdminSecret(t *testing.T) {
+	is := is.New(t)
+
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	org := &tykv1alpha1.TykOrganisation{
+		ObjectMeta: v1.ObjectMeta{Name: "acme", Namespace: "default"},
+		Spec:       tykv1alpha1.TykOrganisationSpec{OwnerName: "Acme"},
+	}
+
+	cl, err := NewFakeClient([]runtime.Object{org})
+	is.NoErr(err)
+
+	r := &TykOrganisationReconciler{Client: cl, Log: log.NullLogger{}, Scheme: cl.Scheme(), Env: s.Env()}
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(org)})
+	is.Equal(err, ErrMissingAdminSecret)
+
+	is.NoErr(cl.Get(context.Background(), client.ObjectKeyFromObject(org), org))
+	is.Equal(org.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+	is.Equal(len(s.Organisations()), 0)
+}
+
+func TestTykOrganisationLostSecret(t *testing.T) {
+	// A Secret owned by someone else fails the write of the generated one.
+	foreign := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "acme", Namespace: "tenants"}}
+
+	t.Run("write failed", func(t *testing.T) {
+		is := is.New(t)
+
+		s := fake.NewDashboard()
+		defer s.Close()
+
+		org, cl := organisationFixture(t, s, foreign.DeepCopy())
+		r := &TykOrganisationReconciler{Client: cl, Log: log.NullLogger{}, Scheme: cl.Scheme(), Env: s.Env()}
+		ctx := context.Background()
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(org)}
+
+		_, err := r.Reconcile(ctx, req)
+		is.True(err != nil)
+		is.NoErr(cl.Get(ctx, req.NamespacedName, org))
+		is.Equal(org.Status.LatestTransaction.Status, tykv1alpha1.Failed)
+		is.Equal(len(s.Users()), 1)
+
+		user := s.Users()[org.Status.UserID]
+
+		is.NoErr(cl.Delete(ctx, foreign.DeepCopy()))
+
+		_, err = r.Reconcile(ctx, req)
+		is.NoErr(err)
+		is.NoErr(cl.Get(ctx, req.NamespacedName, org))
+
+		// the user created by the failed reconciliation is reused
+		is.Equal(len(s.Users()), 1)
+		is.Equal(org.Status.UserID, user.ID)
+
+		generated, err := GetContext(ctx, org.Namespace, cl, &model.Target{Name: "acme"}, log.NullLogger{})
+		is.NoErr(err)
+		is.Equal(generated.Spec.Env.Auth, user.AccessKey)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		is := is.New(t)
+
+		s := fake.NewDashboard()
+		defer s.Close()
+
+		org, cl := organisationFixture(t, s)
+		r := &TykOrganisationReconciler{Client: cl, Log: log.NullLogger{}, Scheme: cl.Scheme(), Env: s.Env()}
+		ctx := context.Background()
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(org)}
+
+		_, err := r.Reconcile(ctx, req)
+		is.NoErr(err)
+		is.NoErr(cl.Get(ctx, req.NamespacedName, org))
+
+		user := s.Users()[org.Status.UserID]
+
+		is.NoErr(cl.Delete(ctx, foreign.DeepCopy()))
+
+		_, err = r.Reconcile(ctx, req)
+		is.NoErr(err)
+		is.Equal(len(s.Users()), 1)
+
+		generated, err := GetContext(ctx, org.Namespace, cl, &model.Target{Name: "acme"}, log.NullLogger{})
+		is.NoErr(err)
+		is.Equal(generated.Spec.Env.Auth, user.AccessKey)
+	})
+}
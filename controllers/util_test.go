@@ -3,12 +3,17 @@ package controllers
 import (
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func NewFakeClient(objs []runtime.Object) (client.Client, error) {
 	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
 	if err := v1alpha1.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
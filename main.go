@@ -23,22 +23,31 @@ import (
 	"os"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	tykv1alpha1 "github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"github.com/TykTechnologies/tyk-operator/controllers"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/dryrun"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
 	"github.com/TykTechnologies/tyk-operator/pkg/snapshot"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
+// leaderElectionID is the leader election id of the operator when the config
+// file sets none.
+const leaderElectionID = "91ad8c6e.tyk.io"
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -78,21 +87,36 @@ func init() {
 
 func main() {
 	var configFile string
+	var dryRun bool
+	var shardSelector string
 	var env environment.Env
+	var tracingConfig tracing.Config
 	var err error
 
 	flag.StringVar(&configFile, "config", "",
 		"The controller will load its initial configuration from this file. "+
 			"Omit this flag to use the default configuration values. "+
 			"Command-line flags override configuration from this file.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Record the changes the operator would make to Tyk instead of making them. "+
+			"It can also be enabled with the "+tykv1alpha1.TykDryRun+" env var.")
+	flag.StringVar(&shardSelector, "shard-selector", "",
+		"Label selector of the resources managed by this shard of the operator, eg tyk.io/shard=a. "+
+			"It overrides the "+tykv1alpha1.TykShardSelector+" env var.")
 
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
+	tracingConfig.BindFlags(flag.CommandLine)
 	flag.Parse()
 	ctrl.SetLogger(zap.New(zap.UseDevMode(false)))
 	env.Parse()
+	env.DryRun = env.DryRun || dryRun
+
+	if shardSelector != "" {
+		env.ShardSelector = shardSelector
+	}
 
 	runSnapshot := apiDefFileFlag != "" || policyFileFlag != "" || separateFileFlag
 	if runSnapshot {
@@ -135,16 +159,84 @@ func main() {
 		options.NewCache = cache.MultiNamespacedCacheBuilder(strings.Split(env.Namespace, ","))
 	}
 
+	// A shard only caches the resources matching its selector, and elects its
+	// own leader.
+	var shard labels.Selector
+
+	if env.ShardSelector != "" {
+		shard, err = labels.Parse(env.ShardSelector)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the shard selector")
+			os.Exit(1)
+		}
+
+		setupLog.Info("managing the resources of a single shard", "selector", shard.String())
+
+		selectors := controllers.ShardSelectors(shard)
+		if options.NewCache == nil {
+			options.NewCache = cache.BuilderWithOptions(cache.Options{SelectorsByObject: selectors})
+		} else {
+			options.NewCache = controllers.ShardedCache(options.NewCache, selectors)
+		}
+
+		if options.LeaderElection {
+			if options.LeaderElectionID == "" {
+				options.LeaderElectionID = leaderElectionID
+			}
+
+			options.LeaderElectionID = controllers.ShardLeaderElectionID(options.LeaderElectionID, shard)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// A dry run still writes the finalizers and statuses of the resources, it
+	// replaces the operator applying the changes and shares its leader election.
+	if env.DryRun {
+		setupLog.Info("running in dry run mode, changes to Tyk are recorded as events and not applied")
+
+		klient.DryRunRecorder = dryrun.NewRecorder(mgr.GetEventRecorderFor("dry-run"))
+	}
+
+	// The objects created on Tyk are stamped with the identity of the operator,
+	// so that clusters sharing a Tyk only collect their own orphans.
+	if env.OperatorID == "" {
+		env.OperatorID, err = controllers.ClusterID(context.Background(), mgr.GetAPIReader())
+		if err != nil {
+			setupLog.Error(err, "unable to identify the cluster, orphaned objects are not collected",
+				"env", tykv1alpha1.TykOperatorID)
+		}
+	}
+
+	// The resources of other shards are read uncached, to report references to
+	// them.
+	cl := mgr.GetClient()
+
+	var reader client.Reader
+
+	if shard != nil {
+		reader = mgr.GetAPIReader()
+		cl = controllers.NewShardClient(cl, reader, shard)
+	}
+
+	if env.DryRun {
+		cl = controllers.NewDryRunClient(cl)
+	}
+
 	a := ctrl.Log.WithName("controllers").WithName("ApiDefinition")
 
 	if err = (&controllers.ApiDefinitionReconciler{
-		Client:   mgr.GetClient(),
+		Client:   cl,
 		Log:      a,
 		Scheme:   mgr.GetScheme(),
 		Env:      env,
@@ -157,7 +249,7 @@ func main() {
 	il := ctrl.Log.WithName("controllers").WithName("Ingress")
 
 	if err = (&controllers.IngressReconciler{
-		Client:   mgr.GetClient(),
+		Client:   cl,
 		Log:      il,
 		Scheme:   mgr.GetScheme(),
 		Env:      env,
@@ -170,10 +262,12 @@ func main() {
 	sl := ctrl.Log.WithName("controllers").WithName("SecretCert")
 
 	if err = (&controllers.SecretCertReconciler{
-		Client: mgr.GetClient(),
-		Log:    sl,
-		Scheme: mgr.GetScheme(),
-		Env:    env,
+		Client:   cl,
+		Log:      sl,
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("secretcert-controller"),
+		Shard:    shard,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SecretCert")
 		os.Exit(1)
@@ -182,10 +276,11 @@ func main() {
 	spg := ctrl.Log.WithName("controllers").WithName("SuperGraph")
 
 	if err = (&controllers.SuperGraphReconciler{
-		Client: mgr.GetClient(),
-		Log:    spg,
-		Scheme: mgr.GetScheme(),
-		Env:    env,
+		Client:   cl,
+		Log:      spg,
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("supergraph-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SuperGraph")
 		os.Exit(1)
@@ -194,7 +289,7 @@ func main() {
 	sp := ctrl.Log.WithName("controllers").WithName("SecurityPolicy")
 
 	if err = (&controllers.SecurityPolicyReconciler{
-		Client:   mgr.GetClient(),
+		Client:   cl,
 		Log:      sp,
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("securitypolicy-controller"),
@@ -217,52 +312,121 @@ func main() {
 	}
 
 	if err = (&controllers.APIDescriptionReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("APIDescription"),
-		Scheme: mgr.GetScheme(),
-		Env:    env,
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("APIDescription"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("apidescription-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "APIDescription")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.PortalAPICatalogueReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("PortalAPICatalogue"),
-		Scheme: mgr.GetScheme(),
-		Env:    env,
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("PortalAPICatalogue"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("portalapicatalogue-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PortalAPICatalogue")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.PortalConfigReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("PortalConfig"),
-		Scheme: mgr.GetScheme(),
-		Env:    env,
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("PortalConfig"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("portalconfig-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PortalConfig")
 		os.Exit(1)
 	}
 	if err = (&controllers.OperatorContextReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("OperatorContext"),
+		Client:   cl,
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("OperatorContext"),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("operatorcontext-controller"),
+		Shard:    shard,
+		Reader:   reader,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OperatorContext")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.SubGraphReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("SubGraph"),
-		Env:    env,
+		Client:   cl,
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("SubGraph"),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("subgraph-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SubGraph")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TykOasApiDefinitionReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("TykOasApiDefinition"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("tykoasapidefinition-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TykOasApiDefinition")
+		os.Exit(1)
+	}
+	if err = (&controllers.ApiKeyReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("ApiKey"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("apikey-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApiKey")
+		os.Exit(1)
+	}
+	if err = (&controllers.DashboardUserGroupReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("DashboardUserGroup"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("dashboardusergroup-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DashboardUserGroup")
+		os.Exit(1)
+	}
+	if err = (&controllers.DashboardUserReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("DashboardUser"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("dashboarduser-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DashboardUser")
+		os.Exit(1)
+	}
+	if err = (&controllers.TykOrganisationReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("TykOrganisation"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("tykorganisation-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TykOrganisation")
+		os.Exit(1)
+	}
+	if err = (&controllers.ApiEventWebhookReconciler{
+		Client:   cl,
+		Log:      ctrl.Log.WithName("controllers").WithName("ApiEventWebhook"),
+		Scheme:   mgr.GetScheme(),
+		Env:      env,
+		Recorder: mgr.GetEventRecorderFor("apieventwebhook-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApiEventWebhook")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -281,4 +445,8 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
 }
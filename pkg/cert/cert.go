@@ -2,11 +2,16 @@ package cert
 
 import (
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/pem"
 	"errors"
 )
 
+// fingerprintLen is the length of the hex encoded SHA256 fingerprint ending
+// the ID of certificates stored on Tyk.
+const fingerprintLen = sha256.Size * 2
+
 var ErrInvalidCert = errors.New("certificate is invalid")
 
 func HexSHA256(cert []byte) string {
@@ -22,3 +27,30 @@ func CalculateFingerPrint(data []byte) (string, error) {
 
 	return "", ErrInvalidCert
 }
+
+// FingerprintFromID returns the fingerprint of the certificate whose Tyk ID is
+// id, which is the organisation followed by the fingerprint.
+func FingerprintFromID(id string) string {
+	if len(id) < fingerprintLen {
+		return ""
+	}
+
+	return id[len(id)-fingerprintLen:]
+}
+
+// Parse returns the first certificate of the PEM encoded data, which may also
+// hold a private key.
+func Parse(data []byte) (*x509.Certificate, error) {
+	for rest := data; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, ErrInvalidCert
+		}
+
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -84,3 +84,30 @@ func generateTestCertificate() ([]byte, []byte, error) {
 
 	return certPem, keyPEM, nil
 }
+
+func TestParse(t *testing.T) {
+	is := is.New(t)
+
+	testCert, testKey, err := generateTestCertificate()
+	is.NoErr(err)
+
+	c, err := cert.Parse(append(testKey, testCert...))
+	is.NoErr(err)
+	is.Equal(c.Subject.CommonName, "localhost")
+
+	_, err = cert.Parse(testKey)
+	is.Equal(err, cert.ErrInvalidCert)
+}
+
+func TestFingerprintFromID(t *testing.T) {
+	is := is.New(t)
+
+	testCert, _, err := generateTestCertificate()
+	is.NoErr(err)
+
+	fingerprint, err := cert.CalculateFingerPrint(testCert)
+	is.NoErr(err)
+
+	is.Equal(cert.FingerprintFromID("5e9d9544a1dcd60001d0ed20"+fingerprint), fingerprint)
+	is.Equal(cert.FingerprintFromID("short"), "")
+}
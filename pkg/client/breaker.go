@@ -0,0 +1,131 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when calls to a Tyk endpoint are short-circuited
+// because it kept failing.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned by Call instead of contacting Tyk while the
+// circuit breaker for the target URL is open.
+type CircuitOpenError struct {
+	// URL is the base URL of the Tyk Gateway or Dashboard.
+	URL string
+
+	// Until is the time at which a probe request will be let through again.
+	Until time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v for %s until %s", ErrCircuitOpen, e.URL, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// IsCircuitOpen returns true if err was caused by an open circuit breaker.
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
+
+// BreakerPolicy controls when the circuit breaker of a Tyk URL opens and for how
+// long.
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failed calls that opens the
+	// circuit.
+	FailureThreshold int
+
+	// OpenTimeout is how long the circuit stays open before a single probe
+	// request is allowed through.
+	OpenTimeout time.Duration
+}
+
+// DefaultBreakerPolicy is the policy used for every Tyk URL.
+var DefaultBreakerPolicy = BreakerPolicy{
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+// breaker is a circuit breaker guarding a single Tyk URL. It is shared by all
+// reconcilers talking to that URL.
+type breaker struct {
+	mu       sync.Mutex
+	url      string
+	failures int
+	until    time.Time
+	probing  bool
+}
+
+var breakers = struct {
+	sync.Mutex
+	m map[string]*breaker
+}{m: make(map[string]*breaker)}
+
+// breakerFor returns the circuit breaker of the OperatorContext URL u.
+func breakerFor(u string) *breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	b, ok := breakers.m[u]
+	if !ok {
+		b = &breaker{url: u}
+		breakers.m[u] = b
+	}
+
+	return b
+}
+
+// allow returns a *CircuitOpenError if no call should be made right now.
+// Once the open timeout elapsed, only one probe is allowed at a time until it
+// reports its outcome.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < DefaultBreakerPolicy.FailureThreshold {
+		return nil
+	}
+
+	if time.Now().Before(b.until) || b.probing {
+		return &CircuitOpenError{URL: b.url, Until: b.until}
+	}
+
+	b.probing = true
+
+	return nil
+}
+
+// done records the outcome of a call that was allowed through.
+func (b *breaker) done(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	if b.failures >= DefaultBreakerPolicy.FailureThreshold {
+		b.until = time.Now().Add(DefaultBreakerPolicy.OpenTimeout)
+	}
+}
+
+// release lets another probe through without recording an outcome, for calls
+// that were allowed through but whose outcome says nothing about Tyk, such as
+// calls cancelled by their context.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
@@ -3,24 +3,26 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"time"
 
-	errors2 "github.com/pkg/errors"
+	"github.com/cenkalti/backoff/v4"
 
 	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/TykTechnologies/tyk-operator/pkg/tracing"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
@@ -36,6 +38,14 @@ var (
 	ErrMissingAPIID = errors.New("Missing API ID")
 
 	ErrMissingPolicyID = errors.New("Missing Policy ID")
+
+	// ErrMissingAdminAuth is returned by calls to the admin API of the dashboard
+	// when the context has no admin secret.
+	ErrMissingAdminAuth = errors.New("Missing dashboard admin secret")
+
+	// ErrHotReloadPending is returned by HotReloadResult while the batched hot
+	// reload requested for a resource is not done yet.
+	ErrHotReloadPending = errors.New("Hot reload is pending")
 )
 
 func IsTODO(err error) bool {
@@ -56,25 +66,12 @@ func IgnoreNotFound(err error) error {
 	return nil
 }
 
+// client is used by calls whose Context has no HTTPClient.
 var client = &http.Client{}
 
 func init() {
 	if os.Getenv(v1alpha1.SkipVerify) == "true" {
-		client.Transport = &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		}
+		client, _ = NewHTTPClient(TransportConfig{InsecureSkipVerify: true}) //nolint:errcheck
 	}
 }
 
@@ -92,6 +89,27 @@ type Context struct {
 	Log           logr.Logger
 	BeforeRequest func(*http.Request)
 	Do            func(*http.Request) (*http.Response, error)
+
+	// HTTPClient sends requests when Do is nil. It defaults to a client
+	// shared by all contexts.
+	HTTPClient *http.Client
+
+	// AdminAuth is the admin secret of the dashboard. It is only sent by calls
+	// to the admin API.
+	AdminAuth string
+
+	// ReadAuth re-reads the credentials of Env. When set, a call rejected as
+	// unauthorized is sent once more with the credentials it returns, if they
+	// changed.
+	ReadAuth func(context.Context) (string, error)
+
+	// Nodes are the URLs of the gateways that calls applied to several gateways
+	// are sent to, see EachNode. Other calls are sent to Env.URL.
+	Nodes []string
+
+	// Object is the resource reconciled with the context, if any. In dry run
+	// mode, the changes to Tyk are recorded as its events.
+	Object runtime.Object
 }
 
 type contextKey struct{}
@@ -164,10 +182,197 @@ func Delete(ctx context.Context, url string, body io.Reader, fn ...func(*http.Re
 	return Call(ctx, http.MethodDelete, url, body, fn...)
 }
 
+// Call sends a request to the Tyk Gateway or Dashboard configured in ctx.
+//
+// Idempotent requests (GET, PUT, DELETE) that fail with a connection error, a
+// 5xx or a 429 are retried following DefaultRetryPolicy, honoring Retry-After.
+// Calls to a URL that kept failing are short-circuited with a
+// *CircuitOpenError until the breaker lets a probe through.
+//
+// When tracing is enabled, every Call is recorded in a client span whose trace
+// context is propagated to Tyk.
 func Call(ctx context.Context, method, url string, body io.Reader, fn ...func(*http.Request)) (*http.Response, error) {
+	endpoint := endpointTemplate(url)
+
+	ctx, span := tracing.StartClient(ctx, method+" "+endpoint,
+		semconv.HTTPMethodKey.String(method),
+		attribute.String("tyk.endpoint", endpoint),
+		attribute.String("tyk.mode", string(GetContext(ctx).Env.Mode)),
+	)
+
+	rctx := GetContext(ctx)
+
+	// Buffer the body so that it can be sent again with new credentials.
+	var payload []byte
+
+	if rctx.ReadAuth != nil && body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, err
+		}
+
+		payload = b
+		body = bytes.NewReader(payload)
+	}
+
+	res, err := call(ctx, method, endpoint, url, body, fn...)
+
+	if IsUnauthorized(err) && reauthenticate(ctx, &rctx) {
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		res, err = call(SetContext(ctx, rctx), method, endpoint, url, body, fn...)
+	}
+
+	switch {
+	case res != nil:
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
+	case err != nil:
+		if e, ok := AsTykAPIError(err); ok {
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(e.StatusCode))
+		}
+	}
+
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// reauthenticate reads the credentials of rctx again and reports whether they
+// changed, in which case rctx is updated to use them.
+func reauthenticate(ctx context.Context, rctx *Context) bool {
+	if rctx.ReadAuth == nil {
+		return false
+	}
+
+	auth, err := rctx.ReadAuth(ctx)
+	if err != nil {
+		rctx.Log.Error(err, "Failed to read credentials again")
+		return false
+	}
+
+	if auth == "" || auth == rctx.Env.Auth {
+		return false
+	}
+
+	rctx.Log.Info("Credentials changed, sending the request again")
+	rctx.Env.Auth = auth
+
+	return true
+}
+
+// call implements Call for the endpoint template of url.
+func call(
+	ctx context.Context,
+	method, endpoint, url string,
+	body io.Reader,
+	fn ...func(*http.Request),
+) (*http.Response, error) {
 	rctx := GetContext(ctx)
+	cb := breakerFor(rctx.Env.URL)
 	url = JoinURL(rctx.Env.URL, url)
 
+	if err := cb.allow(); err != nil {
+		rctx.Log.Info("Call", "Method", method, "URL", redactURL(url), "Status", err.Error())
+		observeCircuitOpen(rctx, endpoint, method)
+
+		return nil, err
+	}
+
+	retry := isIdempotent(method)
+
+	// Buffer the body so that it can be sent again on retries.
+	var payload []byte
+
+	if retry && body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			cb.release()
+			return nil, err
+		}
+
+		payload = b
+	}
+
+	bo := DefaultRetryPolicy.backOff()
+
+	var res *http.Response
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		var r *http.Request
+
+		r, err = newRequest(ctx, method, url, body, fn...)
+		if err != nil {
+			cb.release()
+			return nil, err
+		}
+
+		res, err = do(rctx, endpoint, r)
+		if !retry || !shouldRetry(ctx, res, err) {
+			break
+		}
+
+		wait := bo.NextBackOff()
+		if wait == backoff.Stop {
+			break
+		}
+
+		if d := retryAfter(res); d > wait {
+			wait = d
+		}
+
+		discard(res)
+
+		rctx.Log.Info("Retrying", "Method", method, "URL", redactURL(url), "Attempt", attempt, "After", wait.String())
+
+		if err = sleep(ctx, wait); err != nil {
+			cb.release()
+			return nil, err
+		}
+	}
+
+	if ctx.Err() != nil {
+		cb.release()
+	} else {
+		cb.done(isTransient(res, err))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) > 0 {
+			rctx.Log.Info(http.StatusText(res.StatusCode), "body", string(b))
+		}
+
+		return nil, newTykAPIError(method, endpoint, res, b)
+	}
+
+	return res, err
+}
+
+// newRequest creates the request for a single attempt of a Call.
+func newRequest(
+	ctx context.Context,
+	method, url string,
+	body io.Reader,
+	fn ...func(*http.Request),
+) (*http.Request, error) {
 	r, err := Request(ctx, method, url, body)
 	if err != nil {
 		return nil, err
@@ -177,15 +382,31 @@ func Call(ctx context.Context, method, url string, body io.Reader, fn ...func(*h
 		f(r)
 	}
 
+	tracing.Inject(ctx, r.Header)
+
+	return r, nil
+}
+
+// do sends r, which is a single attempt of a Call to endpoint.
+func do(rctx Context, endpoint string, r *http.Request) (*http.Response, error) {
 	var res *http.Response
-	if rctx.Do != nil {
+	var err error
+
+	start := time.Now()
+
+	switch {
+	case rctx.Do != nil:
 		res, err = rctx.Do(r)
-	} else {
+	case rctx.HTTPClient != nil:
+		res, err = rctx.HTTPClient.Do(r)
+	default:
 		res, err = client.Do(r)
 	}
 
+	observeRequest(rctx, endpoint, r.Method, statusClass(res, err), time.Since(start))
+
 	values := []interface{}{
-		"Method", method, "URL", url,
+		"Method", r.Method, "URL", redactURL(r.URL.String()),
 	}
 
 	if res != nil {
@@ -200,40 +421,31 @@ func Call(ctx context.Context, method, url string, body io.Reader, fn ...func(*h
 
 	rctx.Log.Info("Call", values...)
 
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		defer res.Body.Close()
+	return res, err
+}
 
-		b, err := io.ReadAll(res.Body)
-		if err != nil {
-			return nil, err
-		}
+// redactURL returns url, with the key it names replaced by {key}, to be
+// logged. The ids of keys are the keys themselves.
+func redactURL(url string) string {
+	const keys = "/keys/"
 
-		if len(b) > 0 {
-			rctx.Log.Info(http.StatusText(res.StatusCode), "body", string(b))
-		}
+	i := strings.Index(url, keys)
+	if i == -1 {
+		return url
+	}
 
-		errString := string(b)
-		result := model.Result{}
+	i += len(keys)
 
-		err = json.Unmarshal(b, &result)
-		if err == nil {
-			result.StatusCode = res.StatusCode
-			errString = result.String()
-		}
+	end := strings.IndexAny(url[i:], "/?")
+	if end == -1 {
+		end = len(url) - i
+	}
 
-		switch res.StatusCode {
-		case http.StatusNotFound:
-			return nil, ErrNotFound
-		default:
-			return nil, errors2.Wrap(ErrFailed, errString)
-		}
+	if end == 0 {
+		return url
 	}
 
-	return res, err
+	return url[:i] + "{key}" + url[i+end:]
 }
 
 // AddQuery call back for adding url queries
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallReadAuth(t *testing.T) {
+	const rotated = "rotated"
+
+	var calls int
+
+	var bodies []string
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		b, _ := io.ReadAll(r.Body) //nolint:errcheck
+		bodies = append(bodies, string(b))
+
+		if r.Header.Get("x-tyk-authorization") != rotated {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	withReadAuth := func(auth string) context.Context {
+		rctx := GetContext(testContext(svr.URL))
+		rctx.Env.Auth = "old"
+		rctx.ReadAuth = func(context.Context) (string, error) { return auth, nil }
+
+		return SetContext(context.Background(), rctx)
+	}
+
+	res, err := Call(withReadAuth(rotated), http.MethodPost, "/tyk/apis", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("expected the call to succeed with the new credentials got %v", err)
+	}
+
+	res.Body.Close()
+
+	if calls != 2 {
+		t.Errorf("expected 2 requests got %d", calls)
+	}
+
+	if bodies[0] != "{}" || bodies[1] != "{}" {
+		t.Errorf("expected the body to be sent again got %q", bodies)
+	}
+
+	calls = 0
+
+	_, err = Call(withReadAuth("old"), http.MethodPost, "/tyk/apis", strings.NewReader("{}"))
+	if !IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized error got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected unchanged credentials not to be sent again got %d requests", calls)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "http://tyk/tyk/keys/secret", want: "http://tyk/tyk/keys/{key}"},
+		{url: "http://tyk/tyk/keys/secret?hashed=true", want: "http://tyk/tyk/keys/{key}?hashed=true"},
+		{url: "/api/keys/secret/", want: "/api/keys/{key}/"},
+		{url: "http://tyk/tyk/keys", want: "http://tyk/tyk/keys"},
+		{url: "http://tyk/tyk/keys/", want: "http://tyk/tyk/keys/"},
+		{url: "http://tyk/tyk/apis/ZGVmYXVsdC9odHRwYmlu", want: "http://tyk/tyk/apis/ZGVmYXVsdC9odHRwYmlu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := redactURL(tt.url); got != tt.want {
+				t.Errorf("expected %q got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -93,9 +93,13 @@ func (a Api) Update(ctx context.Context, spec *model.APIDefinitionSpec) (*model.
 func (a Api) update(ctx context.Context, result *model.Result, spec *model.APIDefinitionSpec) (*model.Result, error) {
 	var o model.Result
 
+	octx := client.GetContext(ctx)
+
 	err := client.Data(&o)(client.PutJSON(
 		ctx, client.Join(endpointAPIs, result.Meta), DashboardApi{
-			ApiDefinition: *spec,
+			ApiDefinition:   *spec,
+			UserOwners:      octx.Env.UserOwners,
+			UserGroupOwners: octx.Env.UserGroupOwners,
 		},
 	))
 	if err != nil {
@@ -7,7 +7,10 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/cert"
 	"github.com/TykTechnologies/tyk-operator/pkg/client"
 )
 
@@ -20,7 +23,7 @@ type CertificateList struct {
 
 // All returns a list of all certificates ID's
 func (c Cert) All(ctx context.Context) ([]string, error) {
-	res, err := client.Get(ctx, endpointCerts, nil)
+	res, err := client.Get(ctx, endpointCerts, nil, client.AddQuery(url.Values{"p": {"-2"}}))
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +44,36 @@ func (c Cert) All(ctx context.Context) ([]string, error) {
 	return o.CertIDs, nil
 }
 
+// CertificateMetaList is the detailed list of certificates.
+type CertificateMetaList struct {
+	Certs []model.CertificateMeta `json:"certs"`
+	Pages int                     `json:"pages"`
+}
+
+// Metadata returns the metadata of all certificates, of every page.
+func (c Cert) Metadata(ctx context.Context) ([]model.CertificateMeta, error) {
+	res, err := client.Get(ctx, endpointCerts, nil, client.AddQuery(url.Values{"mode": {"detailed"}, "p": {"-2"}}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var o CertificateMetaList
+
+	if err := client.JSON(res, &o); err != nil {
+		return nil, err
+	}
+
+	for i := range o.Certs {
+		if o.Certs[i].Fingerprint == "" {
+			o.Certs[i].Fingerprint = cert.FingerprintFromID(o.Certs[i].ID)
+		}
+	}
+
+	return o.Certs, nil
+}
+
 func (c Cert) Exists(ctx context.Context, id string) bool {
 	res, err := client.Get(ctx, client.Join(endpointCerts, id), nil)
 	if err != nil {
@@ -37,6 +37,30 @@ func (c Client) Portal() universal.Portal {
 	return Portal{}
 }
 
+func (c Client) OAS() universal.OAS {
+	return OAS{}
+}
+
+func (c Client) Keys() universal.Keys {
+	return Keys{}
+}
+
+func (c Client) Users() universal.Users {
+	return Users{}
+}
+
+func (c Client) UserGroups() universal.UserGroups {
+	return UserGroups{}
+}
+
+func (c Client) Organisations() universal.Organisations {
+	return Organisations{}
+}
+
+func (c Client) Webhooks() universal.Webhooks {
+	return Webhooks{}
+}
+
 func (c Client) Api() universal.Api {
 	return Api{}
 }
@@ -44,3 +68,7 @@ func (c Client) Api() universal.Api {
 func (c Client) HotReload(context.Context) error {
 	return nil
 }
+
+func (c Client) HotReloadResult(context.Context) error {
+	return nil
+}
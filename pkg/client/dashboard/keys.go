@@ -0,0 +1,54 @@
+package dashboard
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const endpointKeys = "/api/keys"
+
+var _ universal.Keys = Keys{}
+
+type Keys struct{}
+
+func (Keys) Create(ctx context.Context, key *model.SessionState) (string, string, error) {
+	var o KeyResponse
+
+	if err := client.Data(&o)(client.PostJSON(ctx, endpointKeys, key)); err != nil {
+		return "", "", err
+	}
+
+	return o.KeyID, o.KeyHash, nil
+}
+
+func (Keys) Get(ctx context.Context, id string) (*model.SessionState, error) {
+	var o KeyResponse
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointKeys, id), nil)); err != nil {
+		return nil, err
+	}
+
+	return &o.Data, nil
+}
+
+func (Keys) Update(ctx context.Context, id string, key *model.SessionState) error {
+	_, err := client.Result(client.PutJSON(ctx, client.Join(endpointKeys, id), key))
+	return err
+}
+
+func (Keys) Delete(ctx context.Context, id string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointKeys, id), nil))
+	return err
+}
+
+func (Keys) DeleteHashed(ctx context.Context, hash string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointKeys, hash), nil,
+		client.AddQuery(url.Values{"hashed": {"true"}}),
+	))
+
+	return err
+}
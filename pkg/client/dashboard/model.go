@@ -32,3 +32,28 @@ type CertResponse struct {
 	Message string `json:"message"`
 	Status  string `json:"status"`
 }
+
+// KeyResponse is returned by the dashboard when a key is created or read.
+type KeyResponse struct {
+	KeyID   string             `json:"key_id"`
+	KeyHash string             `json:"key_hash,omitempty"`
+	Data    model.SessionState `json:"data"`
+}
+
+// UserResponse is returned by the dashboard when a user is created.
+type UserResponse struct {
+	Status  string              `json:"Status"`
+	Message string              `json:"Message"`
+	Meta    model.DashboardUser `json:"Meta"`
+}
+
+// PasswordReset is the body sent to set the password of a user.
+type PasswordReset struct {
+	NewPassword string `json:"new_password"`
+}
+
+// WebhooksResponse is returned by the dashboard when listing webhooks.
+type WebhooksResponse struct {
+	Hooks []model.WebHookHandlerConf `json:"hooks"`
+	Pages int                        `json:"pages"`
+}
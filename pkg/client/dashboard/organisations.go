@@ -0,0 +1,123 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const (
+	endpointOrganisations = "/admin/organisations"
+	endpointAdminUsers    = "/admin/users"
+
+	// XAdminAuthorization is the header holding the admin secret in calls to
+	// the admin API.
+	XAdminAuthorization = "admin-auth"
+)
+
+var _ universal.Organisations = Organisations{}
+
+type Organisations struct{}
+
+// admin returns the option authenticating a call to the admin API. The admin
+// API ignores the authorization header set for every call.
+func admin(ctx context.Context) (func(*http.Request), error) {
+	secret := client.GetContext(ctx).AdminAuth
+	if secret == "" {
+		return nil, client.ErrMissingAdminAuth
+	}
+
+	return func(r *http.Request) {
+		r.Header.Del(XAuthorization)
+		r.Header.Set(XAdminAuthorization, secret)
+	}, nil
+}
+
+func (Organisations) Create(ctx context.Context, org *model.Organisation) (string, error) {
+	auth, err := admin(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Result(client.PostJSON(ctx, endpointOrganisations, org, auth))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Meta, nil
+}
+
+func (Organisations) Get(ctx context.Context, id string) (*model.Organisation, error) {
+	auth, err := admin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var o model.Organisation
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointOrganisations, id), nil, auth)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (Organisations) Update(ctx context.Context, org *model.Organisation) error {
+	auth, err := admin(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Result(client.PutJSON(ctx, client.Join(endpointOrganisations, org.ID), org, auth))
+
+	return err
+}
+
+func (Organisations) Delete(ctx context.Context, id string) error {
+	auth, err := admin(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Result(client.Delete(ctx, client.Join(endpointOrganisations, id), nil, auth))
+
+	return err
+}
+
+func (Organisations) CreateUser(ctx context.Context, user *model.DashboardUser) (*model.DashboardUser, error) {
+	auth, err := admin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var o UserResponse
+
+	if err := client.Data(&o)(client.PostJSON(ctx, endpointAdminUsers, user, auth)); err != nil {
+		return nil, err
+	}
+
+	// The access key is returned in the message of the response.
+	if o.Meta.AccessKey == "" {
+		o.Meta.AccessKey = o.Message
+	}
+
+	return &o.Meta, nil
+}
+
+func (Organisations) GetUser(ctx context.Context, id string) (*model.DashboardUser, error) {
+	auth, err := admin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var o model.DashboardUser
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointAdminUsers, id), nil, auth)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
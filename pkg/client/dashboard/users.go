@@ -0,0 +1,90 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const (
+	endpointUsers      = "/api/users"
+	endpointUserGroups = "/api/usergroups"
+)
+
+var (
+	_ universal.Users      = Users{}
+	_ universal.UserGroups = UserGroups{}
+)
+
+type Users struct{}
+
+func (Users) Create(ctx context.Context, user *model.DashboardUser) (string, error) {
+	var o UserResponse
+
+	if err := client.Data(&o)(client.PostJSON(ctx, endpointUsers, user)); err != nil {
+		return "", err
+	}
+
+	return o.Meta.ID, nil
+}
+
+func (Users) Get(ctx context.Context, id string) (*model.DashboardUser, error) {
+	var o model.DashboardUser
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointUsers, id), nil)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (Users) Update(ctx context.Context, user *model.DashboardUser) error {
+	_, err := client.Result(client.PutJSON(ctx, client.Join(endpointUsers, user.ID), user))
+	return err
+}
+
+func (Users) Delete(ctx context.Context, id string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointUsers, id), nil))
+	return err
+}
+
+func (Users) SetPassword(ctx context.Context, id, password string) error {
+	_, err := client.Result(client.PostJSON(ctx, client.Join(endpointUsers, id, "actions", "reset"),
+		PasswordReset{NewPassword: password},
+	))
+
+	return err
+}
+
+type UserGroups struct{}
+
+func (UserGroups) Create(ctx context.Context, group *model.UserGroup) (string, error) {
+	res, err := client.Result(client.PostJSON(ctx, endpointUserGroups, group))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Meta, nil
+}
+
+func (UserGroups) Get(ctx context.Context, id string) (*model.UserGroup, error) {
+	var o model.UserGroup
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointUserGroups, id), nil)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (UserGroups) Update(ctx context.Context, group *model.UserGroup) error {
+	_, err := client.Result(client.PutJSON(ctx, client.Join(endpointUserGroups, group.ID), group))
+	return err
+}
+
+func (UserGroups) Delete(ctx context.Context, id string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointUserGroups, id), nil))
+	return err
+}
@@ -0,0 +1,67 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const endpointWebhooks = "/api/hooks"
+
+var _ universal.Webhooks = Webhooks{}
+
+type Webhooks struct{}
+
+func (w Webhooks) Create(ctx context.Context, hook *model.WebHookHandlerConf) (string, error) {
+	res, err := client.Result(client.PostJSON(ctx, endpointWebhooks, hook))
+	if err != nil {
+		return "", err
+	}
+
+	if res.Meta != "" {
+		return res.Meta, nil
+	}
+
+	// Some versions of the dashboard do not return the id of the new webhook,
+	// it is looked up by name.
+	return w.find(ctx, hook.Name)
+}
+
+// find returns the id of the webhook called name.
+func (Webhooks) find(ctx context.Context, name string) (string, error) {
+	var o WebhooksResponse
+
+	if err := client.Data(&o)(client.Get(ctx, endpointWebhooks, nil)); err != nil {
+		return "", err
+	}
+
+	for _, hook := range o.Hooks {
+		if hook.Name == name {
+			return hook.ID, nil
+		}
+	}
+
+	return "", client.ErrNotFound
+}
+
+func (Webhooks) Get(ctx context.Context, id string) (*model.WebHookHandlerConf, error) {
+	var o model.WebHookHandlerConf
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointWebhooks, id), nil)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (Webhooks) Update(ctx context.Context, hook *model.WebHookHandlerConf) error {
+	_, err := client.Result(client.PutJSON(ctx, client.Join(endpointWebhooks, hook.ID), hook))
+	return err
+}
+
+func (Webhooks) Delete(ctx context.Context, id string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointWebhooks, id), nil))
+	return err
+}
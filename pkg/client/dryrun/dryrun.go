@@ -0,0 +1,236 @@
+// Package dryrun implements a universal.Client that records the changes it
+// would make to Tyk instead of making them.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+	"github.com/TykTechnologies/tyk-operator/pkg/jsondiff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events recording the changes of a dry run.
+const (
+	ReasonCreate    = "DryRunCreate"
+	ReasonUpdate    = "DryRunUpdate"
+	ReasonDelete    = "DryRunDelete"
+	ReasonHotReload = "DryRunHotReload"
+)
+
+// maxEventDiff is the maximum length of the diff in the message of an event,
+// the whole diff is logged.
+const maxEventDiff = 512
+
+var verbs = map[string]string{
+	ReasonCreate:    "create",
+	ReasonUpdate:    "update",
+	ReasonDelete:    "delete",
+	ReasonHotReload: "reload",
+}
+
+var _ universal.Client = Client{}
+
+// Client sends reads to Client. Creates, updates, deletes and hot reloads are
+// never sent to Tyk: they are logged, with the JSON diff of the change against
+// the current object on Tyk, and recorded with Recorder as events of the Object
+// of the client context.
+type Client struct {
+	Client   universal.Client
+	Recorder *Recorder
+}
+
+// Recorder records the changes of a dry run as events. A resource is
+// reconciled again and again while its changes are not applied, each change
+// is only recorded until the next one of the same object on Tyk.
+type Recorder struct {
+	recorder record.EventRecorder
+
+	mu       sync.Mutex
+	recorded map[changeKey]string
+}
+
+// changeKey identifies the changes of the object what on Tyk recorded as events
+// of a resource.
+type changeKey struct {
+	kind   reflect.Type
+	uid    types.UID
+	key    types.NamespacedName
+	reason string
+	what   string
+}
+
+// NewRecorder returns a Recorder recording events with recorder.
+func NewRecorder(recorder record.EventRecorder) *Recorder {
+	return &Recorder{recorder: recorder, recorded: make(map[changeKey]string)}
+}
+
+// Event records the change of the object what on Tyk, described by message, as
+// an event of o, unless it is the change last recorded for them.
+func (r *Recorder) Event(o runtime.Object, reason, what, message string) {
+	k := changeKey{kind: reflect.TypeOf(o), reason: reason, what: what}
+
+	if m, err := meta.Accessor(o); err == nil {
+		k.uid = m.GetUID()
+		k.key = types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}
+	}
+
+	r.mu.Lock()
+	recorded := r.recorded[k] == message
+	r.recorded[k] = message
+	r.mu.Unlock()
+
+	if !recorded {
+		r.recorder.Event(o, corev1.EventTypeNormal, reason, message)
+	}
+}
+
+func (c Client) HotReload(ctx context.Context) error {
+	c.record(ctx, ReasonHotReload, "the gateways", "")
+	return nil
+}
+
+// HotReloadResult returns nil, no hot reload is ever requested.
+func (c Client) HotReloadResult(ctx context.Context) error {
+	return nil
+}
+
+func (c Client) Api() universal.Api {
+	return Api{c: c, api: c.Client.Api()}
+}
+
+func (c Client) OAS() universal.OAS {
+	return OAS{c: c, oas: c.Client.OAS()}
+}
+
+func (c Client) Keys() universal.Keys {
+	return Keys{c: c, keys: c.Client.Keys()}
+}
+
+func (c Client) Users() universal.Users {
+	return Users{c: c, users: c.Client.Users()}
+}
+
+func (c Client) UserGroups() universal.UserGroups {
+	return UserGroups{c: c, groups: c.Client.UserGroups()}
+}
+
+func (c Client) Organisations() universal.Organisations {
+	return Organisations{c: c, orgs: c.Client.Organisations()}
+}
+
+func (c Client) Webhooks() universal.Webhooks {
+	return Webhooks{c: c, hooks: c.Client.Webhooks()}
+}
+
+func (c Client) Portal() universal.Portal {
+	return Portal{c: c, portal: c.Client.Portal()}
+}
+
+func (c Client) Certificate() universal.Certificate {
+	return Certificate{c: c, cert: c.Client.Certificate()}
+}
+
+// change records the change of the object what on Tyk from current to desired.
+// A nil current is an object missing from Tyk, a nil desired an object deleted.
+func (c Client) change(ctx context.Context, reason, what string, current, desired interface{}) error {
+	all, err := jsondiff.Diff(desired, current)
+	if err != nil {
+		return err
+	}
+
+	// Tyk serves missing fields with their zero value, such changes are noise.
+	changes := []jsondiff.Change{}
+
+	for _, o := range all {
+		if !zero(o.Current) || !zero(o.Desired) {
+			changes = append(changes, o)
+		}
+	}
+
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	c.record(ctx, reason, what, string(diff))
+
+	return nil
+}
+
+// record logs the change of the object what on Tyk, with its JSON diff if any,
+// and records it as an event of the object of the client context.
+func (c Client) record(ctx context.Context, reason, what, diff string) {
+	rctx := client.GetContext(ctx)
+
+	log := rctx.Log.WithValues("reason", reason, "object", what)
+	if diff != "" {
+		log = log.WithValues("diff", diff)
+	}
+
+	log.Info("Dry run, change not sent to Tyk")
+
+	if c.Recorder == nil || rctx.Object == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Would %s %s", verbs[reason], what)
+
+	if diff != "" {
+		if len(diff) > maxEventDiff {
+			diff = strings.ToValidUTF8(diff[:maxEventDiff], "") + "..."
+		}
+
+		message += ": " + diff
+	}
+
+	c.Recorder.Event(rctx.Object, reason, what, message)
+}
+
+// zero returns true if the JSON value v is null or the zero value of its type.
+func zero(v interface{}) bool {
+	switch o := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !o
+	case float64:
+		return o == 0
+	case string:
+		return o == ""
+	case []interface{}:
+		return len(o) == 0
+	case map[string]interface{}:
+		return len(o) == 0
+	}
+
+	return false
+}
+
+// named returns the name of the object kind with id, kind alone when the id is
+// not known yet.
+func named(kind, id string) string {
+	if id == "" {
+		return kind
+	}
+
+	return kind + " " + id
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
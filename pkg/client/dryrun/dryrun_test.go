@@ -0,0 +1,195 @@
+package dryrun_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/dryrun"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/gateway"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+// dryRun returns a dry run client of the gateway s, recording with recorder
+// the events of api, and the context of the calls.
+func dryRun(s *fake.Server, recorder record.EventRecorder, api *v1alpha1.ApiDefinition) (dryrun.Client, context.Context) {
+	ctx := client.SetContext(context.Background(), client.Context{
+		Env:    s.Env(),
+		Log:    logr.Discard(),
+		Object: api,
+	})
+
+	return dryrun.Client{Client: gateway.Client{}, Recorder: dryrun.NewRecorder(recorder)}, ctx
+}
+
+func TestClient(t *testing.T) {
+	s := fake.NewGateway()
+	defer s.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	api := &v1alpha1.ApiDefinition{ObjectMeta: metav1.ObjectMeta{Name: "httpbin", Namespace: "default"}}
+	c, ctx := dryRun(s, recorder, api)
+
+	def := &model.APIDefinitionSpec{Name: "httpbin", APIID: ptr("ZGVmYXVsdC9odHRwYmlu")}
+
+	if _, err := c.Api().Create(ctx, def); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.APIs()) != 0 {
+		t.Fatalf("expected no API on Tyk got %d", len(s.APIs()))
+	}
+
+	expected := `Normal DryRunCreate Would create API ZGVmYXVsdC9odHRwYmlu: ` +
+		`[{"path":"api_id","current":null,"desired":"ZGVmYXVsdC9odHRwYmlu"},` +
+		`{"path":"name","current":null,"desired":"httpbin"}]`
+	if got := <-recorder.Events; got != expected {
+		t.Errorf("expected event %q got %q", expected, got)
+	}
+
+	// The changes are diffed against the object on Tyk, fields only set on Tyk
+	// are left out.
+	if _, err := (gateway.Client{}).Api().Create(s.Context(context.Background()), def); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &model.APIDefinitionSpec{Name: "httpbin-v2", APIID: def.APIID}
+	if _, err := c.Api().Update(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `Normal DryRunUpdate Would update API ZGVmYXVsdC9odHRwYmlu: ` +
+		`[{"path":"name","current":"httpbin","desired":"httpbin-v2"}]`
+	if got := <-recorder.Events; got != expected {
+		t.Errorf("expected event %q got %q", expected, got)
+	}
+
+	if name := s.APIs()[0].Name; name != "httpbin" {
+		t.Errorf("expected the API on Tyk to be unchanged got name %q", name)
+	}
+
+	if _, err := c.Api().Delete(ctx, *def.APIID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := <-recorder.Events; !strings.HasPrefix(got, "Normal DryRunDelete Would delete API ZGVmYXVsdC9odHRwYmlu") {
+		t.Errorf("expected a delete event got %q", got)
+	}
+
+	if err := c.HotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := <-recorder.Events; got != "Normal DryRunHotReload Would reload the gateways" {
+		t.Errorf("expected a hot reload event got %q", got)
+	}
+
+	if len(s.APIs()) != 1 || s.Reloads() != 0 {
+		t.Errorf("expected Tyk to be unchanged got %d APIs and %d reloads", len(s.APIs()), s.Reloads())
+	}
+}
+
+func TestClientSecrets(t *testing.T) {
+	s := fake.NewGateway()
+	defer s.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	c, ctx := dryRun(s, recorder, &v1alpha1.ApiDefinition{})
+
+	key, hash, err := c.Keys().Create(ctx, &model.SessionState{OrgID: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key != "" || hash != "" {
+		t.Errorf("expected no key got %q hashed %q", key, hash)
+	}
+
+	if len(s.Keys()) != 0 {
+		t.Errorf("expected no key on Tyk got %d", len(s.Keys()))
+	}
+
+	if got := <-recorder.Events; !strings.HasPrefix(got, "Normal DryRunCreate Would create key: ") {
+		t.Errorf("expected a create event got %q", got)
+	}
+
+	if _, err := c.Users().Create(ctx, &model.DashboardUser{EmailAddress: "a@b.c", Password: "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := <-recorder.Events; strings.Contains(got, "hunter2") {
+		t.Errorf("expected the password not to be recorded got %q", got)
+	}
+}
+
+func TestClientLongDiff(t *testing.T) {
+	s := fake.NewGateway()
+	defer s.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	c, ctx := dryRun(s, recorder, &v1alpha1.ApiDefinition{})
+
+	def := &model.APIDefinitionSpec{Name: strings.Repeat("é", 1000)}
+	if _, err := c.Api().Create(ctx, def); err != nil {
+		t.Fatal(err)
+	}
+
+	got := <-recorder.Events
+	if !strings.HasSuffix(got, "...") || len(got) > 600 {
+		t.Errorf("expected the diff to be cut got %d characters", len(got))
+	}
+
+	if !strings.Contains(got, "é...") {
+		t.Errorf("expected the diff to be cut at a character boundary got %q", got[len(got)-10:])
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := dryrun.NewRecorder(recorder)
+
+	a := &v1alpha1.ApiDefinition{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default", UID: "a"}}
+	b := &v1alpha1.ApiDefinition{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default", UID: "b"}}
+
+	r.Event(a, dryrun.ReasonCreate, "API", "Would create API: v1")
+
+	// The same change, reported again by another reconciliation, is recorded
+	// once.
+	r.Event(a, dryrun.ReasonCreate, "API", "Would create API: v1")
+
+	// Other changes, or changes of other resources, are recorded.
+	r.Event(a, dryrun.ReasonCreate, "API", "Would create API: v2")
+	r.Event(a, dryrun.ReasonHotReload, "the gateways", "Would reload the gateways")
+	r.Event(b, dryrun.ReasonCreate, "API", "Would create API: v2")
+
+	// A change reverted to one recorded before is recorded again.
+	r.Event(a, dryrun.ReasonCreate, "API", "Would create API: v1")
+
+	expected := []string{
+		"Normal DryRunCreate Would create API: v1",
+		"Normal DryRunCreate Would create API: v2",
+		"Normal DryRunHotReload Would reload the gateways",
+		"Normal DryRunCreate Would create API: v2",
+		"Normal DryRunCreate Would create API: v1",
+	}
+
+	if len(recorder.Events) != len(expected) {
+		t.Fatalf("expected %d events got %d", len(expected), len(recorder.Events))
+	}
+
+	for _, e := range expected {
+		if got := <-recorder.Events; got != e {
+			t.Errorf("expected event %q got %q", e, got)
+		}
+	}
+}
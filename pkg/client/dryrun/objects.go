@@ -0,0 +1,483 @@
+package dryrun
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/cert"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+// current returns the object o read from Tyk with err, nil if it does not
+// exist.
+func current(o interface{}, err error) (interface{}, error) {
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return o, err
+}
+
+type Api struct {
+	c   Client
+	api universal.Api
+}
+
+func (a Api) Create(ctx context.Context, def *model.APIDefinitionSpec) (*model.Result, error) {
+	id := str(def.APIID)
+
+	var o interface{}
+
+	if id != "" {
+		var err error
+		if o, err = current(a.api.Get(ctx, id)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.Result{Meta: id}, a.c.change(ctx, ReasonCreate, named("API", id), o, def)
+}
+
+func (a Api) Get(ctx context.Context, id string) (*model.APIDefinitionSpec, error) {
+	return a.api.Get(ctx, id)
+}
+
+func (a Api) Update(ctx context.Context, spec *model.APIDefinitionSpec) (*model.Result, error) {
+	id := str(spec.APIID)
+
+	o, err := current(a.api.Get(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{Meta: id}, a.c.change(ctx, ReasonUpdate, named("API", id), o, spec)
+}
+
+func (a Api) Delete(ctx context.Context, id string) (*model.Result, error) {
+	o, err := current(a.api.Get(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{Meta: id}, a.c.change(ctx, ReasonDelete, named("API", id), o, nil)
+}
+
+func (a Api) List(ctx context.Context, options ...model.ListAPIOptions) (*model.APIDefinitionSpecList, error) {
+	return a.api.List(ctx, options...)
+}
+
+type OAS struct {
+	c   Client
+	oas universal.OAS
+}
+
+func (a OAS) Create(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	id := def.ID()
+
+	var o interface{}
+
+	if id != "" {
+		var err error
+		if o, err = current(a.oas.Get(ctx, id)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.Result{Meta: id}, a.c.change(ctx, ReasonCreate, named("OAS API", id), o, def)
+}
+
+func (a OAS) Get(ctx context.Context, id string) (model.TykOAS, error) {
+	return a.oas.Get(ctx, id)
+}
+
+func (a OAS) Update(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	o, err := current(a.oas.Get(ctx, def.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{Meta: def.ID()}, a.c.change(ctx, ReasonUpdate, named("OAS API", def.ID()), o, def)
+}
+
+func (a OAS) Delete(ctx context.Context, id string) (*model.Result, error) {
+	o, err := current(a.oas.Get(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{Meta: id}, a.c.change(ctx, ReasonDelete, named("OAS API", id), o, nil)
+}
+
+// Keys never names the keys it records, their ids are the secrets of the API
+// consumers.
+type Keys struct {
+	c    Client
+	keys universal.Keys
+}
+
+// Create returns an empty key, none is generated.
+func (k Keys) Create(ctx context.Context, key *model.SessionState) (string, string, error) {
+	return "", "", k.c.change(ctx, ReasonCreate, "key", nil, key)
+}
+
+func (k Keys) Get(ctx context.Context, id string) (*model.SessionState, error) {
+	return k.keys.Get(ctx, id)
+}
+
+func (k Keys) Update(ctx context.Context, id string, key *model.SessionState) error {
+	o, err := current(k.keys.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return k.c.change(ctx, ReasonUpdate, "key", o, key)
+}
+
+func (k Keys) Delete(ctx context.Context, id string) error {
+	o, err := current(k.keys.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return k.c.change(ctx, ReasonDelete, "key", o, nil)
+}
+
+// DeleteHashed records the deletion without a diff, keys can't be read by hash.
+func (k Keys) DeleteHashed(ctx context.Context, hash string) error {
+	k.c.record(ctx, ReasonDelete, "key", "")
+	return nil
+}
+
+// withoutPassword returns a copy of user without its password, which is never
+// recorded.
+func withoutPassword(user *model.DashboardUser) *model.DashboardUser {
+	o := *user
+	o.Password = ""
+
+	return &o
+}
+
+type Users struct {
+	c     Client
+	users universal.Users
+}
+
+// Create returns an empty id, no user is created.
+func (u Users) Create(ctx context.Context, user *model.DashboardUser) (string, error) {
+	return "", u.c.change(ctx, ReasonCreate, "user", nil, withoutPassword(user))
+}
+
+func (u Users) Get(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return u.users.Get(ctx, id)
+}
+
+func (u Users) Update(ctx context.Context, user *model.DashboardUser) error {
+	o, err := current(u.users.Get(ctx, user.ID))
+	if err != nil {
+		return err
+	}
+
+	return u.c.change(ctx, ReasonUpdate, named("user", user.ID), o, withoutPassword(user))
+}
+
+func (u Users) Delete(ctx context.Context, id string) error {
+	o, err := current(u.users.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return u.c.change(ctx, ReasonDelete, named("user", id), o, nil)
+}
+
+func (u Users) SetPassword(ctx context.Context, id, password string) error {
+	u.c.record(ctx, ReasonUpdate, "the password of "+named("user", id), "")
+	return nil
+}
+
+type UserGroups struct {
+	c      Client
+	groups universal.UserGroups
+}
+
+// Create returns an empty id, no group is created.
+func (g UserGroups) Create(ctx context.Context, group *model.UserGroup) (string, error) {
+	return "", g.c.change(ctx, ReasonCreate, "user group", nil, group)
+}
+
+func (g UserGroups) Get(ctx context.Context, id string) (*model.UserGroup, error) {
+	return g.groups.Get(ctx, id)
+}
+
+func (g UserGroups) Update(ctx context.Context, group *model.UserGroup) error {
+	o, err := current(g.groups.Get(ctx, group.ID))
+	if err != nil {
+		return err
+	}
+
+	return g.c.change(ctx, ReasonUpdate, named("user group", group.ID), o, group)
+}
+
+func (g UserGroups) Delete(ctx context.Context, id string) error {
+	o, err := current(g.groups.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return g.c.change(ctx, ReasonDelete, named("user group", id), o, nil)
+}
+
+type Organisations struct {
+	c    Client
+	orgs universal.Organisations
+}
+
+// Create returns an empty id, no organisation is created.
+func (o Organisations) Create(ctx context.Context, org *model.Organisation) (string, error) {
+	return "", o.c.change(ctx, ReasonCreate, "organisation", nil, org)
+}
+
+func (o Organisations) Get(ctx context.Context, id string) (*model.Organisation, error) {
+	return o.orgs.Get(ctx, id)
+}
+
+func (o Organisations) Update(ctx context.Context, org *model.Organisation) error {
+	c, err := current(o.orgs.Get(ctx, org.ID))
+	if err != nil {
+		return err
+	}
+
+	return o.c.change(ctx, ReasonUpdate, named("organisation", org.ID), c, org)
+}
+
+func (o Organisations) Delete(ctx context.Context, id string) error {
+	c, err := current(o.orgs.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return o.c.change(ctx, ReasonDelete, named("organisation", id), c, nil)
+}
+
+// CreateUser returns user without id nor access key, no user is created.
+func (o Organisations) CreateUser(ctx context.Context, user *model.DashboardUser) (*model.DashboardUser, error) {
+	u := withoutPassword(user)
+	return u, o.c.change(ctx, ReasonCreate, "user of "+named("organisation", user.OrgID), nil, u)
+}
+
+func (o Organisations) GetUser(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return o.orgs.GetUser(ctx, id)
+}
+
+type Webhooks struct {
+	c     Client
+	hooks universal.Webhooks
+}
+
+// Create returns an empty id, no webhook is created.
+func (w Webhooks) Create(ctx context.Context, hook *model.WebHookHandlerConf) (string, error) {
+	return "", w.c.change(ctx, ReasonCreate, "webhook", nil, hook)
+}
+
+func (w Webhooks) Get(ctx context.Context, id string) (*model.WebHookHandlerConf, error) {
+	return w.hooks.Get(ctx, id)
+}
+
+func (w Webhooks) Update(ctx context.Context, hook *model.WebHookHandlerConf) error {
+	o, err := current(w.hooks.Get(ctx, hook.ID))
+	if err != nil {
+		return err
+	}
+
+	return w.c.change(ctx, ReasonUpdate, named("webhook", hook.ID), o, hook)
+}
+
+func (w Webhooks) Delete(ctx context.Context, id string) error {
+	o, err := current(w.hooks.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return w.c.change(ctx, ReasonDelete, named("webhook", id), o, nil)
+}
+
+type Portal struct {
+	c      Client
+	portal universal.Portal
+}
+
+func (p Portal) Policy() universal.Policy {
+	return Policy{c: p.c, policy: p.portal.Policy()}
+}
+
+func (p Portal) Documentation() universal.Documentation {
+	return Documentation{c: p.c}
+}
+
+func (p Portal) Catalogue() universal.Catalogue {
+	return Catalogue{c: p.c, catalogue: p.portal.Catalogue()}
+}
+
+func (p Portal) Configuration() universal.Configuration {
+	return Configuration{c: p.c, config: p.portal.Configuration()}
+}
+
+type Policy struct {
+	c      Client
+	policy universal.Policy
+}
+
+// policyID returns the id of def, its _id when it has none.
+func policyID(def *v1alpha1.SecurityPolicySpec) string {
+	if id := str(def.ID); id != "" {
+		return id
+	}
+
+	return str(def.MID)
+}
+
+func (p Policy) All(ctx context.Context) ([]v1alpha1.SecurityPolicySpec, error) {
+	return p.policy.All(ctx)
+}
+
+func (p Policy) Get(ctx context.Context, id string) (*v1alpha1.SecurityPolicySpec, error) {
+	return p.policy.Get(ctx, id)
+}
+
+// Create leaves the _id of def unset, no policy is created.
+func (p Policy) Create(ctx context.Context, def *v1alpha1.SecurityPolicySpec) error {
+	id := policyID(def)
+
+	var o interface{}
+
+	if id != "" {
+		var err error
+		if o, err = current(p.policy.Get(ctx, id)); err != nil {
+			return err
+		}
+	}
+
+	return p.c.change(ctx, ReasonCreate, named("policy", id), o, def)
+}
+
+func (p Policy) Update(ctx context.Context, def *v1alpha1.SecurityPolicySpec) error {
+	id := policyID(def)
+
+	o, err := current(p.policy.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return p.c.change(ctx, ReasonUpdate, named("policy", id), o, def)
+}
+
+func (p Policy) Delete(ctx context.Context, id string) error {
+	o, err := current(p.policy.Get(ctx, id))
+	if err != nil {
+		return err
+	}
+
+	return p.c.change(ctx, ReasonDelete, named("policy", id), o, nil)
+}
+
+// Documentation can't read the documentation on Tyk, its deletes are recorded
+// without diff.
+type Documentation struct {
+	c Client
+}
+
+func (d Documentation) Upload(ctx context.Context, o *model.APIDocumentation) (*model.Result, error) {
+	return &model.Result{}, d.c.change(ctx, ReasonCreate, "documentation", nil, o)
+}
+
+func (d Documentation) Delete(ctx context.Context, id string) (*model.Result, error) {
+	d.c.record(ctx, ReasonDelete, named("documentation", id), "")
+	return &model.Result{}, nil
+}
+
+type Catalogue struct {
+	c         Client
+	catalogue universal.Catalogue
+}
+
+func (c Catalogue) Get(ctx context.Context) (*model.APICatalogue, error) {
+	return c.catalogue.Get(ctx)
+}
+
+func (c Catalogue) Create(ctx context.Context, o *model.APICatalogue) (*model.Result, error) {
+	return &model.Result{}, c.c.change(ctx, ReasonCreate, "catalogue", nil, o)
+}
+
+func (c Catalogue) Update(ctx context.Context, o *model.APICatalogue) (*model.Result, error) {
+	cur, err := current(c.catalogue.Get(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{}, c.c.change(ctx, ReasonUpdate, named("catalogue", o.Id), cur, o)
+}
+
+type Configuration struct {
+	c      Client
+	config universal.Configuration
+}
+
+func (c Configuration) Get(ctx context.Context) (*model.PortalModelPortalConfig, error) {
+	return c.config.Get(ctx)
+}
+
+func (c Configuration) Create(
+	ctx context.Context, o *model.PortalModelPortalConfig,
+) (*model.Result, error) {
+	return &model.Result{}, c.c.change(ctx, ReasonCreate, "portal configuration", nil, o)
+}
+
+func (c Configuration) Update(
+	ctx context.Context, o *model.PortalModelPortalConfig,
+) (*model.Result, error) {
+	cur, err := current(c.config.Get(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{}, c.c.change(ctx, ReasonUpdate, "portal configuration", cur, o)
+}
+
+type Certificate struct {
+	c    Client
+	cert universal.Certificate
+}
+
+func (c Certificate) All(ctx context.Context) ([]string, error) {
+	return c.cert.All(ctx)
+}
+
+func (c Certificate) Metadata(ctx context.Context) ([]model.CertificateMeta, error) {
+	return c.cert.Metadata(ctx)
+}
+
+// Upload returns the id Tyk gives to crt, the certificate is recorded without
+// diff, its key is never recorded.
+func (c Certificate) Upload(ctx context.Context, key, crt []byte) (id string, err error) {
+	fingerprint, err := cert.CalculateFingerPrint(crt)
+	if err != nil {
+		return "", err
+	}
+
+	id = client.GetContext(ctx).Env.Org + fingerprint
+	if !c.cert.Exists(ctx, id) {
+		c.c.record(ctx, ReasonCreate, named("certificate", id), "")
+	}
+
+	return id, nil
+}
+
+func (c Certificate) Delete(ctx context.Context, id string) error {
+	c.c.record(ctx, ReasonDelete, named("certificate", id), "")
+	return nil
+}
+
+func (c Certificate) Exists(ctx context.Context, id string) bool {
+	return c.cert.Exists(ctx, id)
+}
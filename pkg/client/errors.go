@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// maxErrorBody is the maximum number of bytes of a response body included in
+// the message of a TykAPIError.
+const maxErrorBody = 512
+
+// TykAPIError is returned by Call when the Tyk Gateway or Dashboard answers with
+// a status code other than 200.
+//
+// It unwraps to ErrNotFound for 404 responses and to ErrFailed otherwise, so
+// IsNotFound and errors.Is keep working.
+type TykAPIError struct {
+	StatusCode int
+	Method     string
+
+	// Endpoint is the endpoint template that was called, e.g. /api/apis/{id}.
+	Endpoint string
+
+	// Result is the parsed response body, nil if the body is not a Tyk result.
+	Result *model.Result
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+func newTykAPIError(method, endpoint string, res *http.Response, body []byte) *TykAPIError {
+	e := &TykAPIError{
+		StatusCode: res.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		Body:       body,
+	}
+
+	var result model.Result
+	if err := json.Unmarshal(body, &result); err == nil {
+		result.StatusCode = res.StatusCode
+		e.Result = &result
+	}
+
+	return e
+}
+
+func (e *TykAPIError) Error() string {
+	msg := fmt.Sprintf("%s %s failed with HTTP %d", e.Method, e.Endpoint, e.StatusCode)
+
+	switch {
+	case e.Result != nil && (e.Result.Message != "" || len(e.Result.Errors) != 0):
+		msg = fmt.Sprintf("%s: %s", msg, e.Result.Message)
+
+		if len(e.Result.Errors) != 0 {
+			msg = fmt.Sprintf("%s %v", msg, e.Result.Errors)
+		}
+	case len(e.Body) != 0:
+		body := strings.TrimSpace(string(e.Body))
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody] + "..."
+		}
+
+		msg = fmt.Sprintf("%s: %s", msg, body)
+	}
+
+	return msg
+}
+
+func (e *TykAPIError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return ErrFailed
+}
+
+// AsTykAPIError returns the *TykAPIError in err's chain, if any.
+func AsTykAPIError(err error) (*TykAPIError, bool) {
+	var e *TykAPIError
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+func hasStatus(err error, codes ...int) bool {
+	e, ok := AsTykAPIError(err)
+	if !ok {
+		return false
+	}
+
+	for _, code := range codes {
+		if e.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsConflict returns true if Tyk rejected the call because the object already
+// exists or was modified concurrently.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized returns true if Tyk rejected the credentials of the
+// OperatorContext.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsValidation returns true if Tyk rejected the payload of the call. Sending
+// the same payload again is expected to fail the same way.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsRetryable returns true if err is likely to go away by itself: Tyk could
+// not be reached, is overloaded or failed with a 5xx.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if e, ok := AsTykAPIError(err); ok {
+		return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+	}
+
+	if IsCircuitOpen(err) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
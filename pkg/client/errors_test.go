@@ -0,0 +1,100 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCallTykAPIError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"Status":"Error","Message":"Validation failed","Errors":["listen path is required"]}`)
+	}))
+	defer svr.Close()
+
+	_, err := Call(testContext(svr.URL), http.MethodPost, "/api/apis", nil)
+
+	e, ok := AsTykAPIError(err)
+	if !ok {
+		t.Fatalf("expected a *TykAPIError got %T", err)
+	}
+
+	if e.StatusCode != http.StatusBadRequest || e.Method != http.MethodPost || e.Endpoint != "/api/apis" {
+		t.Errorf("unexpected error %+v", e)
+	}
+
+	if e.Result == nil || e.Result.Message != "Validation failed" {
+		t.Errorf("expected the body to be parsed got %+v", e.Result)
+	}
+
+	want := "POST /api/apis failed with HTTP 400: Validation failed [listen path is required]"
+	if err.Error() != want {
+		t.Errorf("expected %q got %q", want, err.Error())
+	}
+
+	if !errors.Is(err, ErrFailed) || IsNotFound(err) {
+		t.Error("expected error to unwrap to ErrFailed")
+	}
+}
+
+func TestTykAPIErrorHelpers(t *testing.T) {
+	apiErr := func(code int) error {
+		return fmt.Errorf("wrapped: %w", &TykAPIError{StatusCode: code, Method: http.MethodGet, Endpoint: "/tyk/apis/{id}"})
+	}
+
+	tests := []struct {
+		name         string
+		err          error
+		notFound     bool
+		conflict     bool
+		unauthorized bool
+		validation   bool
+		retryable    bool
+	}{
+		{name: "404", err: apiErr(http.StatusNotFound), notFound: true},
+		{name: "409", err: apiErr(http.StatusConflict), conflict: true},
+		{name: "401", err: apiErr(http.StatusUnauthorized), unauthorized: true},
+		{name: "403", err: apiErr(http.StatusForbidden), unauthorized: true},
+		{name: "400", err: apiErr(http.StatusBadRequest), validation: true},
+		{name: "429", err: apiErr(http.StatusTooManyRequests), retryable: true},
+		{name: "503", err: apiErr(http.StatusServiceUnavailable), retryable: true},
+		{name: "connection refused", err: &url.Error{Op: "Get", URL: "http://tyk", Err: &netError{}}, retryable: true},
+		{name: "circuit open", err: &CircuitOpenError{URL: "http://tyk"}, retryable: true},
+		{name: "other", err: errors.New("boom")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound: expected %v got %v", tt.notFound, got)
+			}
+
+			if got := IsConflict(tt.err); got != tt.conflict {
+				t.Errorf("IsConflict: expected %v got %v", tt.conflict, got)
+			}
+
+			if got := IsUnauthorized(tt.err); got != tt.unauthorized {
+				t.Errorf("IsUnauthorized: expected %v got %v", tt.unauthorized, got)
+			}
+
+			if got := IsValidation(tt.err); got != tt.validation {
+				t.Errorf("IsValidation: expected %v got %v", tt.validation, got)
+			}
+
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable: expected %v got %v", tt.retryable, got)
+			}
+		})
+	}
+}
+
+type netError struct{}
+
+func (netError) Error() string   { return "connection refused" }
+func (netError) Timeout() bool   { return false }
+func (netError) Temporary() bool { return false }
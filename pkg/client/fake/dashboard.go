@@ -0,0 +1,770 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+)
+
+// dashboardMsg is the body of most dashboard responses.
+type dashboardMsg struct {
+	Status  string
+	Message string
+	Meta    interface{}
+}
+
+// dashboardAPI wraps API definitions sent to and returned by the dashboard.
+type dashboardAPI struct {
+	APIDefinition   model.APIDefinitionSpec `json:"api_definition"`
+	UserOwners      []string                `json:"user_owners"`
+	UserGroupOwners []string                `json:"user_group_owners"`
+}
+
+// dashboardKey wraps keys returned by the dashboard.
+type dashboardKey struct {
+	KeyID   string             `json:"key_id"`
+	KeyHash string             `json:"key_hash,omitempty"`
+	Data    model.SessionState `json:"data"`
+}
+
+func dashboardError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, dashboardMsg{Status: "Error", Message: msg})
+}
+
+func dashboardOK(w http.ResponseWriter, msg string, meta interface{}) {
+	writeJSON(w, http.StatusOK, dashboardMsg{Status: "OK", Message: msg, Meta: meta})
+}
+
+func (s *Server) serveDashboard(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/admin/") {
+		s.serveAdmin(w, r)
+		return
+	}
+
+	if r.Header.Get("authorization") != Secret {
+		dashboardError(w, http.StatusUnauthorized, "Not authorised")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := route(r.URL.Path, "/api/apis/oas"); ok {
+		s.serveOAS(w, r, id,
+			func(status int, msg string) { dashboardError(w, status, msg) },
+			func(key, action string) {
+				if action == "added" {
+					dashboardOK(w, "API created", s.oas[key].DBID())
+					return
+				}
+
+				dashboardOK(w, "API "+action, nil)
+			},
+		)
+
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/apis"); ok {
+		s.dashboardAPIs(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/keys"); ok {
+		s.dashboardKeys(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/users"); ok {
+		s.dashboardUsers(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/usergroups"); ok {
+		s.dashboardUserGroups(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/hooks"); ok {
+		s.dashboardWebhooks(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/portal/policies"); ok {
+		s.dashboardPolicies(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/certs"); ok {
+		s.dashboardCerts(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/portal/catalogue"); ok && id == "" {
+		s.dashboardCatalogue(w, r)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/portal/configuration"); ok && id == "" {
+		s.dashboardConfiguration(w, r)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/api/portal/documentation"); ok {
+		s.dashboardDocumentation(w, r, id)
+		return
+	}
+
+	dashboardError(w, http.StatusNotFound, "Not found")
+}
+
+// findAPI returns the id of the API whose id or api_id is id.
+func (s *Server) findAPI(id string) (string, bool) {
+	if _, ok := s.apis[id]; ok {
+		return id, true
+	}
+
+	for k, v := range s.apis {
+		if str(v.APIID) == id {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Server) dashboardAPIs(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			o := make([]dashboardAPI, 0, len(s.apis))
+			for _, k := range s.apiKeys() {
+				o = append(o, dashboardAPI{APIDefinition: *s.apis[k]})
+			}
+
+			writeJSON(w, http.StatusOK, struct {
+				Apis  []dashboardAPI `json:"apis"`
+				Pages int            `json:"pages"`
+			}{Apis: o, Pages: 1})
+		case http.MethodPost:
+			var o dashboardAPI
+			if err := readJSON(r, &o); err != nil {
+				dashboardError(w, http.StatusBadRequest, "Request malformed")
+				return
+			}
+
+			api := o.APIDefinition
+			if str(api.APIID) == "" {
+				api.APIID = ptr(uuid())
+			}
+
+			if _, ok := s.findAPI(*api.APIID); ok {
+				dashboardError(w, http.StatusConflict, "API ID already exists")
+				return
+			}
+
+			api.ID = ptr(objectID())
+			api.OrgID = ptr(Org)
+			s.apis[*api.ID] = &api
+			s.owners[*api.ID] = Owners{Users: o.UserOwners, Groups: o.UserGroupOwners}
+			dashboardOK(w, "API created", *api.ID)
+		default:
+			dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+		}
+
+		return
+	}
+
+	key, ok := s.findAPI(id)
+	if !ok {
+		dashboardError(w, http.StatusNotFound, "Could not retrieve API detail")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, dashboardAPI{APIDefinition: *s.apis[key]})
+	case http.MethodPut:
+		var o dashboardAPI
+		if err := readJSON(r, &o); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		api := o.APIDefinition
+		api.ID = ptr(key)
+		api.OrgID = ptr(Org)
+
+		if str(api.APIID) == "" {
+			api.APIID = s.apis[key].APIID
+		}
+
+		s.apis[key] = &api
+		s.owners[key] = Owners{Users: o.UserOwners, Groups: o.UserGroupOwners}
+		dashboardOK(w, "Api updated", nil)
+	case http.MethodDelete:
+		delete(s.apis, key)
+		delete(s.owners, key)
+		dashboardOK(w, "API deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+// findPolicy returns the _id of the policy whose _id or id is id.
+func (s *Server) findPolicy(id string) (string, bool) {
+	if _, ok := s.policies[id]; ok {
+		return id, true
+	}
+
+	for k, v := range s.policies {
+		if str(v.ID) == id {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Server) dashboardPolicies(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			o := make([]v1alpha1.SecurityPolicySpec, 0, len(s.policies))
+			for _, k := range s.policyKeys() {
+				o = append(o, *s.policies[k])
+			}
+
+			writeJSON(w, http.StatusOK, struct {
+				Data  []v1alpha1.SecurityPolicySpec `json:"data"`
+				Pages int                           `json:"pages"`
+			}{Data: o, Pages: 1})
+		case http.MethodPost:
+			var pol v1alpha1.SecurityPolicySpec
+			if err := readJSON(r, &pol); err != nil {
+				dashboardError(w, http.StatusBadRequest, "Request malformed")
+				return
+			}
+
+			mid := objectID()
+			pol.MID = ptr(mid)
+			pol.OrgID = ptr(Org)
+
+			if str(pol.ID) == "" {
+				pol.ID = ptr(mid)
+			}
+
+			s.policies[mid] = &pol
+			dashboardOK(w, mid, nil)
+		default:
+			dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+		}
+
+		return
+	}
+
+	key, ok := s.findPolicy(id)
+	if !ok {
+		dashboardError(w, http.StatusNotFound, "Could not retrieve policy detail")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.policies[key])
+	case http.MethodPut:
+		var pol v1alpha1.SecurityPolicySpec
+		if err := readJSON(r, &pol); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		pol.MID = ptr(key)
+		pol.OrgID = ptr(Org)
+
+		if str(pol.ID) == "" {
+			pol.ID = s.policies[key].ID
+		}
+
+		s.policies[key] = &pol
+		writeJSON(w, http.StatusOK, &pol)
+	case http.MethodDelete:
+		delete(s.policies, key)
+		dashboardOK(w, "Deleted policy", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardKeys(w http.ResponseWriter, r *http.Request, id string) {
+	id = s.keyID(r, id)
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var key model.SessionState
+		if err := readJSON(r, &key); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		key.OrgID = Org
+		id = Org + uuid()
+		s.keys[id] = &key
+		writeJSON(w, http.StatusOK, dashboardKey{KeyID: id, KeyHash: keyHash(id), Data: key})
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.keys[id] == nil:
+		dashboardError(w, http.StatusNotFound, "Could not retrieve key detail")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, dashboardKey{KeyID: id, Data: *s.keys[id]})
+	case r.Method == http.MethodPut:
+		var key model.SessionState
+		if err := readJSON(r, &key); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		key.OrgID = Org
+		s.keys[id] = &key
+		dashboardOK(w, "Key updated", nil)
+	case r.Method == http.MethodDelete:
+		delete(s.keys, id)
+		dashboardOK(w, "Key deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardUsers(w http.ResponseWriter, r *http.Request, id string) {
+	var action string
+	if i := strings.Index(id, "/"); i != -1 {
+		id, action = id[:i], id[i+1:]
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var user model.DashboardUser
+		if err := readJSON(r, &user); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		for _, v := range s.users {
+			if v.EmailAddress == user.EmailAddress {
+				dashboardError(w, http.StatusBadRequest, "User email already exists for this Org")
+				return
+			}
+		}
+
+		user.ID = objectID()
+		user.OrgID = Org
+		s.users[user.ID] = &user
+
+		o := user
+		o.Password = ""
+		dashboardOK(w, "User created", o)
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.users[id] == nil:
+		dashboardError(w, http.StatusNotFound, "User not found")
+	case action == "actions/reset" && r.Method == http.MethodPost:
+		var o struct {
+			NewPassword string `json:"new_password"`
+		}
+
+		if err := readJSON(r, &o); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		s.users[id].Password = o.NewPassword
+		dashboardOK(w, "User password updated", nil)
+	case action != "":
+		dashboardError(w, http.StatusNotFound, "Not found")
+	case r.Method == http.MethodGet:
+		o := *s.users[id]
+		o.Password = ""
+		writeJSON(w, http.StatusOK, o)
+	case r.Method == http.MethodPut:
+		var user model.DashboardUser
+		if err := readJSON(r, &user); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		user.ID = id
+		user.OrgID = Org
+		user.Password = s.users[id].Password
+		s.users[id] = &user
+		dashboardOK(w, "User updated", nil)
+	case r.Method == http.MethodDelete:
+		delete(s.users, id)
+		dashboardOK(w, "User deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardUserGroups(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var group model.UserGroup
+		if err := readJSON(r, &group); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		group.ID = objectID()
+		group.OrgID = Org
+		s.userGroups[group.ID] = &group
+		dashboardOK(w, "User group created", group.ID)
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.userGroups[id] == nil:
+		dashboardError(w, http.StatusNotFound, "User group not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.userGroups[id])
+	case r.Method == http.MethodPut:
+		var group model.UserGroup
+		if err := readJSON(r, &group); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		group.ID = id
+		group.OrgID = Org
+		s.userGroups[id] = &group
+		dashboardOK(w, "User group updated", nil)
+	case r.Method == http.MethodDelete:
+		delete(s.userGroups, id)
+		dashboardOK(w, "User group deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+// pageSize is the number of items of a page of a dashboard list.
+const pageSize = 10
+
+// page returns the page of items requested by the p query parameter of r, the
+// first one by default and all of them when it is -2, along with the number
+// of pages.
+func page[T any](r *http.Request, items []T) ([]T, int) {
+	pages := (len(items) + pageSize - 1) / pageSize
+
+	p, err := strconv.Atoi(r.URL.Query().Get("p"))
+	switch {
+	case p == -2:
+		return items, pages
+	case err != nil || p < 1:
+		p = 1
+	}
+
+	start := (p - 1) * pageSize
+	if start >= len(items) {
+		return items[:0], pages
+	}
+
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], pages
+}
+
+func (s *Server) dashboardCerts(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet && r.URL.Query().Get("mode") == "detailed":
+		certs, pages := page(r, s.certMeta())
+		writeJSON(w, http.StatusOK, struct {
+			Certs []model.CertificateMeta `json:"certs"`
+			Pages int                     `json:"pages"`
+		}{Certs: certs, Pages: pages})
+	case id == "" && r.Method == http.MethodGet:
+		certs, pages := page(r, keys(s.certs))
+		writeJSON(w, http.StatusOK, struct {
+			Certs []string `json:"certs"`
+			Pages int      `json:"pages"`
+		}{Certs: certs, Pages: pages})
+	case id == "" && r.Method == http.MethodPost:
+		id, exists, err := s.storeCert(r)
+		if err != nil {
+			dashboardError(w, http.StatusBadRequest, "Malformed certificate")
+			return
+		}
+
+		if exists {
+			dashboardError(w, http.StatusForbidden, "Could not add certificate: Certificate with "+id+" ID already exists")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, certResponse{ID: id, Message: "Certificate added", Status: "ok"})
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.certs[id] == nil:
+		dashboardError(w, http.StatusNotFound, "Certificate not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, certResponse{ID: id})
+	case r.Method == http.MethodDelete:
+		delete(s.certs, id)
+		dashboardOK(w, "Certificate deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardCatalogue(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if s.catalogue == nil {
+			dashboardError(w, http.StatusNotFound, "Could not retrieve catalogue")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.catalogue)
+	case http.MethodPost, http.MethodPut:
+		var o model.APICatalogue
+		if err := readJSON(r, &o); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && s.catalogue != nil:
+			dashboardError(w, http.StatusConflict, "Catalogue already exists")
+			return
+		case r.Method == http.MethodPut && s.catalogue == nil:
+			dashboardError(w, http.StatusNotFound, "Could not retrieve catalogue")
+			return
+		case s.catalogue != nil:
+			o.Id = s.catalogue.Id
+		default:
+			o.Id = objectID()
+		}
+
+		o.OrgId = Org
+		s.catalogue = &o
+		dashboardOK(w, o.Id, nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardConfiguration(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if s.configuration == nil {
+			dashboardError(w, http.StatusNotFound, "Could not retrieve configuration")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.configuration)
+	case http.MethodPost, http.MethodPut:
+		var o model.PortalModelPortalConfig
+		if err := readJSON(r, &o); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && s.configuration != nil:
+			dashboardError(w, http.StatusConflict, "Configuration already exists")
+			return
+		case r.Method == http.MethodPut && s.configuration == nil:
+			dashboardError(w, http.StatusNotFound, "Could not retrieve configuration")
+			return
+		case s.configuration != nil:
+			o.Id = s.configuration.Id
+		default:
+			o.Id = objectID()
+		}
+
+		o.OrgID = Org
+		s.configuration = &o
+		dashboardOK(w, o.Id, nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardDocumentation(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var o model.APIDocumentation
+		if err := readJSON(r, &o); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		o.Id = objectID()
+		s.documentation[o.Id] = &o
+		dashboardOK(w, o.Id, nil)
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.documentation[id] == nil:
+		dashboardError(w, http.StatusNotFound, "Could not retrieve documentation")
+	case r.Method == http.MethodDelete:
+		delete(s.documentation, id)
+		dashboardOK(w, "Documentation deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) dashboardWebhooks(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.webhooks))
+		for k := range s.webhooks {
+			ids = append(ids, k)
+		}
+
+		sort.Strings(ids)
+
+		o := make([]*model.WebHookHandlerConf, 0, len(ids))
+		for _, k := range ids {
+			o = append(o, s.webhooks[k])
+		}
+
+		writeJSON(w, http.StatusOK, struct {
+			Hooks []*model.WebHookHandlerConf `json:"hooks"`
+			Pages int                         `json:"pages"`
+		}{Hooks: o, Pages: 1})
+	case id == "" && r.Method == http.MethodPost:
+		var hook model.WebHookHandlerConf
+		if err := readJSON(r, &hook); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		hook.ID = objectID()
+		s.webhooks[hook.ID] = &hook
+		dashboardOK(w, "Webhook created", hook.ID)
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.webhooks[id] == nil:
+		dashboardError(w, http.StatusNotFound, "Webhook not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.webhooks[id])
+	case r.Method == http.MethodPut:
+		var hook model.WebHookHandlerConf
+		if err := readJSON(r, &hook); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		hook.ID = id
+		s.webhooks[id] = &hook
+		dashboardOK(w, "Webhook updated", nil)
+	case r.Method == http.MethodDelete:
+		delete(s.webhooks, id)
+		dashboardOK(w, "Webhook deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+// serveAdmin serves the admin API, authenticated by the admin-auth header.
+func (s *Server) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("admin-auth") != AdminSecret {
+		dashboardError(w, http.StatusUnauthorized, "Not authorised")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := route(r.URL.Path, "/admin/organisations"); ok {
+		s.adminOrganisations(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/admin/users"); ok && id == "" && r.Method == http.MethodPost {
+		var user model.DashboardUser
+		if err := readJSON(r, &user); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		if s.organisations[user.OrgID] == nil {
+			dashboardError(w, http.StatusBadRequest, "Organisation not found")
+			return
+		}
+
+		for _, v := range s.users {
+			if v.EmailAddress == user.EmailAddress {
+				dashboardError(w, http.StatusBadRequest, "User email already exists")
+				return
+			}
+		}
+
+		user.ID = objectID()
+		user.AccessKey = uuid()
+		s.users[user.ID] = &user
+
+		// The admin API returns the access key in the message only.
+		o := user
+		o.Password = ""
+		o.AccessKey = ""
+		dashboardOK(w, user.AccessKey, o)
+
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/admin/users"); ok && id != "" && r.Method == http.MethodGet {
+		user, ok := s.users[id]
+		if !ok {
+			dashboardError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		o := *user
+		o.Password = ""
+		writeJSON(w, http.StatusOK, o)
+
+		return
+	}
+
+	dashboardError(w, http.StatusNotFound, "Not found")
+}
+
+func (s *Server) adminOrganisations(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var org model.Organisation
+		if err := readJSON(r, &org); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		org.ID = objectID()
+		s.organisations[org.ID] = &org
+		dashboardOK(w, "Org created", org.ID)
+	case id == "":
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.organisations[id] == nil:
+		dashboardError(w, http.StatusNotFound, "Could not retrieve org detail")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.organisations[id])
+	case r.Method == http.MethodPut:
+		var org model.Organisation
+		if err := readJSON(r, &org); err != nil {
+			dashboardError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		org.ID = id
+		s.organisations[id] = &org
+		dashboardOK(w, "Org updated", nil)
+	case r.Method == http.MethodDelete:
+		delete(s.organisations, id)
+		dashboardOK(w, "Org deleted", nil)
+	default:
+		dashboardError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
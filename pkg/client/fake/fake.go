@@ -0,0 +1,568 @@
+// Package fake provides in-memory Tyk Gateway and Dashboard servers, so that
+// the clients and the reconcilers can be tested end-to-end without a running
+// Tyk.
+//
+// The servers keep state between calls, generate IDs the way Tyk does and
+// answer unknown objects with 404.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/cert"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/go-logr/logr"
+)
+
+const (
+	// Secret is the API key accepted by the servers.
+	Secret = "fake-secret"
+
+	// Org is the organisation the objects created on the servers belong to.
+	Org = "5e9d9544a1dcd60001d0ed20"
+
+	// AdminSecret is the admin secret accepted by the admin API of the
+	// dashboard.
+	AdminSecret = "fake-admin-secret"
+)
+
+// Owners are the dashboard users and user groups owning an API.
+type Owners struct {
+	Users  []string
+	Groups []string
+}
+
+// Server is an in-memory Tyk Gateway or Dashboard.
+type Server struct {
+	*httptest.Server
+
+	mode v1alpha1.OperatorContextMode
+
+	mu sync.Mutex
+
+	// apis are keyed by api_id on the gateway and by id on the dashboard.
+	apis map[string]*model.APIDefinitionSpec
+
+	// owners are the user and user group owners of the apis, keyed by id. They
+	// are only set by the dashboard.
+	owners map[string]Owners
+
+	// oas are the Tyk OAS API definitions keyed by api id.
+	oas map[string]model.TykOAS
+
+	// keys are keyed by their value.
+	keys map[string]*model.SessionState
+
+	// policies are keyed by id on the gateway and by _id on the dashboard.
+	policies map[string]*v1alpha1.SecurityPolicySpec
+
+	users      map[string]*model.DashboardUser
+	userGroups map[string]*model.UserGroup
+
+	// organisations are only managed through the admin API of the dashboard.
+	organisations map[string]*model.Organisation
+
+	// webhooks are only stored by the dashboard.
+	webhooks map[string]*model.WebHookHandlerConf
+
+	certs         map[string][]byte
+	catalogue     *model.APICatalogue
+	configuration *model.PortalModelPortalConfig
+	documentation map[string]*model.APIDocumentation
+	reloads       int
+	reloadError   string
+
+	// keyDeleteError is returned by the gateway to the key deletions.
+	keyDeleteError string
+}
+
+func newServer(mode v1alpha1.OperatorContextMode) *Server {
+	return &Server{
+		mode:          mode,
+		apis:          make(map[string]*model.APIDefinitionSpec),
+		owners:        make(map[string]Owners),
+		oas:           make(map[string]model.TykOAS),
+		users:         make(map[string]*model.DashboardUser),
+		userGroups:    make(map[string]*model.UserGroup),
+		organisations: make(map[string]*model.Organisation),
+		webhooks:      make(map[string]*model.WebHookHandlerConf),
+		keys:          make(map[string]*model.SessionState),
+		policies:      make(map[string]*v1alpha1.SecurityPolicySpec),
+		certs:         make(map[string][]byte),
+		documentation: make(map[string]*model.APIDocumentation),
+	}
+}
+
+// NewGateway starts a Tyk Gateway serving /tyk/apis, /tyk/policies, /tyk/certs,
+// /tyk/keys and /tyk/reload/group. It must be closed once done.
+func NewGateway() *Server {
+	s := newServer("ce")
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveGateway))
+
+	return s
+}
+
+// NewDashboard starts a Tyk Dashboard serving /api/apis, /api/certs, /api/keys,
+// /api/users, /api/usergroups, /api/hooks, the portal policies, catalogue, configuration
+// and documentation, and the /admin/organisations and /admin/users admin API.
+// It must be closed once done.
+func NewDashboard() *Server {
+	s := newServer("pro")
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveDashboard))
+
+	return s
+}
+
+// Env returns the environment used to connect to s.
+func (s *Server) Env() environment.Env {
+	return environment.Env{
+		Environment: v1alpha1.Environment{
+			Mode: s.mode,
+			URL:  s.URL,
+			Auth: Secret,
+			Org:  Org,
+		},
+	}
+}
+
+// Context returns a copy of ctx carrying the client context of s.
+func (s *Server) Context(ctx context.Context) context.Context {
+	return client.SetContext(ctx, client.Context{
+		Env: s.Env(),
+		Log: logr.Discard(),
+	})
+}
+
+// APIs returns the API definitions stored on s, sorted by api_id.
+func (s *Server) APIs() []model.APIDefinitionSpec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make([]model.APIDefinitionSpec, 0, len(s.apis))
+	for _, k := range s.apiKeys() {
+		o = append(o, *s.apis[k])
+	}
+
+	return o
+}
+
+// Owners returns the owners of the API whose api_id is id.
+func (s *Server) Owners(id string) Owners {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, _ := s.findAPI(id)
+
+	return s.owners[key]
+}
+
+// Users returns the dashboard users stored on s, keyed by id. Their password is
+// set.
+func (s *Server) Users() map[string]model.DashboardUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make(map[string]model.DashboardUser, len(s.users))
+	for k, v := range s.users {
+		o[k] = *v
+	}
+
+	return o
+}
+
+// UserGroups returns the dashboard user groups stored on s, keyed by id.
+func (s *Server) UserGroups() map[string]model.UserGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make(map[string]model.UserGroup, len(s.userGroups))
+	for k, v := range s.userGroups {
+		o[k] = *v
+	}
+
+	return o
+}
+
+// Organisations returns the organisations stored on s, keyed by id.
+func (s *Server) Organisations() map[string]model.Organisation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make(map[string]model.Organisation, len(s.organisations))
+	for k, v := range s.organisations {
+		o[k] = *v
+	}
+
+	return o
+}
+
+// Webhooks returns the webhooks stored on s, keyed by id.
+func (s *Server) Webhooks() map[string]model.WebHookHandlerConf {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make(map[string]model.WebHookHandlerConf, len(s.webhooks))
+	for k, v := range s.webhooks {
+		o[k] = *v
+	}
+
+	return o
+}
+
+// OAS returns the Tyk OAS API definitions stored on s, sorted by id.
+func (s *Server) OAS() []model.TykOAS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.oas))
+	for id := range s.oas {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	o := make([]model.TykOAS, 0, len(ids))
+	for _, id := range ids {
+		o = append(o, s.oas[id])
+	}
+
+	return o
+}
+
+// Keys returns the keys stored on s, keyed by their value.
+func (s *Server) Keys() map[string]model.SessionState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make(map[string]model.SessionState, len(s.keys))
+	for k, v := range s.keys {
+		o[k] = *v
+	}
+
+	return o
+}
+
+// Policies returns the security policies stored on s, sorted by id.
+func (s *Server) Policies() []v1alpha1.SecurityPolicySpec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := make([]v1alpha1.SecurityPolicySpec, 0, len(s.policies))
+	for _, k := range s.policyKeys() {
+		o = append(o, *s.policies[k])
+	}
+
+	return o
+}
+
+// Certificates returns the ID of the certificates stored on s, sorted.
+func (s *Server) Certificates() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return keys(s.certs)
+}
+
+// Reloads returns the number of group reloads s received.
+func (s *Server) Reloads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reloads
+}
+
+// FailReloads makes the group reloads received by s fail with message, until
+// it is called again with an empty message.
+func (s *Server) FailReloads(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reloadError = message
+}
+
+// FailKeyDeletes makes the key deletions received by the gateway s fail with
+// message, until it is called again with an empty message.
+func (s *Server) FailKeyDeletes(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keyDeleteError = message
+}
+
+func (s *Server) apiKeys() []string {
+	k := make([]string, 0, len(s.apis))
+	for id := range s.apis {
+		k = append(k, id)
+	}
+
+	sort.Slice(k, func(i, j int) bool {
+		return str(s.apis[k[i]].APIID) < str(s.apis[k[j]].APIID)
+	})
+
+	return k
+}
+
+func (s *Server) policyKeys() []string {
+	k := make([]string, 0, len(s.policies))
+	for id := range s.policies {
+		k = append(k, id)
+	}
+
+	sort.Slice(k, func(i, j int) bool {
+		return str(s.policies[k[i]].ID) < str(s.policies[k[j]].ID)
+	})
+
+	return k
+}
+
+// serveOAS serves the Tyk OAS API definitions of both the gateway and the
+// dashboard. The dashboard also sets info.dbId, the id of its database object.
+func (s *Server) serveOAS(
+	w http.ResponseWriter,
+	r *http.Request,
+	id string,
+	fail func(status int, msg string),
+	ok func(id, action string),
+) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		def, err := readOAS(r)
+		if err != nil {
+			fail(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if def.ID() == "" {
+			def.SetID(uuid())
+		}
+
+		if _, exists := s.oas[def.ID()]; exists {
+			fail(http.StatusConflict, "API with this ID already exists")
+			return
+		}
+
+		if s.mode == "pro" {
+			def.SetOrgID(Org)
+			def.SetDBID(objectID())
+		}
+
+		s.oas[def.ID()] = def
+		ok(def.ID(), "added")
+	case id == "":
+		fail(http.StatusMethodNotAllowed, "Method not supported")
+	case s.oas[id] == nil:
+		fail(http.StatusNotFound, "API not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.oas[id])
+	case r.Method == http.MethodPut:
+		def, err := readOAS(r)
+		if err != nil {
+			fail(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if def.ID() != id {
+			fail(http.StatusBadRequest, "Request APIID does not match that in Definition! For Update operations these must match.")
+			return
+		}
+
+		if s.mode == "pro" {
+			def.SetOrgID(Org)
+			def.SetDBID(s.oas[id].DBID())
+		}
+
+		s.oas[id] = def
+		ok(id, "modified")
+	case r.Method == http.MethodDelete:
+		delete(s.oas, id)
+		ok(id, "deleted")
+	default:
+		fail(http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func readOAS(r *http.Request) (model.TykOAS, error) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseTykOAS(b)
+}
+
+// storeCert stores the certificate found in the multipart form of r and
+// returns its ID, the organisation followed by the fingerprint of the
+// certificate.
+func (s *Server) storeCert(r *http.Request) (id string, exists bool, err error) {
+	f, _, err := r.FormFile("cert")
+	if err != nil {
+		return "", false, err
+	}
+
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return "", false, err
+	}
+
+	crt := b
+
+	for rest := b; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type == "CERTIFICATE" {
+			crt = pem.EncodeToMemory(block)
+			break
+		}
+	}
+
+	fingerprint, err := cert.CalculateFingerPrint(crt)
+	if err != nil {
+		return "", false, err
+	}
+
+	id = Org + fingerprint
+
+	if _, exists = s.certs[id]; !exists {
+		s.certs[id] = b
+	}
+
+	return id, exists, nil
+}
+
+// certMeta returns the metadata of the certificates stored on s, sorted by id,
+// as listed by the detailed certificate endpoints.
+func (s *Server) certMeta() []model.CertificateMeta {
+	o := make([]model.CertificateMeta, 0, len(s.certs))
+
+	for _, id := range keys(s.certs) {
+		c, err := cert.Parse(s.certs[id])
+		if err != nil {
+			continue
+		}
+
+		o = append(o, model.CertificateMeta{
+			ID:            id,
+			IssuerCN:      c.Issuer.CommonName,
+			SubjectCN:     c.Subject.CommonName,
+			DNSNames:      c.DNSNames,
+			HasPrivateKey: bytes.Contains(s.certs[id], []byte("PRIVATE KEY")),
+			NotBefore:     c.NotBefore,
+			NotAfter:      c.NotAfter,
+			IsCA:          c.IsCA,
+		})
+	}
+
+	return o
+}
+
+// objectID returns a new 24 characters hex ID, like the ones generated by
+// MongoDB for dashboard objects.
+func objectID() string {
+	return randomHex(12)
+}
+
+// uuid returns a new 32 characters hex ID, like the ones generated by the
+// gateway for APIs and policies.
+// keyHash returns the hash the servers store key under.
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// keyID returns the id of the key requested by r, looked up by its hash when
+// the request is made with hashed=true.
+func (s *Server) keyID(r *http.Request, id string) string {
+	if id == "" || r.URL.Query().Get("hashed") != "true" {
+		return id
+	}
+
+	for k := range s.keys {
+		if keyHash(k) == id {
+			return k
+		}
+	}
+
+	return "missing"
+}
+
+func uuid() string {
+	return randomHex(16)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// route returns the id following prefix in path. ok is false if path is not
+// prefix nor one of its children.
+func route(path, prefix string) (id string, ok bool) {
+	if path == prefix || path == prefix+"/" {
+		return "", true
+	}
+
+	if strings.HasPrefix(path, prefix+"/") {
+		return strings.TrimPrefix(path, prefix+"/"), true
+	}
+
+	return "", false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func keys(m map[string][]byte) []string {
+	k := make([]string, 0, len(m))
+	for id := range m {
+		k = append(k, id)
+	}
+
+	sort.Strings(k)
+
+	return k
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,677 @@
+package fake_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/cert"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/fake"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/klient"
+	"github.com/go-logr/logr"
+)
+
+func servers(t *testing.T) map[string]*fake.Server {
+	t.Helper()
+
+	o := map[string]*fake.Server{
+		"gateway":   fake.NewGateway(),
+		"dashboard": fake.NewDashboard(),
+	}
+
+	for _, s := range o {
+		t.Cleanup(s.Close)
+	}
+
+	return o
+}
+
+func TestApi(t *testing.T) {
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			ctx := s.Context(context.Background())
+			api := &model.APIDefinitionSpec{Name: "httpbin", APIID: ptr("ZGVmYXVsdC9odHRwYmlu")}
+
+			if _, err := klient.Universal.Api().Create(ctx, api); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := klient.Universal.Api().Get(ctx, *api.APIID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Name != api.Name {
+				t.Errorf("expected name %q got %q", api.Name, got.Name)
+			}
+
+			api.Name = "httpbin updated"
+
+			if _, err := klient.Universal.Api().Update(ctx, api); err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := klient.Universal.Api().List(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(list.Apis) != 1 || list.Apis[0].Name != api.Name {
+				t.Errorf("expected a single updated api got %+v", list.Apis)
+			}
+
+			if _, err := klient.Universal.Api().Delete(ctx, *api.APIID); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.Api().Get(ctx, *api.APIID); !client.IsNotFound(err) {
+				t.Errorf("expected not found got %v", err)
+			}
+
+			if n := len(s.APIs()); n != 0 {
+				t.Errorf("expected no api left got %d", n)
+			}
+		})
+	}
+}
+
+const oasDocument = `
+openapi: 3.0.3
+info:
+  title: httpbin
+  version: 1.0.0
+paths: {}
+x-tyk-api-gateway:
+  info:
+    id: ZGVmYXVsdC9odHRwYmluLW9hcw
+    name: httpbin
+    state:
+      active: true
+  server:
+    listenPath:
+      value: /httpbin/
+  upstream:
+    url: http://httpbin.org
+`
+
+func TestOAS(t *testing.T) {
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			ctx := s.Context(context.Background())
+
+			def, err := model.ParseTykOAS([]byte(oasDocument))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.OAS().Create(ctx, def); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.OAS().Create(ctx, def); !client.IsConflict(err) {
+				t.Errorf("expected conflict got %v", err)
+			}
+
+			got, err := klient.Universal.OAS().Get(ctx, def.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.ListenPath() != "/httpbin/" {
+				t.Errorf("expected listen path /httpbin/ got %q", got.ListenPath())
+			}
+
+			def[model.TykOASExtension].(map[string]interface{})["upstream"] = map[string]interface{}{
+				"url": "http://httpbin.org/anything",
+			}
+
+			if _, err := klient.Universal.OAS().Update(ctx, def); err != nil {
+				t.Fatal(err)
+			}
+
+			list := s.OAS()
+			if len(list) != 1 || list[0].TargetURL() != "http://httpbin.org/anything" {
+				t.Errorf("expected a single updated api got %+v", list)
+			}
+
+			if _, err := klient.Universal.OAS().Delete(ctx, def.ID()); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.OAS().Get(ctx, def.ID()); !client.IsNotFound(err) {
+				t.Errorf("expected not found got %v", err)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			ctx := s.Context(context.Background())
+			key := &model.SessionState{OrgID: fake.Org, Alias: "httpbin", ApplyPolicies: []string{"policy"}}
+
+			id, hash, err := klient.Universal.Keys().Create(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hash == "" || hash == id {
+				t.Errorf("expected the key to be hashed got %q", hash)
+			}
+
+			got, err := klient.Universal.Keys().Get(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Alias != key.Alias || len(got.ApplyPolicies) != 1 {
+				t.Errorf("expected %+v got %+v", key, got)
+			}
+
+			key.Alias = "httpbin updated"
+
+			if err := klient.Universal.Keys().Update(ctx, id, key); err != nil {
+				t.Fatal(err)
+			}
+
+			if keys := s.Keys(); len(keys) != 1 || keys[id].Alias != key.Alias {
+				t.Errorf("expected a single updated key got %+v", keys)
+			}
+
+			if err := klient.Universal.Keys().Delete(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.Keys().Get(ctx, id); !client.IsNotFound(err) {
+				t.Errorf("expected not found got %v", err)
+			}
+
+			// A key is deleted by its hash when its value is lost.
+			id, hash, err = klient.Universal.Keys().Create(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := klient.Universal.Keys().DeleteHashed(ctx, hash); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.Keys().Get(ctx, id); !client.IsNotFound(err) {
+				t.Errorf("expected not found got %v", err)
+			}
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	groupID, err := klient.Universal.UserGroups().Create(ctx, &model.UserGroup{Name: "readers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &model.DashboardUser{
+		FirstName:    "John",
+		LastName:     "Doe",
+		EmailAddress: "john@example.com",
+		Password:     "secret",
+		GroupID:      groupID,
+	}
+
+	id, err := klient.Universal.Users().Create(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := klient.Universal.Users().Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.EmailAddress != user.EmailAddress || got.GroupID != groupID || got.Password != "" {
+		t.Errorf("expected %+v without password got %+v", user, got)
+	}
+
+	if err := klient.Universal.Users().SetPassword(ctx, id, "rotated"); err != nil {
+		t.Fatal(err)
+	}
+
+	if users := s.Users(); users[id].Password != "rotated" {
+		t.Errorf("expected password to be set got %q", users[id].Password)
+	}
+
+	if err := klient.Universal.Users().Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := klient.Universal.UserGroups().Delete(ctx, groupID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := klient.Universal.UserGroups().Get(ctx, groupID); !client.IsNotFound(err) {
+		t.Errorf("expected not found got %v", err)
+	}
+}
+
+func TestOrganisations(t *testing.T) {
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	if _, err := klient.Universal.Organisations().Create(ctx, &model.Organisation{}); !errors.Is(err, client.ErrMissingAdminAuth) {
+		t.Fatalf("expected %v got %v", client.ErrMissingAdminAuth, err)
+	}
+
+	rctx := client.GetContext(ctx)
+	rctx.AdminAuth = fake.AdminSecret
+	ctx = client.SetContext(ctx, rctx)
+
+	id, err := klient.Universal.Organisations().Create(ctx, &model.Organisation{OwnerName: "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := klient.Universal.Organisations().Update(ctx, &model.Organisation{ID: id, OwnerName: "Acme Corp"}); err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := klient.Universal.Organisations().Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if org.OwnerName != "Acme Corp" {
+		t.Errorf("expected Acme Corp got %q", org.OwnerName)
+	}
+
+	user, err := klient.Universal.Organisations().CreateUser(ctx, &model.DashboardUser{
+		OrgID:        id,
+		EmailAddress: "operator@acme.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.ID == "" || user.AccessKey == "" || s.Users()[user.ID].AccessKey != user.AccessKey {
+		t.Errorf("expected user with its access key got %+v", user)
+	}
+
+	got, err := klient.Universal.Organisations().GetUser(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.AccessKey != user.AccessKey {
+		t.Errorf("expected access key %q got %q", user.AccessKey, got.AccessKey)
+	}
+
+	if _, err := klient.Universal.Organisations().GetUser(ctx, "missing"); !client.IsNotFound(err) {
+		t.Errorf("expected not found got %v", err)
+	}
+
+	if err := klient.Universal.Organisations().Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := klient.Universal.Organisations().Get(ctx, id); !client.IsNotFound(err) {
+		t.Errorf("expected not found got %v", err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	hook := &model.WebHookHandlerConf{
+		Name:       "default/notify",
+		Method:     "POST",
+		TargetPath: "http://notify.default.svc:8080/",
+	}
+
+	id, err := klient.Universal.Webhooks().Create(ctx, hook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook.ID = id
+	hook.HeaderMap = map[string]string{"X-Env": "test"}
+
+	if err := klient.Universal.Webhooks().Update(ctx, hook); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := klient.Universal.Webhooks().Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.TargetPath != hook.TargetPath || got.HeaderMap["X-Env"] != "test" {
+		t.Errorf("expected %+v got %+v", hook, got)
+	}
+
+	if err := klient.Universal.Webhooks().Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := klient.Universal.Webhooks().Get(ctx, id); !client.IsNotFound(err) {
+		t.Errorf("expected not found got %v", err)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			ctx := s.Context(context.Background())
+			pol := &v1alpha1.SecurityPolicySpec{}
+			pol.ID = ptr("ZGVmYXVsdC9wb2xpY3k")
+			pol.Name = "policy"
+
+			if err := klient.Universal.Portal().Policy().Create(ctx, pol); err != nil {
+				t.Fatal(err)
+			}
+
+			// The dashboard identifies policies by the _id it generated.
+			id := *pol.ID
+			if name == "dashboard" {
+				if len(*pol.MID) != 24 {
+					t.Fatalf("expected a generated object id got %q", *pol.MID)
+				}
+
+				id = *pol.MID
+			}
+
+			pol.Name = "policy updated"
+
+			if err := klient.Universal.Portal().Policy().Update(ctx, pol); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := klient.Universal.Portal().Policy().Get(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Name != pol.Name {
+				t.Errorf("expected name %q got %q", pol.Name, got.Name)
+			}
+
+			all, err := klient.Universal.Portal().Policy().All(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(all) != 1 {
+				t.Errorf("expected a single policy got %d", len(all))
+			}
+
+			if err := klient.Universal.Portal().Policy().Delete(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := klient.Universal.Portal().Policy().Get(ctx, id); !client.IsNotFound(err) {
+				t.Errorf("expected not found got %v", err)
+			}
+		})
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	key, crt := certificate(t)
+
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			ctx := s.Context(context.Background())
+
+			id, err := klient.Universal.Certificate().Upload(ctx, key, crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := pem.Decode(crt)
+			if expect := fake.Org + cert.HexSHA256(block.Bytes); id != expect {
+				t.Errorf("expected id %q got %q", expect, id)
+			}
+
+			if !klient.Universal.Certificate().Exists(ctx, id) {
+				t.Error("expected certificate to exist")
+			}
+
+			if _, err := klient.Universal.Certificate().Upload(ctx, key, crt); err == nil {
+				t.Error("expected uploading the same certificate twice to fail")
+			}
+
+			all, err := klient.Universal.Certificate().All(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(all) != 1 || all[0] != id {
+				t.Errorf("expected [%s] got %v", id, all)
+			}
+
+			meta, err := klient.Universal.Certificate().Metadata(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(meta) != 1 || meta[0].ID != id {
+				t.Fatalf("expected metadata of %s got %v", id, meta)
+			}
+
+			if meta[0].SubjectCN != "fake.tyk.io" || meta[0].Fingerprint != cert.HexSHA256(block.Bytes) {
+				t.Errorf("unexpected metadata %+v", meta[0])
+			}
+
+			if d := time.Until(meta[0].NotAfter); d <= 0 || d > time.Hour {
+				t.Errorf("expected certificate to expire within an hour, got %s", meta[0].NotAfter)
+			}
+
+			if err := klient.Universal.Certificate().Delete(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+
+			if klient.Universal.Certificate().Exists(ctx, id) {
+				t.Error("expected certificate to be deleted")
+			}
+		})
+	}
+}
+
+func TestCertificatePages(t *testing.T) {
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	// More certificates than a page of the dashboard holds.
+	for i := 0; i < 12; i++ {
+		key, crt := certificate(t)
+
+		if _, err := klient.Universal.Certificate().Upload(ctx, key, crt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := klient.Universal.Certificate().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 12 {
+		t.Errorf("expected 12 certificates got %d", len(all))
+	}
+
+	meta, err := klient.Universal.Certificate().Metadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(meta) != 12 {
+		t.Errorf("expected the metadata of 12 certificates got %d", len(meta))
+	}
+}
+
+func TestHotReload(t *testing.T) {
+	s := fake.NewGateway()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	for i := 0; i < 2; i++ {
+		if err := klient.Universal.HotReload(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := s.Reloads(); n != 2 {
+		t.Errorf("expected 2 reloads got %d", n)
+	}
+}
+
+func TestPortal(t *testing.T) {
+	s := fake.NewDashboard()
+	defer s.Close()
+
+	ctx := s.Context(context.Background())
+
+	if _, err := klient.Universal.Portal().Catalogue().Get(ctx); !client.IsNotFound(err) {
+		t.Fatalf("expected not found got %v", err)
+	}
+
+	res, err := klient.Universal.Portal().Catalogue().Create(ctx, &model.APICatalogue{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := klient.Universal.Portal().Documentation().Upload(ctx, &model.APIDocumentation{
+		Documentation: "e30=",
+		APIID:         "policy",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = klient.Universal.Portal().Catalogue().Update(ctx, &model.APICatalogue{
+		Id:   res.Message,
+		APIS: []model.APIDescription{{Name: "httpbin", Documentation: doc.Message}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cat, err := klient.Universal.Portal().Catalogue().Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cat.Id != res.Message || cat.OrgId != fake.Org || len(cat.APIS) != 1 {
+		t.Errorf("unexpected catalogue %+v", cat)
+	}
+
+	if _, err := klient.Universal.Portal().Documentation().Delete(ctx, doc.Message); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := klient.Universal.Portal().Documentation().Delete(ctx, doc.Message); !client.IsNotFound(err) {
+		t.Errorf("expected not found got %v", err)
+	}
+
+	if _, err := klient.Universal.Portal().Configuration().Get(ctx); !client.IsNotFound(err) {
+		t.Fatalf("expected not found got %v", err)
+	}
+
+	conf, err := klient.Universal.Portal().Configuration().Create(ctx, &model.PortalModelPortalConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := klient.Universal.Portal().Configuration().Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Id != conf.Message {
+		t.Errorf("expected configuration %q got %q", conf.Message, got.Id)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	for name, s := range servers(t) {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			env := s.Env()
+			env.Auth = "wrong"
+
+			ctx := client.SetContext(context.Background(), client.Context{Env: env, Log: logr.Discard()})
+
+			if _, err := klient.Universal.Api().List(ctx); !client.IsUnauthorized(err) {
+				t.Errorf("expected unauthorized got %v", err)
+			}
+		})
+	}
+}
+
+func certificate(t *testing.T) (key, crt []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake.tyk.io"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	crt = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	return key, crt
+}
+
+func ptr(s string) *string {
+	return &s
+}
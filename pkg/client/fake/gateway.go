@@ -0,0 +1,267 @@
+package fake
+
+import (
+	"net/http"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+)
+
+// gatewayMsg is the body of most gateway responses.
+type gatewayMsg struct {
+	Key     string `json:"key,omitempty"`
+	Status  string `json:"status"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message,omitempty"`
+	KeyHash string `json:"key_hash,omitempty"`
+}
+
+func gatewayError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, gatewayMsg{Status: "error", Message: msg})
+}
+
+func gatewayOK(w http.ResponseWriter, key, action string) {
+	writeJSON(w, http.StatusOK, gatewayMsg{Key: key, Status: "ok", Action: action})
+}
+
+func (s *Server) serveGateway(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("x-tyk-authorization") != Secret {
+		gatewayError(w, http.StatusForbidden, "Attempted administrative access with invalid or missing key!")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := route(r.URL.Path, "/tyk/apis/oas"); ok {
+		s.serveOAS(w, r, id,
+			func(status int, msg string) { gatewayError(w, status, msg) },
+			func(key, action string) { gatewayOK(w, key, action) },
+		)
+
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/tyk/apis"); ok {
+		s.gatewayAPIs(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/tyk/policies"); ok {
+		s.gatewayPolicies(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/tyk/certs"); ok {
+		s.gatewayCerts(w, r, id)
+		return
+	}
+
+	if id, ok := route(r.URL.Path, "/tyk/keys"); ok {
+		s.gatewayKeys(w, r, id)
+		return
+	}
+
+	if r.URL.Path == "/tyk/reload/group" && r.Method == http.MethodGet {
+		s.reloads++
+
+		if s.reloadError != "" {
+			writeJSON(w, http.StatusOK, gatewayMsg{Status: "error", Message: s.reloadError})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, gatewayMsg{Status: "ok"})
+
+		return
+	}
+
+	gatewayError(w, http.StatusNotFound, "Not found")
+}
+
+func (s *Server) gatewayAPIs(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		o := make([]*model.APIDefinitionSpec, 0, len(s.apis))
+		for _, k := range s.apiKeys() {
+			o = append(o, s.apis[k])
+		}
+
+		writeJSON(w, http.StatusOK, o)
+	case id == "" && r.Method == http.MethodPost:
+		var api model.APIDefinitionSpec
+		if err := readJSON(r, &api); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		if str(api.APIID) == "" {
+			api.APIID = ptr(uuid())
+		}
+
+		action := "added"
+		if _, ok := s.apis[*api.APIID]; ok {
+			action = "modified"
+		}
+
+		s.apis[*api.APIID] = &api
+		gatewayOK(w, *api.APIID, action)
+	case id == "":
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.apis[id] == nil:
+		gatewayError(w, http.StatusNotFound, "API not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.apis[id])
+	case r.Method == http.MethodPut:
+		var api model.APIDefinitionSpec
+		if err := readJSON(r, &api); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		api.APIID = ptr(id)
+		s.apis[id] = &api
+		gatewayOK(w, id, "modified")
+	case r.Method == http.MethodDelete:
+		delete(s.apis, id)
+		gatewayOK(w, id, "deleted")
+	default:
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) gatewayPolicies(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		o := make([]v1alpha1.SecurityPolicySpec, 0, len(s.policies))
+		for _, k := range s.policyKeys() {
+			o = append(o, *s.policies[k])
+		}
+
+		writeJSON(w, http.StatusOK, struct {
+			Data  []v1alpha1.SecurityPolicySpec `json:"data"`
+			Pages int                           `json:"pages"`
+		}{Data: o, Pages: 1})
+	case id == "" && r.Method == http.MethodPost:
+		var pol v1alpha1.SecurityPolicySpec
+		if err := readJSON(r, &pol); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		if str(pol.ID) == "" {
+			pol.ID = ptr(uuid())
+		}
+
+		action := "added"
+		if _, ok := s.policies[*pol.ID]; ok {
+			action = "modified"
+		}
+
+		s.policies[*pol.ID] = &pol
+		gatewayOK(w, *pol.ID, action)
+	case id == "":
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.policies[id] == nil:
+		gatewayError(w, http.StatusNotFound, "Policy not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.policies[id])
+	case r.Method == http.MethodPut:
+		var pol v1alpha1.SecurityPolicySpec
+		if err := readJSON(r, &pol); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		pol.ID = ptr(id)
+		s.policies[id] = &pol
+		gatewayOK(w, id, "modified")
+	case r.Method == http.MethodDelete:
+		delete(s.policies, id)
+		gatewayOK(w, id, "deleted")
+	default:
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) gatewayCerts(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet && r.URL.Query().Get("mode") == "detailed":
+		writeJSON(w, http.StatusOK, struct {
+			Certs []model.CertificateMeta `json:"certs"`
+		}{Certs: s.certMeta()})
+	case id == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, struct {
+			Certs []string `json:"certs"`
+		}{Certs: keys(s.certs)})
+	case id == "" && r.Method == http.MethodPost:
+		id, exists, err := s.storeCert(r)
+		if err != nil {
+			gatewayError(w, http.StatusBadRequest, "Malformed certificate")
+			return
+		}
+
+		if exists {
+			gatewayError(w, http.StatusForbidden, "Certificate with "+id+" ID already exists")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, certResponse{ID: id, Message: "Certificate added", Status: "ok"})
+	case id == "":
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.certs[id] == nil:
+		gatewayError(w, http.StatusNotFound, "Certificate not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, certResponse{ID: id})
+	case r.Method == http.MethodDelete:
+		delete(s.certs, id)
+		writeJSON(w, http.StatusOK, gatewayMsg{Status: "ok", Message: "removed"})
+	default:
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+func (s *Server) gatewayKeys(w http.ResponseWriter, r *http.Request, id string) {
+	id = s.keyID(r, id)
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var key model.SessionState
+		if err := readJSON(r, &key); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		id = key.OrgID + uuid()
+		s.keys[id] = &key
+		writeJSON(w, http.StatusOK, gatewayMsg{Key: id, Status: "ok", Action: "added", KeyHash: keyHash(id)})
+	case id == "":
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	case s.keys[id] == nil:
+		gatewayError(w, http.StatusNotFound, "Key not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.keys[id])
+	case r.Method == http.MethodPut:
+		var key model.SessionState
+		if err := readJSON(r, &key); err != nil {
+			gatewayError(w, http.StatusBadRequest, "Request malformed")
+			return
+		}
+
+		s.keys[id] = &key
+		gatewayOK(w, id, "modified")
+	case r.Method == http.MethodDelete && s.keyDeleteError != "":
+		gatewayError(w, http.StatusForbidden, s.keyDeleteError)
+	case r.Method == http.MethodDelete:
+		delete(s.keys, id)
+		gatewayOK(w, id, "deleted")
+	default:
+		gatewayError(w, http.StatusMethodNotAllowed, "Method not supported")
+	}
+}
+
+// certResponse is the body returned when a certificate is uploaded or read.
+type certResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
@@ -20,25 +20,33 @@ func (a Api) List(ctx context.Context, options ...model.ListAPIOptions) (*model.
 	return &o, nil
 }
 
+// Get returns the API whose id is apiID. When there are several gateway nodes,
+// it fails with client.ErrOutOfSync unless they all serve the same definition.
 func (a Api) Get(ctx context.Context, apiID string) (*model.APIDefinitionSpec, error) {
-	var spec model.APIDefinitionSpec
+	return client.ReadNodes(ctx, func(ctx context.Context) (*model.APIDefinitionSpec, error) {
+		var spec model.APIDefinitionSpec
 
-	err := client.Data(&spec)(client.Get(ctx, client.Join(endpointAPIs, apiID), nil))
-	if err != nil {
-		return nil, err
-	}
+		err := client.Data(&spec)(client.Get(ctx, client.Join(endpointAPIs, apiID), nil))
+		if err != nil {
+			return nil, err
+		}
 
-	return &spec, nil
+		return &spec, nil
+	})
 }
 
 func (a Api) Create(ctx context.Context, def *model.APIDefinitionSpec) (*model.Result, error) {
 	return a.createOrUpdate(ctx, def)
 }
 
+// createOrUpdate stores def on every gateway node, the gateway replaces the API
+// if it already exists.
 func (a Api) createOrUpdate(ctx context.Context, def *model.APIDefinitionSpec) (*model.Result, error) {
 	var o model.Result
 
-	err := client.Data(&o)(client.PostJSON(ctx, endpointAPIs, def))
+	err := client.EachNode(ctx, func(ctx context.Context) error {
+		return client.Data(&o)(client.PostJSON(ctx, endpointAPIs, def))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +61,9 @@ func (a Api) Update(ctx context.Context, def *model.APIDefinitionSpec) (*model.R
 func (a Api) Delete(ctx context.Context, id string) (*model.Result, error) {
 	var o model.Result
 
-	err := client.Data(&o)(client.Delete(ctx, client.Join(endpointAPIs, id), nil))
+	err := client.DeleteEachNode(ctx, func(ctx context.Context) error {
+		return client.Data(&o)(client.Delete(ctx, client.Join(endpointAPIs, id), nil))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -7,7 +7,10 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/cert"
 	"github.com/TykTechnologies/tyk-operator/pkg/client"
 )
 
@@ -40,7 +43,50 @@ func (c Cert) All(ctx context.Context) ([]string, error) {
 	return o.CertIDs, nil
 }
 
+// CertificateMetaList is the detailed list of certificates.
+type CertificateMetaList struct {
+	Certs []model.CertificateMeta `json:"certs"`
+}
+
+// Metadata returns the metadata of all certificates
+func (c Cert) Metadata(ctx context.Context) ([]model.CertificateMeta, error) {
+	res, err := client.Get(ctx, endpointCerts, nil, client.AddQuery(url.Values{"mode": {"detailed"}}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var o CertificateMetaList
+
+	if err := client.JSON(res, &o); err != nil {
+		return nil, err
+	}
+
+	for i := range o.Certs {
+		if o.Certs[i].Fingerprint == "" {
+			o.Certs[i].Fingerprint = cert.FingerprintFromID(o.Certs[i].ID)
+		}
+	}
+
+	return o.Certs, nil
+}
+
+// Exists returns true if the certificate whose id is id exists on every gateway
+// node.
 func (c Cert) Exists(ctx context.Context, id string) bool {
+	err := client.EachNode(ctx, func(ctx context.Context) error {
+		if !c.exists(ctx, id) {
+			return client.ErrFailed
+		}
+
+		return nil
+	})
+
+	return err == nil
+}
+
+func (c Cert) exists(ctx context.Context, id string) bool {
 	res, err := client.Get(ctx, client.Join(endpointCerts, id), nil)
 	if err != nil {
 		client.LError(ctx, err, "failed to get certificate")
@@ -57,7 +103,14 @@ func (c Cert) Exists(ctx context.Context, id string) bool {
 	return true
 }
 
+// Delete deletes the certificate whose id is id from every gateway node.
 func (c Cert) Delete(ctx context.Context, id string) error {
+	return client.DeleteEachNode(ctx, func(ctx context.Context) error {
+		return c.delete(ctx, id)
+	})
+}
+
+func (c Cert) delete(ctx context.Context, id string) error {
 	res, err := client.Delete(ctx, client.Join(endpointCerts, id), nil)
 	if err != nil {
 		return err
@@ -72,7 +125,38 @@ func (c Cert) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Upload uploads the certificate crt and its private key to every gateway node.
+// When there are several nodes, the nodes already holding it are skipped.
 func (c Cert) Upload(ctx context.Context, key, crt []byte) (id string, err error) {
+	rctx := client.GetContext(ctx)
+	if len(rctx.Nodes) == 0 {
+		return c.upload(ctx, key, crt)
+	}
+
+	fingerprint, err := cert.CalculateFingerPrint(crt)
+	if err != nil {
+		return "", err
+	}
+
+	id = rctx.Env.Org + fingerprint
+
+	err = client.EachNode(ctx, func(ctx context.Context) error {
+		if c.exists(ctx, id) {
+			return nil
+		}
+
+		_, err := c.upload(ctx, key, crt)
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (c Cert) upload(ctx context.Context, key, crt []byte) (id string, err error) {
 	combined := make([]byte, 0)
 	combined = append(combined, key...)
 	combined = append(combined, crt...)
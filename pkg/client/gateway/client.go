@@ -30,11 +30,63 @@ func (c Client) Api() universal.Api {
 	return Api{}
 }
 
+func (c Client) OAS() universal.OAS {
+	return OAS{}
+}
+
+func (c Client) Keys() universal.Keys {
+	return Keys{}
+}
+
+func (c Client) Users() universal.Users {
+	return Users{}
+}
+
+func (c Client) UserGroups() universal.UserGroups {
+	return UserGroups{}
+}
+
+func (c Client) Organisations() universal.Organisations {
+	return Organisations{}
+}
+
+func (c Client) Webhooks() universal.Webhooks {
+	return Webhooks{}
+}
+
 func (c Client) Portal() universal.Portal {
 	return Portal{}
 }
 
+// HotReload reloads the gateway group. When the context has a hot reload
+// window, the reload is batched with the other reloads requested during that
+// window and HotReload returns without waiting for it, see HotReloadResult.
 func (c Client) HotReload(ctx context.Context) error {
+	rctx := client.GetContext(ctx)
+
+	if window := rctx.Env.HotReloadWindow; window != nil && window.Duration > 0 {
+		reloaderFor(rctx).schedule(ctx, window.Duration)
+		return nil
+	}
+
+	return hotReload(ctx)
+}
+
+// HotReloadResult returns the error of the latest batched hot reload requested
+// for the resource of the context, client.ErrHotReloadPending while it is not
+// done. It returns nil if hot reloads are not batched.
+func (c Client) HotReloadResult(ctx context.Context) error {
+	return reloaderFor(client.GetContext(ctx)).result(ctx)
+}
+
+// hotReload reloads the gateway group, or every gateway node.
+func hotReload(ctx context.Context) error {
+	return client.EachNode(ctx, reloadGroup)
+}
+
+func reloadGroup(ctx context.Context) (err error) {
+	defer func() { client.ObserveHotReload(err) }()
+
 	res, err := client.Get(ctx, endpointReload, nil)
 	if err != nil {
 		return err
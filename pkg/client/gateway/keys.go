@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const endpointKeys = "/tyk/keys"
+
+var _ universal.Keys = Keys{}
+
+type Keys struct{}
+
+func (Keys) Create(ctx context.Context, key *model.SessionState) (string, string, error) {
+	res, err := client.Result(client.PostJSON(ctx, endpointKeys, key))
+	if err != nil {
+		return "", "", err
+	}
+
+	return res.Key, res.KeyHash, nil
+}
+
+func (Keys) Get(ctx context.Context, id string) (*model.SessionState, error) {
+	var o model.SessionState
+
+	if err := client.Data(&o)(client.Get(ctx, client.Join(endpointKeys, id), nil)); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (Keys) Update(ctx context.Context, id string, key *model.SessionState) error {
+	_, err := client.Result(client.PutJSON(ctx, client.Join(endpointKeys, id), key))
+	return err
+}
+
+func (Keys) Delete(ctx context.Context, id string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointKeys, id), nil))
+	return err
+}
+
+func (Keys) DeleteHashed(ctx context.Context, hash string) error {
+	_, err := client.Result(client.Delete(ctx, client.Join(endpointKeys, hash), nil,
+		client.AddQuery(url.Values{"hashed": {"true"}}),
+	))
+
+	return err
+}
@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+const endpointOAS = "/tyk/apis/oas"
+
+var _ universal.OAS = OAS{}
+
+type OAS struct{}
+
+func (OAS) Create(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	return client.Result(client.PostJSON(ctx, endpointOAS, def))
+}
+
+func (OAS) Get(ctx context.Context, id string) (model.TykOAS, error) {
+	var o model.TykOAS
+
+	err := client.Data(&o)(client.Get(ctx, client.Join(endpointOAS, id), nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (OAS) Update(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	if def.ID() == "" {
+		return nil, client.ErrMissingAPIID
+	}
+
+	return client.Result(client.PutJSON(ctx, client.Join(endpointOAS, def.ID()), def))
+}
+
+func (OAS) Delete(ctx context.Context, id string) (*model.Result, error) {
+	return client.Result(client.Delete(ctx, client.Join(endpointOAS, id), nil))
+}
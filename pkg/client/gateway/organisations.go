@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+var _ universal.Organisations = Organisations{}
+
+// Organisations are only managed by the dashboard.
+type Organisations struct{}
+
+func (Organisations) Create(ctx context.Context, org *model.Organisation) (string, error) {
+	return "", client.ErrTODO
+}
+
+func (Organisations) Get(ctx context.Context, id string) (*model.Organisation, error) {
+	return nil, client.ErrTODO
+}
+
+func (Organisations) Update(ctx context.Context, org *model.Organisation) error {
+	return client.ErrTODO
+}
+
+func (Organisations) Delete(ctx context.Context, id string) error {
+	return client.ErrTODO
+}
+
+func (Organisations) CreateUser(ctx context.Context, user *model.DashboardUser) (*model.DashboardUser, error) {
+	return nil, client.ErrTODO
+}
+
+func (Organisations) GetUser(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return nil, client.ErrTODO
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// reloadBatch is a single group reload covering every request made while it
+// was pending.
+type reloadBatch struct {
+	done chan struct{}
+	err  error
+}
+
+// reloader coalesces the hot reloads of a gateway group.
+type reloader struct {
+	mu sync.Mutex
+
+	// pending is the batch collecting requests, nil if none is.
+	pending *reloadBatch
+
+	// last is the latest batch, pending, in flight or done.
+	last *reloadBatch
+
+	// joined are the latest batches joined by the reconciled resources, by
+	// resource, until their result is read.
+	joined map[string]*reloadBatch
+}
+
+var reloaders = struct {
+	sync.Mutex
+	m map[string]*reloader
+}{m: make(map[string]*reloader)}
+
+// reloaderFor returns the reloader of the gateways reloaded with rctx. Requests
+// only share a batch if they reload the same gateways with the same
+// credentials.
+func reloaderFor(rctx client.Context) *reloader {
+	key := strings.Join(append([]string{rctx.Env.URL, rctx.Env.Auth}, rctx.Nodes...), "\n")
+
+	reloaders.Lock()
+	defer reloaders.Unlock()
+
+	r, ok := reloaders.m[key]
+	if !ok {
+		r = &reloader{}
+		reloaders.m[key] = r
+	}
+
+	return r
+}
+
+// schedule adds a reload request to the pending batch. If there is none, a new
+// batch is started and sent once window elapsed.
+//
+// A batch stops collecting requests right before it is sent, so the reload
+// that covers a request always starts after the request was made.
+func (r *reloader) schedule(ctx context.Context, window time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending != nil {
+		r.join(ctx, r.pending)
+		return
+	}
+
+	b := &reloadBatch{done: make(chan struct{})}
+	r.pending, r.last = b, b
+	r.join(ctx, b)
+
+	// The batch belongs to no reconciliation: it is neither cancelled with the
+	// one that started it nor attributed to the resource it reconciles.
+	rctx := client.GetContext(ctx)
+	rctx.Object = nil
+	ctx = client.SetContext(context.Background(), rctx)
+
+	time.AfterFunc(window, func() {
+		r.mu.Lock()
+		r.pending = nil
+		r.mu.Unlock()
+
+		b.err = hotReload(ctx)
+		if b.err != nil {
+			client.LError(ctx, b.err, "Batched hot reload failed")
+		}
+
+		close(b.done)
+	})
+}
+
+// join records that the resource reconciled with ctx joined b. Resources being
+// deleted are not recorded, their result would never be read.
+func (r *reloader) join(ctx context.Context, b *reloadBatch) {
+	key, ok := objectKey(client.GetContext(ctx).Object)
+	if !ok {
+		return
+	}
+
+	if r.joined == nil {
+		r.joined = make(map[string]*reloadBatch)
+	}
+
+	r.joined[key] = b
+}
+
+// result returns the error of the latest batch joined by the resource
+// reconciled with ctx, client.ErrHotReloadPending while it is not done yet. It
+// returns nil if the resource joined no batch since its result was last read.
+func (r *reloader) result(ctx context.Context) error {
+	key, ok := objectKey(client.GetContext(ctx).Object)
+	if !ok {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.joined[key]
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-b.done:
+		delete(r.joined, key)
+		return b.err
+	default:
+		return client.ErrHotReloadPending
+	}
+}
+
+// objectKey returns the key of o in reloader.joined, false if o is not a
+// resource or is being deleted.
+func objectKey(o runtime.Object) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+
+	m, err := meta.Accessor(o)
+	if err != nil || m.GetDeletionTimestamp() != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%T %s/%s", o, m.GetNamespace(), m.GetName()), true
+}
+
+// wait waits for the latest batch to be sent and returns its error.
+func (r *reloader) wait(ctx context.Context) error {
+	r.mu.Lock()
+	b := r.last
+	r.mu.Unlock()
+
+	if b == nil {
+		return nil
+	}
+
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
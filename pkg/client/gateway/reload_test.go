@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func reloadServer(t *testing.T, reloads *int32) string {
+	t.Helper()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != endpointReload {
+			t.Errorf("unexpected call to %s", r.URL.Path)
+		}
+
+		atomic.AddInt32(reloads, 1)
+		w.Write([]byte(`{"status":"ok"}`)) //nolint:errcheck
+	}))
+	t.Cleanup(svr.Close)
+
+	return svr.URL
+}
+
+func reloadContext(url string, window time.Duration) context.Context {
+	env := environment.Env{Environment: v1alpha1.Environment{Mode: "ce", URL: url}}
+	if window > 0 {
+		env.HotReloadWindow = &metav1.Duration{Duration: window}
+	}
+
+	return client.SetContext(context.Background(), client.Context{Env: env, Log: logr.Discard()})
+}
+
+// waitHotReload waits for the latest batched hot reload of the gateways of ctx.
+func waitHotReload(ctx context.Context) error {
+	return reloaderFor(client.GetContext(ctx)).wait(ctx)
+}
+
+func TestHotReloadBatching(t *testing.T) {
+	var reloads int32
+
+	ctx := reloadContext(reloadServer(t, &reloads), 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := (Client{}).HotReload(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&reloads); got != 0 {
+		t.Errorf("expected reloads to be batched, got %d before the window elapsed", got)
+	}
+
+	if err := waitHotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(&reloads); got != 1 {
+		t.Errorf("expected a single reload got %d", got)
+	}
+
+	// A request made after the batch was sent is covered by a new reload.
+	if err := (Client{}).HotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := waitHotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(&reloads); got != 2 {
+		t.Errorf("expected 2 reloads got %d", got)
+	}
+}
+
+func TestHotReloadWithoutWindow(t *testing.T) {
+	var reloads int32
+
+	ctx := reloadContext(reloadServer(t, &reloads), 0)
+
+	for i := 0; i < 3; i++ {
+		if err := (Client{}).HotReload(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&reloads); got != 3 {
+		t.Errorf("expected every call to reload got %d", got)
+	}
+
+	if err := waitHotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitHotReloadCancelled(t *testing.T) {
+	var reloads int32
+
+	ctx := reloadContext(reloadServer(t, &reloads), time.Hour)
+
+	if err := (Client{}).HotReload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if err := waitHotReload(wctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestHotReloadResult(t *testing.T) {
+	var reloads int32
+
+	ctx := reloadContext(reloadServer(t, &reloads), 20*time.Millisecond)
+
+	withObject := func(name string) context.Context {
+		rctx := client.GetContext(ctx)
+		rctx.Object = &v1alpha1.ApiDefinition{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+
+		return client.SetContext(ctx, rctx)
+	}
+
+	a, b := withObject("a"), withObject("b")
+
+	if err := (Client{}).HotReload(a); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (Client{}).HotReloadResult(a); err != client.ErrHotReloadPending {
+		t.Errorf("expected %v got %v", client.ErrHotReloadPending, err)
+	}
+
+	// b requested no reload.
+	if err := (Client{}).HotReloadResult(b); err != nil {
+		t.Errorf("expected no result got %v", err)
+	}
+
+	if err := waitHotReload(a); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (Client{}).HotReloadResult(a); err != nil {
+		t.Errorf("expected the reload to succeed got %v", err)
+	}
+
+	// The result is only read once.
+	if err := (Client{}).HotReloadResult(a); err != nil {
+		t.Errorf("expected no result got %v", err)
+	}
+}
+
+func TestHotReloadBatchPerCredentials(t *testing.T) {
+	var reloads int32
+
+	var mu sync.Mutex
+
+	auths := map[string]bool{}
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths[r.Header.Get("x-tyk-authorization")] = true
+		mu.Unlock()
+
+		atomic.AddInt32(&reloads, 1)
+		w.Write([]byte(`{"status":"ok"}`)) //nolint:errcheck
+	}))
+	defer svr.Close()
+
+	withAuth := func(auth string) context.Context {
+		ctx := reloadContext(svr.URL, 20*time.Millisecond)
+		rctx := client.GetContext(ctx)
+		rctx.Env.Auth = auth
+
+		return client.SetContext(ctx, rctx)
+	}
+
+	// The reconciliation starting the batch is cancelled before it is sent.
+	a, cancel := context.WithCancel(withAuth("a"))
+	b := withAuth("b")
+
+	for _, ctx := range []context.Context{a, b} {
+		if err := (Client{}).HotReload(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cancel()
+
+	for _, ctx := range []context.Context{withAuth("a"), b} {
+		if err := waitHotReload(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&reloads); got != 2 {
+		t.Errorf("expected a reload per credentials got %d", got)
+	}
+
+	if !auths["a"] || !auths["b"] {
+		t.Errorf("expected each batch to be sent with its own credentials got %v", auths)
+	}
+}
@@ -32,7 +32,15 @@ func (a SecurityPolicy) All(ctx context.Context) ([]v1.SecurityPolicySpec, error
 	return response.Policies, nil
 }
 
+// Get returns the policy whose id is id. When there are several gateway nodes,
+// it fails with client.ErrOutOfSync unless they all serve the same policy.
 func (a SecurityPolicy) Get(ctx context.Context, id string) (*v1.SecurityPolicySpec, error) {
+	return client.ReadNodes(ctx, func(ctx context.Context) (*v1.SecurityPolicySpec, error) {
+		return a.get(ctx, id)
+	})
+}
+
+func (a SecurityPolicy) get(ctx context.Context, id string) (*v1.SecurityPolicySpec, error) {
 	res, err := client.Get(ctx, client.Join(endpointPolicies, id), nil)
 	if err != nil {
 		return nil, err
@@ -49,7 +57,14 @@ func (a SecurityPolicy) Get(ctx context.Context, id string) (*v1.SecurityPolicyS
 	return &o, nil
 }
 
+// Create creates def on every gateway node.
 func (a SecurityPolicy) Create(ctx context.Context, def *v1.SecurityPolicySpec) error {
+	return client.EachNode(ctx, func(ctx context.Context) error {
+		return a.create(ctx, def)
+	})
+}
+
+func (a SecurityPolicy) create(ctx context.Context, def *v1.SecurityPolicySpec) error {
 	res, err := client.PostJSON(ctx, client.Join(endpointPolicies), def)
 	if err != nil {
 		return err
@@ -78,7 +93,22 @@ func (a SecurityPolicy) Create(ctx context.Context, def *v1.SecurityPolicySpec)
 	}
 }
 
+// Update updates def on every gateway node. When there are several nodes, the
+// policy is created again on the nodes that lost it.
 func (a SecurityPolicy) Update(ctx context.Context, def *v1.SecurityPolicySpec) error {
+	recreate := len(client.GetContext(ctx).Nodes) != 0
+
+	return client.EachNode(ctx, func(ctx context.Context) error {
+		err := a.update(ctx, def)
+		if recreate && client.IsNotFound(err) {
+			return a.create(ctx, def)
+		}
+
+		return err
+	})
+}
+
+func (a SecurityPolicy) update(ctx context.Context, def *v1.SecurityPolicySpec) error {
 	res, err := client.PutJSON(ctx, client.Join(endpointPolicies, *def.ID), def)
 	if err != nil {
 		return err
@@ -93,7 +123,14 @@ func (a SecurityPolicy) Update(ctx context.Context, def *v1.SecurityPolicySpec)
 	return nil
 }
 
+// Delete deletes the policy whose id is id from every gateway node.
 func (a SecurityPolicy) Delete(ctx context.Context, id string) error {
+	return client.DeleteEachNode(ctx, func(ctx context.Context) error {
+		return a.delete(ctx, id)
+	})
+}
+
+func (a SecurityPolicy) delete(ctx context.Context, id string) error {
 	res, err := client.Delete(ctx, client.Join(endpointPolicies, id), nil)
 	if err != nil {
 		return err
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+var (
+	_ universal.Users      = Users{}
+	_ universal.UserGroups = UserGroups{}
+)
+
+// Users are only managed by the dashboard.
+type Users struct{}
+
+func (Users) Create(ctx context.Context, user *model.DashboardUser) (string, error) {
+	return "", client.ErrTODO
+}
+
+func (Users) Get(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return nil, client.ErrTODO
+}
+
+func (Users) Update(ctx context.Context, user *model.DashboardUser) error {
+	return client.ErrTODO
+}
+
+func (Users) Delete(ctx context.Context, id string) error {
+	return client.ErrTODO
+}
+
+func (Users) SetPassword(ctx context.Context, id, password string) error {
+	return client.ErrTODO
+}
+
+// UserGroups are only managed by the dashboard.
+type UserGroups struct{}
+
+func (UserGroups) Create(ctx context.Context, group *model.UserGroup) (string, error) {
+	return "", client.ErrTODO
+}
+
+func (UserGroups) Get(ctx context.Context, id string) (*model.UserGroup, error) {
+	return nil, client.ErrTODO
+}
+
+func (UserGroups) Update(ctx context.Context, group *model.UserGroup) error {
+	return client.ErrTODO
+}
+
+func (UserGroups) Delete(ctx context.Context, id string) error {
+	return client.ErrTODO
+}
@@ -0,0 +1,31 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+	"github.com/TykTechnologies/tyk-operator/pkg/client"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
+)
+
+var _ universal.Webhooks = Webhooks{}
+
+// Webhooks are only stored by the dashboard, the gateway reads them from the
+// event handlers of the APIs.
+type Webhooks struct{}
+
+func (Webhooks) Create(ctx context.Context, hook *model.WebHookHandlerConf) (string, error) {
+	return "", client.ErrTODO
+}
+
+func (Webhooks) Get(ctx context.Context, id string) (*model.WebHookHandlerConf, error) {
+	return nil, client.ErrTODO
+}
+
+func (Webhooks) Update(ctx context.Context, hook *model.WebHookHandlerConf) error {
+	return client.ErrTODO
+}
+
+func (Webhooks) Delete(ctx context.Context, id string) error {
+	return client.ErrTODO
+}
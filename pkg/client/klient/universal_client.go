@@ -7,6 +7,7 @@ import (
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
 	"github.com/TykTechnologies/tyk-operator/pkg/client"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/dashboard"
+	"github.com/TykTechnologies/tyk-operator/pkg/client/dryrun"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/gateway"
 	"github.com/TykTechnologies/tyk-operator/pkg/client/universal"
 )
@@ -15,13 +16,23 @@ var _ universal.Client = (*Client)(nil)
 
 var Universal = Client{}
 
+// DryRunRecorder records the changes to Tyk of contexts in dry run mode as
+// events, see dryrun.Client.
+var DryRunRecorder *dryrun.Recorder
+
 func get(ctx context.Context) universal.Client {
 	r := client.GetContext(ctx)
+
+	var c universal.Client = gateway.Client{}
 	if r.Env.Mode == "pro" {
-		return dashboard.Client{}
+		c = dashboard.Client{}
+	}
+
+	if r.Env.DryRun {
+		return dryrun.Client{Client: c, Recorder: DryRunRecorder}
 	}
 
-	return gateway.Client{}
+	return c
 }
 
 // Client implements universal.Client but picks the correct client dynamically based on context.Context
@@ -31,10 +42,38 @@ func (Client) HotReload(ctx context.Context) error {
 	return get(ctx).HotReload(ctx)
 }
 
+func (Client) HotReloadResult(ctx context.Context) error {
+	return get(ctx).HotReloadResult(ctx)
+}
+
 func (Client) Api() universal.Api {
 	return Api{}
 }
 
+func (Client) OAS() universal.OAS {
+	return OAS{}
+}
+
+func (Client) Keys() universal.Keys {
+	return Keys{}
+}
+
+func (Client) Users() universal.Users {
+	return Users{}
+}
+
+func (Client) UserGroups() universal.UserGroups {
+	return UserGroups{}
+}
+
+func (Client) Organisations() universal.Organisations {
+	return Organisations{}
+}
+
+func (Client) Webhooks() universal.Webhooks {
+	return Webhooks{}
+}
+
 func (Client) Portal() universal.Portal {
 	return Portal{}
 }
@@ -65,6 +104,130 @@ func (Api) List(ctx context.Context, options ...model.ListAPIOptions) (*model.AP
 	return get(ctx).Api().List(ctx, options...)
 }
 
+type OAS struct{}
+
+func (OAS) Create(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	return get(ctx).OAS().Create(ctx, def)
+}
+
+func (OAS) Get(ctx context.Context, id string) (model.TykOAS, error) {
+	return get(ctx).OAS().Get(ctx, id)
+}
+
+func (OAS) Update(ctx context.Context, def model.TykOAS) (*model.Result, error) {
+	return get(ctx).OAS().Update(ctx, def)
+}
+
+func (OAS) Delete(ctx context.Context, id string) (*model.Result, error) {
+	return get(ctx).OAS().Delete(ctx, id)
+}
+
+type Keys struct{}
+
+func (Keys) Create(ctx context.Context, key *model.SessionState) (string, string, error) {
+	return get(ctx).Keys().Create(ctx, key)
+}
+
+func (Keys) Get(ctx context.Context, id string) (*model.SessionState, error) {
+	return get(ctx).Keys().Get(ctx, id)
+}
+
+func (Keys) Update(ctx context.Context, id string, key *model.SessionState) error {
+	return get(ctx).Keys().Update(ctx, id, key)
+}
+
+func (Keys) Delete(ctx context.Context, id string) error {
+	return get(ctx).Keys().Delete(ctx, id)
+}
+
+func (Keys) DeleteHashed(ctx context.Context, hash string) error {
+	return get(ctx).Keys().DeleteHashed(ctx, hash)
+}
+
+type Users struct{}
+
+func (Users) Create(ctx context.Context, user *model.DashboardUser) (string, error) {
+	return get(ctx).Users().Create(ctx, user)
+}
+
+func (Users) Get(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return get(ctx).Users().Get(ctx, id)
+}
+
+func (Users) Update(ctx context.Context, user *model.DashboardUser) error {
+	return get(ctx).Users().Update(ctx, user)
+}
+
+func (Users) Delete(ctx context.Context, id string) error {
+	return get(ctx).Users().Delete(ctx, id)
+}
+
+func (Users) SetPassword(ctx context.Context, id, password string) error {
+	return get(ctx).Users().SetPassword(ctx, id, password)
+}
+
+type UserGroups struct{}
+
+func (UserGroups) Create(ctx context.Context, group *model.UserGroup) (string, error) {
+	return get(ctx).UserGroups().Create(ctx, group)
+}
+
+func (UserGroups) Get(ctx context.Context, id string) (*model.UserGroup, error) {
+	return get(ctx).UserGroups().Get(ctx, id)
+}
+
+func (UserGroups) Update(ctx context.Context, group *model.UserGroup) error {
+	return get(ctx).UserGroups().Update(ctx, group)
+}
+
+func (UserGroups) Delete(ctx context.Context, id string) error {
+	return get(ctx).UserGroups().Delete(ctx, id)
+}
+
+type Organisations struct{}
+
+func (Organisations) Create(ctx context.Context, org *model.Organisation) (string, error) {
+	return get(ctx).Organisations().Create(ctx, org)
+}
+
+func (Organisations) Get(ctx context.Context, id string) (*model.Organisation, error) {
+	return get(ctx).Organisations().Get(ctx, id)
+}
+
+func (Organisations) Update(ctx context.Context, org *model.Organisation) error {
+	return get(ctx).Organisations().Update(ctx, org)
+}
+
+func (Organisations) Delete(ctx context.Context, id string) error {
+	return get(ctx).Organisations().Delete(ctx, id)
+}
+
+func (Organisations) CreateUser(ctx context.Context, user *model.DashboardUser) (*model.DashboardUser, error) {
+	return get(ctx).Organisations().CreateUser(ctx, user)
+}
+
+func (Organisations) GetUser(ctx context.Context, id string) (*model.DashboardUser, error) {
+	return get(ctx).Organisations().GetUser(ctx, id)
+}
+
+type Webhooks struct{}
+
+func (Webhooks) Create(ctx context.Context, hook *model.WebHookHandlerConf) (string, error) {
+	return get(ctx).Webhooks().Create(ctx, hook)
+}
+
+func (Webhooks) Get(ctx context.Context, id string) (*model.WebHookHandlerConf, error) {
+	return get(ctx).Webhooks().Get(ctx, id)
+}
+
+func (Webhooks) Update(ctx context.Context, hook *model.WebHookHandlerConf) error {
+	return get(ctx).Webhooks().Update(ctx, hook)
+}
+
+func (Webhooks) Delete(ctx context.Context, id string) error {
+	return get(ctx).Webhooks().Delete(ctx, id)
+}
+
 type Portal struct{}
 
 func (Portal) Policy() universal.Policy {
@@ -153,6 +316,10 @@ func (Certificate) All(ctx context.Context) ([]string, error) {
 	return get(ctx).Certificate().All(ctx)
 }
 
+func (Certificate) Metadata(ctx context.Context) ([]model.CertificateMeta, error) {
+	return get(ctx).Certificate().Metadata(ctx)
+}
+
 func (Certificate) Upload(ctx context.Context, key, crt []byte) (id string, err error) {
 	return get(ctx).Certificate().Upload(ctx, key, crt)
 }
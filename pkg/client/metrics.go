@@ -0,0 +1,139 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// statusError is the status class of calls that failed before Tyk answered,
+	// for instance because of a connection refused or a timeout.
+	statusError = "error"
+
+	// statusCircuitOpen is the status class of calls that were short-circuited
+	// by the circuit breaker and never reached Tyk.
+	statusCircuitOpen = "circuit_open"
+
+	// endpointOther is the endpoint label of paths that are not in
+	// endpointTemplates. It keeps the label cardinality bounded.
+	endpointOther = "other"
+)
+
+var (
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tyk_client_request_duration_seconds",
+			Help:    "Duration of requests sent to the Tyk Gateway or Dashboard.",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"mode", "endpoint", "method", "status"},
+	)
+
+	requestTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tyk_client_requests_total",
+			Help: "Total number of requests sent to the Tyk Gateway or Dashboard.",
+		},
+		[]string{"mode", "endpoint", "method", "status"},
+	)
+
+	hotReloadTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tyk_client_hot_reloads_total",
+			Help: "Total number of hot reloads requested from the Tyk Gateway.",
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestDuration, requestTotal, hotReloadTotal)
+}
+
+// endpointTemplates lists the collections exposed by the Tyk Gateway and
+// Dashboard APIs. Any path segment following one of them is an object ID and is
+// replaced by {id} in metric labels.
+var endpointTemplates = []string{
+	"/tyk/apis",
+	"/tyk/apis/oas",
+	"/tyk/certs",
+	"/tyk/keys",
+	"/tyk/policies",
+	"/tyk/reload/group",
+	"/api/apis",
+	"/api/apis/oas",
+	"/api/certs",
+	"/api/hooks",
+	"/api/keys",
+	"/api/portal/catalogue",
+	"/api/portal/configuration",
+	"/api/portal/documentation",
+	"/api/portal/policies",
+	"/api/usergroups",
+	"/api/users",
+	"/admin/organisations",
+	"/admin/users",
+}
+
+// endpointTemplate returns the endpoint label of path, for instance
+// /api/apis/{id} for /api/apis/5e0fac4845bb46c77543be28.
+func endpointTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i != -1 {
+		path = path[:i]
+	}
+
+	path = "/" + strings.Trim(path, "/")
+
+	match := ""
+
+	for _, e := range endpointTemplates {
+		if (path == e || strings.HasPrefix(path, e+"/")) && len(e) > len(match) {
+			match = e
+		}
+	}
+
+	switch {
+	case match == "":
+		return endpointOther
+	case path == match:
+		return match
+	default:
+		return match + "/{id}"
+	}
+}
+
+// statusClass returns the status label of a single attempt, which is either
+// the class of the response status code (2xx, 4xx ...) or statusError.
+func statusClass(res *http.Response, err error) string {
+	if err != nil || res == nil {
+		return statusError
+	}
+
+	return strconv.Itoa(res.StatusCode/100) + "xx"
+}
+
+// observeRequest records a single attempt made to endpoint.
+func observeRequest(rctx Context, endpoint, method, status string, d time.Duration) {
+	requestTotal.WithLabelValues(string(rctx.Env.Mode), endpoint, method, status).Inc()
+	requestDuration.WithLabelValues(string(rctx.Env.Mode), endpoint, method, status).Observe(d.Seconds())
+}
+
+// observeCircuitOpen records a call that was rejected by the circuit breaker.
+func observeCircuitOpen(rctx Context, endpoint, method string) {
+	requestTotal.WithLabelValues(string(rctx.Env.Mode), endpoint, method, statusCircuitOpen).Inc()
+}
+
+// ObserveHotReload records the outcome of a hot reload of the Tyk Gateway.
+func ObserveHotReload(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	hotReloadTotal.WithLabelValues(result).Inc()
+}
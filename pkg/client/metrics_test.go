@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/tyk/apis", want: "/tyk/apis"},
+		{path: "/tyk/apis/", want: "/tyk/apis"},
+		{path: "/tyk/apis/ZGVmYXVsdC9odHRwYmlu", want: "/tyk/apis/{id}"},
+		{path: "/tyk/policies?p=-2", want: "/tyk/policies"},
+		{path: "/tyk/reload/group", want: "/tyk/reload/group"},
+		{path: "/api/apis/5e0fac4845bb46c77543be28", want: "/api/apis/{id}"},
+		{path: "/api/portal/catalogue/", want: "/api/portal/catalogue"},
+		{path: "/api/portal/documentation/5e0fac48", want: "/api/portal/documentation/{id}"},
+		{path: "/api/apisearch", want: endpointOther},
+		{path: "/unknown/5e0fac48", want: endpointOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := endpointTemplate(tt.path); got != tt.want {
+				t.Errorf("expected %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	if got := statusClass(nil, errors.New("connection refused")); got != statusError {
+		t.Errorf("expected %q got %q", statusError, got)
+	}
+
+	if got := statusClass(&http.Response{StatusCode: http.StatusNotFound}, nil); got != "4xx" {
+		t.Errorf("expected 4xx got %q", got)
+	}
+}
+
+func TestCallMetrics(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+
+	counter := requestTotal.WithLabelValues("ce", "/tyk/certs/{id}", http.MethodPost, "4xx")
+	before := testutil.ToFloat64(counter)
+
+	_, err := Call(testContext(svr.URL), http.MethodPost, "/tyk/certs/abc", nil)
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found got %v", err)
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("expected 1 request to be counted got %v", got)
+	}
+}
+
+func TestObserveHotReload(t *testing.T) {
+	failure := hotReloadTotal.WithLabelValues("failure")
+	before := testutil.ToFloat64(failure)
+
+	ObserveHotReload(errors.New("boom"))
+
+	if got := testutil.ToFloat64(failure) - before; got != 1 {
+		t.Errorf("expected 1 failed hot reload got %v", got)
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrOutOfSync is returned by calls applied to several gateways when some of
+// them miss the resource or serve a different version of it.
+var ErrOutOfSync = errors.New("Resource is out of sync between gateways")
+
+// IsOutOfSync returns true if err is ErrOutOfSync
+func IsOutOfSync(err error) bool {
+	return errors.Is(err, ErrOutOfSync)
+}
+
+// NodesError is returned by calls applied to several gateways when some of them
+// failed.
+type NodesError struct {
+	// Total is the number of gateways the call was applied to.
+	Total int
+
+	// Errors maps the URL of the gateways that failed to their error.
+	Errors map[string]error
+}
+
+func (e *NodesError) Error() string {
+	nodes := e.Nodes()
+	msgs := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		msgs = append(msgs, node+": "+e.Errors[node].Error())
+	}
+
+	return fmt.Sprintf("%d of %d gateways failed: %s", len(nodes), e.Total, strings.Join(msgs, "; "))
+}
+
+// Nodes returns the sorted URLs of the gateways that failed.
+func (e *NodesError) Nodes() []string {
+	nodes := make([]string, 0, len(e.Errors))
+	for node := range e.Errors {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	return nodes
+}
+
+func (e *NodesError) Unwrap() []error {
+	o := make([]error, 0, len(e.Errors))
+	for _, node := range e.Nodes() {
+		o = append(o, e.Errors[node])
+	}
+
+	return o
+}
+
+// OnNode returns a copy of ctx whose calls are sent to the gateway at url.
+func OnNode(ctx context.Context, url string) context.Context {
+	rctx := GetContext(ctx)
+	rctx.Env.URL = url
+	rctx.Nodes = nil
+
+	return SetContext(ctx, rctx)
+}
+
+// EachNode calls fn once for every gateway node of ctx, with a context whose
+// calls are sent to that node. fn is called with ctx itself if it has no nodes.
+//
+// Failures are returned as a *NodesError once fn was called for every node.
+// Otherwise ErrOutOfSync is returned if some nodes miss the resource fn works
+// on, so that callers create it again on them, and ErrNotFound if every node
+// misses it.
+func EachNode(ctx context.Context, fn func(context.Context) error) error {
+	return eachNode(ctx, fn, false)
+}
+
+// DeleteEachNode calls fn like EachNode to delete a resource from every gateway
+// node. Nodes already missing the resource are not failures, ErrNotFound is
+// only returned if every node misses it.
+func DeleteEachNode(ctx context.Context, fn func(context.Context) error) error {
+	return eachNode(ctx, fn, true)
+}
+
+func eachNode(ctx context.Context, fn func(context.Context) error, deleting bool) error {
+	nodes := GetContext(ctx).Nodes
+	if len(nodes) == 0 {
+		return fn(ctx)
+	}
+
+	failed := make(map[string]error)
+
+	var notFound error
+
+	missing := 0
+
+	for _, node := range nodes {
+		err := fn(OnNode(ctx, node))
+
+		switch {
+		case err == nil:
+		case IsNotFound(err):
+			notFound = err
+			missing++
+		default:
+			failed[node] = err
+		}
+	}
+
+	switch {
+	case len(failed) != 0:
+		return &NodesError{Total: len(nodes), Errors: failed}
+	case missing == len(nodes):
+		return notFound
+	case missing != 0 && !deleting:
+		return ErrOutOfSync
+	}
+
+	return nil
+}
+
+// ReadNodes reads a resource with read from every gateway node of ctx, and
+// returns it if they all serve the same version of it. It returns
+// ErrOutOfSync if some nodes miss the resource or serve a different version
+// of it, and ErrNotFound if every node misses it. read is called once with ctx
+// if it has no nodes.
+func ReadNodes[T any](ctx context.Context, read func(context.Context) (T, error)) (T, error) {
+	nodes := GetContext(ctx).Nodes
+	if len(nodes) == 0 {
+		return read(ctx)
+	}
+
+	var o T
+
+	served := 0
+	diverged := false
+
+	err := EachNode(ctx, func(ctx context.Context) error {
+		v, err := read(ctx)
+		if err != nil {
+			return err
+		}
+
+		if served == 0 {
+			o = v
+		} else if !reflect.DeepEqual(o, v) {
+			diverged = true
+		}
+
+		served++
+
+		return nil
+	})
+
+	var zero T
+
+	switch {
+	case err != nil:
+		return zero, err
+	case diverged || served != len(nodes):
+		return zero, ErrOutOfSync
+	}
+
+	return o, nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadNodes(t *testing.T) {
+	serving := func(status int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(body)) //nolint:errcheck
+		}))
+	}
+
+	a := serving(http.StatusOK, `{"api_id":"a"}`)
+	defer a.Close()
+
+	b := serving(http.StatusOK, `{"api_id":"b"}`)
+	defer b.Close()
+
+	missing := serving(http.StatusNotFound, `{"status":"error","message":"API not found"}`)
+	defer missing.Close()
+
+	failing := serving(http.StatusForbidden, `{"status":"error","message":"Forbidden"}`)
+	defer failing.Close()
+
+	read := func(ctx context.Context) (map[string]string, error) {
+		var o map[string]string
+		err := Data(&o)(Get(ctx, "/tyk/apis/a", nil))
+
+		return o, err
+	}
+
+	withNodes := func(nodes ...string) context.Context {
+		rctx := GetContext(testContext(a.URL))
+		rctx.Nodes = nodes
+
+		return SetContext(context.Background(), rctx)
+	}
+
+	o, err := ReadNodes(withNodes(a.URL, a.URL), read)
+	if err != nil || o["api_id"] != "a" {
+		t.Errorf("expected the API served by every node got %v %v", o, err)
+	}
+
+	if _, err := ReadNodes(withNodes(a.URL, b.URL), read); !IsOutOfSync(err) {
+		t.Errorf("expected nodes serving different APIs to be out of sync got %v", err)
+	}
+
+	if _, err := ReadNodes(withNodes(a.URL, missing.URL), read); !IsOutOfSync(err) {
+		t.Errorf("expected nodes missing the API to be out of sync got %v", err)
+	}
+
+	if _, err := ReadNodes(withNodes(missing.URL, missing.URL), read); !IsNotFound(err) {
+		t.Errorf("expected the API to be missing got %v", err)
+	}
+
+	_, err = ReadNodes(withNodes(a.URL, failing.URL), read)
+
+	var e *NodesError
+	if !errors.As(err, &e) || e.Total != 2 || len(e.Errors) != 1 || e.Errors[failing.URL] == nil {
+		t.Fatalf("expected the failing node to be reported got %v", err)
+	}
+
+	if !IsUnauthorized(err) {
+		t.Errorf("expected the error of the node to be unwrapped got %v", err)
+	}
+}
+
+func TestEachNodeMissing(t *testing.T) {
+	serving := func(status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"status":"ok"}`)) //nolint:errcheck
+		}))
+	}
+
+	ok := serving(http.StatusOK)
+	defer ok.Close()
+
+	missing := serving(http.StatusNotFound)
+	defer missing.Close()
+
+	call := func(ctx context.Context) error {
+		_, err := Result(PutJSON(ctx, "/tyk/policies/a", nil))
+		return err
+	}
+
+	withNodes := func(nodes ...string) context.Context {
+		rctx := GetContext(testContext(ok.URL))
+		rctx.Nodes = nodes
+
+		return SetContext(context.Background(), rctx)
+	}
+
+	if err := EachNode(withNodes(ok.URL, missing.URL), call); !IsOutOfSync(err) {
+		t.Errorf("expected a node missing the resource to be out of sync got %v", err)
+	}
+
+	if err := EachNode(withNodes(missing.URL, missing.URL), call); !IsNotFound(err) {
+		t.Errorf("expected the resource to be missing got %v", err)
+	}
+
+	if err := DeleteEachNode(withNodes(ok.URL, missing.URL), call); err != nil {
+		t.Errorf("expected deleting from a node missing the resource to succeed got %v", err)
+	}
+
+	if err := DeleteEachNode(withNodes(missing.URL, missing.URL), call); !IsNotFound(err) {
+		t.Errorf("expected the resource to be missing got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryPolicy controls how Call retries idempotent requests that failed with a
+// transient error.
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts made after the first one. Zero
+	// disables retries.
+	MaxRetries int
+
+	// InitialInterval is the wait before the first retry. Subsequent waits grow
+	// exponentially, with jitter, up to MaxInterval.
+	InitialInterval time.Duration
+
+	// MaxInterval caps the wait between two attempts.
+	MaxInterval time.Duration
+}
+
+// DefaultRetryPolicy is the policy used by Call.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:      3,
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+}
+
+func (p RetryPolicy) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	return backoff.WithMaxRetries(b, uint64(p.MaxRetries))
+}
+
+// isIdempotent returns true if requests with the given method can safely be
+// sent more than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransient returns true if res/err is a failure that is likely to go away by
+// itself, for instance a connection reset, a timeout or a 5xx from Tyk.
+func isTransient(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// shouldRetry returns true if the attempt that produced res/err is worth
+// repeating.
+func shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if isTransient(res, err) {
+		return true
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests
+}
+
+// retryAfter returns the wait requested by the Retry-After header of res, or 0
+// if there is none. Both delay-seconds and HTTP-date forms are supported.
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// discard drains and closes the body of a response that is not returned to the
+// caller, so that the underlying connection can be reused.
+func discard(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	io.Copy(io.Discard, res.Body) //nolint:errcheck
+	res.Body.Close()
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	"github.com/TykTechnologies/tyk-operator/pkg/environment"
+	"github.com/go-logr/logr"
+)
+
+func testContext(url string) context.Context {
+	return SetContext(context.Background(), Context{
+		Env: environment.Env{
+			Environment: v1alpha1.Environment{Mode: "ce", URL: url},
+		},
+		Log: logr.Discard(),
+	})
+}
+
+func fastRetries(t *testing.T) {
+	t.Helper()
+
+	policy := DefaultRetryPolicy
+	DefaultRetryPolicy = RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	}
+
+	t.Cleanup(func() { DefaultRetryPolicy = policy })
+}
+
+func TestCallRetry(t *testing.T) {
+	fastRetries(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		failures int32
+		status   int
+		calls    int32
+	}{
+		{name: "GET recovers from 503", method: http.MethodGet, failures: 2, status: http.StatusOK, calls: 3},
+		{name: "PUT recovers from 503", method: http.MethodPut, failures: 1, status: http.StatusOK, calls: 2},
+		{name: "GET gives up", method: http.MethodGet, failures: 10, status: http.StatusServiceUnavailable, calls: 4},
+		{name: "POST is not retried", method: http.MethodPost, failures: 1, status: http.StatusServiceUnavailable, calls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					b, err := io.ReadAll(r.Body)
+					if err != nil || string(b) != "payload" {
+						t.Errorf("expected body to be replayed, got %q", b)
+					}
+				}
+
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer svr.Close()
+
+			res, err := Call(testContext(svr.URL), tt.method, "/tyk/apis", strings.NewReader("payload"))
+
+			if tt.status == http.StatusOK {
+				if err != nil {
+					t.Fatalf("expected no error got %v", err)
+				}
+
+				res.Body.Close()
+			} else if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if got := atomic.LoadInt32(&calls); got != tt.calls {
+				t.Errorf("expected %d calls got %d", tt.calls, got)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "missing", header: "", want: 0},
+		{name: "seconds", header: "7", want: 7 * time.Second},
+		{name: "invalid", header: "soon", want: 0},
+		{name: "date in the past", header: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				res.Header.Set("Retry-After", tt.header)
+			}
+
+			if got := retryAfter(res); got != tt.want {
+				t.Errorf("expected %v got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	fastRetries(t)
+
+	var calls int32
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svr.Close()
+
+	ctx := testContext(svr.URL)
+
+	for i := 0; i < DefaultBreakerPolicy.FailureThreshold; i++ {
+		_, err := Call(ctx, http.MethodPost, "/tyk/apis", nil)
+		if err == nil || IsCircuitOpen(err) {
+			t.Fatalf("call %d: expected a failed call got %v", i, err)
+		}
+	}
+
+	_, err := Call(ctx, http.MethodGet, "/tyk/apis", nil)
+	if !IsCircuitOpen(err) {
+		t.Fatalf("expected circuit to be open got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != int32(DefaultBreakerPolicy.FailureThreshold) {
+		t.Errorf("expected %d calls to reach Tyk got %d", DefaultBreakerPolicy.FailureThreshold, got)
+	}
+
+	// Once the open timeout elapsed, a successful probe closes the circuit.
+	b := breakerFor(svr.URL)
+	b.mu.Lock()
+	b.until = time.Now()
+	b.mu.Unlock()
+
+	b.allow() //nolint:errcheck
+	b.done(false)
+
+	if err := b.allow(); err != nil {
+		t.Errorf("expected circuit to be closed got %v", err)
+	}
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	fastRetries(t)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svr.Close()
+
+	b := breakerFor(svr.URL)
+	b.mu.Lock()
+	b.failures = DefaultBreakerPolicy.FailureThreshold
+	b.mu.Unlock()
+
+	// The probe is cancelled while it backs off between retries.
+	ctx, cancel := context.WithCancel(testContext(svr.URL))
+	cancel()
+
+	_, err := Call(ctx, http.MethodGet, "/tyk/apis", nil)
+	if err == nil || IsCircuitOpen(err) {
+		t.Fatalf("expected the probe to fail got %v", err)
+	}
+
+	b.mu.Lock()
+	failures, probing := b.failures, b.probing
+	b.mu.Unlock()
+
+	if failures != DefaultBreakerPolicy.FailureThreshold || probing {
+		t.Errorf("expected the probe to be released without closing the circuit, got %d failures", failures)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrInvalidCABundle is returned when the CA bundle of a TransportConfig holds
+// no PEM encoded certificate.
+var ErrInvalidCABundle = errors.New("CA bundle has no valid PEM certificate")
+
+// TransportConfig describes how to connect to a Tyk Gateway or Dashboard.
+type TransportConfig struct {
+	// CA is a PEM encoded bundle used instead of the system roots to verify the
+	// certificate presented by Tyk.
+	CA []byte
+
+	// Cert and Key are the PEM encoded client certificate and private key
+	// presented to Tyk for mutual TLS.
+	Cert []byte
+	Key  []byte
+
+	InsecureSkipVerify bool
+
+	// ProxyURL is the url of the HTTP proxy used to reach Tyk. When empty the
+	// proxy is taken from the environment.
+	ProxyURL string
+
+	// Timeout is the time limit of a single request. Zero means no timeout.
+	Timeout time.Duration
+
+	// DialTimeout and TLSHandshakeTimeout default to 30s and 10s.
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+}
+
+func (c TransportConfig) equal(o TransportConfig) bool {
+	return bytes.Equal(c.CA, o.CA) &&
+		bytes.Equal(c.Cert, o.Cert) &&
+		bytes.Equal(c.Key, o.Key) &&
+		c.InsecureSkipVerify == o.InsecureSkipVerify &&
+		c.ProxyURL == o.ProxyURL &&
+		c.Timeout == o.Timeout &&
+		c.DialTimeout == o.DialTimeout &&
+		c.TLSHandshakeTimeout == o.TLSHandshakeTimeout
+}
+
+// NewHTTPClient returns a *http.Client configured according to c.
+func NewHTTPClient(c TransportConfig) (*http.Client, error) {
+	dial := c.DialTimeout
+	if dial == 0 {
+		dial = 30 * time.Second
+	}
+
+	handshake := c.TLSHandshakeTimeout
+	if handshake == 0 {
+		handshake = 10 * time.Second
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if len(c.CA) != 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.CA) {
+			return nil, ErrInvalidCABundle
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(c.Cert) != 0 || len(c.Key) != 0 {
+		cert, err := tls.X509KeyPair(c.Cert, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	proxy := http.ProxyFromEnvironment
+
+	if c.ProxyURL != "" {
+		u, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		proxy = http.ProxyURL(u)
+	}
+
+	return &http.Client{
+		Timeout: c.Timeout,
+		Transport: &http.Transport{
+			Proxy: proxy,
+			DialContext: (&net.Dialer{
+				Timeout:   dial,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   handshake,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig:       tlsConfig,
+		},
+	}, nil
+}
+
+type cachedClient struct {
+	config TransportConfig
+	client *http.Client
+}
+
+var httpClients = struct {
+	sync.Mutex
+	m map[string]cachedClient
+}{m: make(map[string]cachedClient)}
+
+// HTTPClient returns the cached *http.Client of the OperatorContext identified
+// by key. The client is rebuilt when c differs from the configuration it was
+// built with, for instance after the CA or client certificate secret changed.
+func HTTPClient(key string, c TransportConfig) (*http.Client, error) {
+	httpClients.Lock()
+	defer httpClients.Unlock()
+
+	cached, ok := httpClients.m[key]
+	if ok && cached.config.equal(c) {
+		return cached.client, nil
+	}
+
+	hc, err := NewHTTPClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		cached.client.CloseIdleConnections()
+	}
+
+	httpClients.m[key] = cachedClient{config: c, client: hc}
+
+	return hc, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewHTTPClientCA(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+
+	tests := []struct {
+		name   string
+		config TransportConfig
+		ok     bool
+	}{
+		{name: "system roots", config: TransportConfig{}, ok: false},
+		{name: "custom CA", config: TransportConfig{CA: ca}, ok: true},
+		{name: "insecure", config: TransportConfig{InsecureSkipVerify: true}, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc, err := NewHTTPClient(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := hc.Get(svr.URL)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("expected no error got %v", err)
+				}
+
+				res.Body.Close()
+			} else if err == nil {
+				res.Body.Close()
+				t.Fatal("expected certificate verification to fail")
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientInvalid(t *testing.T) {
+	if _, err := NewHTTPClient(TransportConfig{CA: []byte("garbage")}); err != ErrInvalidCABundle {
+		t.Errorf("expected %v got %v", ErrInvalidCABundle, err)
+	}
+
+	if _, err := NewHTTPClient(TransportConfig{Cert: []byte("garbage")}); err == nil {
+		t.Error("expected invalid client certificate to fail")
+	}
+}
+
+func TestHTTPClientCache(t *testing.T) {
+	a, err := HTTPClient("default/ctx", TransportConfig{ProxyURL: "http://proxy:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := HTTPClient("default/ctx", TransportConfig{ProxyURL: "http://proxy:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != b {
+		t.Error("expected the client to be reused")
+	}
+
+	c, err := HTTPClient("default/ctx", TransportConfig{ProxyURL: "http://other:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a == c {
+		t.Error("expected the client to be rebuilt after the configuration changed")
+	}
+}
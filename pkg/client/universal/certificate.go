@@ -1,9 +1,16 @@
 package universal
 
-import "context"
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
 
 type Certificate interface {
 	All(ctx context.Context) ([]string, error)
+	// Metadata returns the subject, issuer, DNS names, validity and
+	// fingerprint of all certificates.
+	Metadata(ctx context.Context) ([]model.CertificateMeta, error)
 	Upload(ctx context.Context, key, crt []byte) (id string, err error)
 	Delete(ctx context.Context, id string) error
 	// Exists returns true if a certificate with id exists
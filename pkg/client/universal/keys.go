@@ -0,0 +1,21 @@
+package universal
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// Keys manages Tyk keys. A key is identified by its value, the secret presented
+// by API consumers.
+type Keys interface {
+	// Create creates key and returns its generated value, along with the hash
+	// Tyk stores it under when keys are hashed.
+	Create(ctx context.Context, key *model.SessionState) (id, hash string, err error)
+	Get(ctx context.Context, id string) (*model.SessionState, error)
+	Update(ctx context.Context, id string, key *model.SessionState) error
+	Delete(ctx context.Context, id string) error
+
+	// DeleteHashed deletes the key stored under hash.
+	DeleteHashed(ctx context.Context, hash string) error
+}
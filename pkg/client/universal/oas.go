@@ -0,0 +1,16 @@
+package universal
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// OAS manages Tyk OAS API definitions. They are identified by info.id of their
+// x-tyk-api-gateway extension.
+type OAS interface {
+	Create(ctx context.Context, def model.TykOAS) (*model.Result, error)
+	Get(ctx context.Context, id string) (model.TykOAS, error)
+	Update(ctx context.Context, def model.TykOAS) (*model.Result, error)
+	Delete(ctx context.Context, id string) (*model.Result, error)
+}
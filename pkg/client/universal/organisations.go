@@ -0,0 +1,23 @@
+package universal
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// Organisations manages the organisations of the dashboard through its admin
+// API. Calls are authenticated with the AdminAuth of the client context.
+type Organisations interface {
+	// Create creates org and returns its id.
+	Create(ctx context.Context, org *model.Organisation) (string, error)
+	Get(ctx context.Context, id string) (*model.Organisation, error)
+	// Update updates the organisation org.ID.
+	Update(ctx context.Context, org *model.Organisation) error
+	Delete(ctx context.Context, id string) error
+	// CreateUser creates user in the organisation user.OrgID. The returned user
+	// holds its id and access key.
+	CreateUser(ctx context.Context, user *model.DashboardUser) (*model.DashboardUser, error)
+	// GetUser returns the user id, along with its access key.
+	GetUser(ctx context.Context, id string) (*model.DashboardUser, error)
+}
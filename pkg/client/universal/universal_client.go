@@ -3,8 +3,21 @@ package universal
 import "context"
 
 type Client interface {
+	// HotReload asks the gateways to reload their configuration. When hot
+	// reloads are batched, it returns as soon as the request joined a batch.
 	HotReload(context.Context) error
+	// HotReloadResult returns the error of the latest batched hot reload
+	// requested for the resource of the context, without waiting for it. It
+	// returns ErrHotReloadPending while the reload is not done, and nil once
+	// its result was read.
+	HotReloadResult(context.Context) error
 	Api() Api
+	OAS() OAS
+	Keys() Keys
+	Users() Users
+	UserGroups() UserGroups
+	Organisations() Organisations
+	Webhooks() Webhooks
 	Portal() Portal
 	Certificate() Certificate
 }
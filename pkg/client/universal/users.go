@@ -0,0 +1,29 @@
+package universal
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// Users manages the users of the dashboard.
+type Users interface {
+	// Create creates user and returns its id.
+	Create(ctx context.Context, user *model.DashboardUser) (string, error)
+	Get(ctx context.Context, id string) (*model.DashboardUser, error)
+	// Update updates the user user.ID. The password is left unchanged.
+	Update(ctx context.Context, user *model.DashboardUser) error
+	Delete(ctx context.Context, id string) error
+	// SetPassword sets the password of the user id.
+	SetPassword(ctx context.Context, id, password string) error
+}
+
+// UserGroups manages the user groups of the dashboard.
+type UserGroups interface {
+	// Create creates group and returns its id.
+	Create(ctx context.Context, group *model.UserGroup) (string, error)
+	Get(ctx context.Context, id string) (*model.UserGroup, error)
+	// Update updates the group group.ID.
+	Update(ctx context.Context, group *model.UserGroup) error
+	Delete(ctx context.Context, id string) error
+}
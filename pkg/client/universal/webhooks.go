@@ -0,0 +1,18 @@
+package universal
+
+import (
+	"context"
+
+	"github.com/TykTechnologies/tyk-operator/api/model"
+)
+
+// Webhooks manages the webhooks of the dashboard, referenced by id from the
+// event handlers of the APIs.
+type Webhooks interface {
+	// Create creates hook and returns its id.
+	Create(ctx context.Context, hook *model.WebHookHandlerConf) (string, error)
+	Get(ctx context.Context, id string) (*model.WebHookHandlerConf, error)
+	// Update updates the webhook hook.ID.
+	Update(ctx context.Context, hook *model.WebHookHandlerConf) error
+	Delete(ctx context.Context, id string) error
+}
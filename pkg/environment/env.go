@@ -4,8 +4,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/TykTechnologies/tyk-operator/api/model"
 	"github.com/TykTechnologies/tyk-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Env holds values needed to talk to the gateway or the dashboard API
@@ -14,6 +17,39 @@ type Env struct {
 	Namespace    string
 	IngressClass string
 	TykVersion   string
+
+	// Paused is true when the OperatorContext of the reconciled resource is
+	// paused.
+	Paused bool
+
+	// DryRun is true when the changes to Tyk are recorded instead of being
+	// sent, see package dryrun. It applies to the whole operator, whatever the
+	// OperatorContext.
+	DryRun bool
+
+	// ShardSelector is the label selector of the resources managed by this
+	// shard of the operator, empty for all of them.
+	ShardSelector string
+
+	// OperatorID identifies this install of the operator. The APIs and policies
+	// it creates are stamped with it, and only those are garbage collected.
+	OperatorID string
+}
+
+// Watches returns true if the operator watches the resources of namespace.
+// Namespace is a comma separated list of namespaces, empty for all of them.
+func (e Env) Watches(namespace string) bool {
+	if e.Namespace == "" {
+		return true
+	}
+
+	for _, ns := range strings.Split(e.Namespace, ",") {
+		if strings.TrimSpace(ns) == namespace {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (e Env) Merge(n Env) Env {
@@ -57,6 +93,50 @@ func (e Env) Merge(n Env) Env {
 		e.UserGroupOwners = append(e.UserGroupOwners, n.UserGroupOwners...)
 	}
 
+	if n.InsecureSkipVerify {
+		e.InsecureSkipVerify = n.InsecureSkipVerify
+	}
+
+	if n.TLS != nil {
+		e.TLS = n.TLS
+	}
+
+	if n.ProxyURL != "" {
+		e.ProxyURL = n.ProxyURL
+	}
+
+	if n.Timeouts != nil {
+		e.Timeouts = n.Timeouts
+	}
+
+	if n.HotReloadWindow != nil {
+		e.HotReloadWindow = n.HotReloadWindow
+	}
+
+	if n.AdminSecretRef != nil {
+		e.AdminSecretRef = n.AdminSecretRef
+	}
+
+	if n.ReloadVerification != nil {
+		e.ReloadVerification = n.ReloadVerification
+	}
+
+	if n.CertificateExpiryWarnings != nil {
+		e.CertificateExpiryWarnings = n.CertificateExpiryWarnings
+	}
+
+	if n.Gateways != nil {
+		e.Gateways = n.Gateways
+	}
+
+	if n.ResyncInterval != nil {
+		e.ResyncInterval = n.ResyncInterval
+	}
+
+	if n.DriftPolicy != "" {
+		e.DriftPolicy = n.DriftPolicy
+	}
+
 	return e
 }
 
@@ -71,6 +151,9 @@ func (e *Env) Parse() {
 	e.Ingress.HTTPSPort, _ = strconv.Atoi(os.Getenv(v1alpha1.IngressTLSPort))
 	e.Ingress.HTTPPort, _ = strconv.Atoi(os.Getenv(v1alpha1.IngressHTTPPort))
 	e.IngressClass = os.Getenv(v1alpha1.IngressClass)
+	e.DryRun, _ = strconv.ParseBool(os.Getenv(v1alpha1.TykDryRun))
+	e.ShardSelector = strings.TrimSpace(os.Getenv(v1alpha1.TykShardSelector))
+	e.OperatorID = strings.TrimSpace(os.Getenv(v1alpha1.TykOperatorID))
 
 	for _, user := range strings.Split(os.Getenv(v1alpha1.TykUserOwners), ",") {
 		if o := strings.TrimSpace(user); o != "" {
@@ -87,4 +170,104 @@ func (e *Env) Parse() {
 	if e.Ingress.HTTPSPort == 0 {
 		e.Ingress.HTTPSPort = 8443
 	}
+
+	e.ProxyURL = strings.TrimSpace(os.Getenv(v1alpha1.TykProxyURL))
+
+	ca := objectRef(os.Getenv(v1alpha1.TykCASecret))
+	cert := objectRef(os.Getenv(v1alpha1.TykClientCertSecret))
+
+	if ca != nil || cert != nil {
+		e.TLS = &v1alpha1.TLS{CASecretRef: ca, ClientCertSecretRef: cert}
+	}
+
+	request := duration(os.Getenv(v1alpha1.TykRequestTimeout))
+	dial := duration(os.Getenv(v1alpha1.TykDialTimeout))
+	handshake := duration(os.Getenv(v1alpha1.TykTLSHandshakeTimeout))
+
+	if request != nil || dial != nil || handshake != nil {
+		e.Timeouts = &v1alpha1.Timeouts{Request: request, Dial: dial, TLSHandshake: handshake}
+	}
+
+	e.HotReloadWindow = duration(os.Getenv(v1alpha1.TykHotReloadWindow))
+	e.AdminSecretRef = objectRef(os.Getenv(v1alpha1.TykAdminSecret))
+
+	if svc := objectRef(os.Getenv(v1alpha1.TykReloadVerificationService)); svc != nil {
+		port, _ := strconv.ParseInt(os.Getenv(v1alpha1.TykReloadVerificationPort), 10, 32)
+
+		e.ReloadVerification = &v1alpha1.ReloadVerification{
+			Service: *svc,
+			Port:    int32(port),
+			Timeout: duration(os.Getenv(v1alpha1.TykReloadVerificationTimeout)),
+		}
+	}
+
+	e.CertificateExpiryWarnings = durations(os.Getenv(v1alpha1.TykCertificateExpiryWarnings))
+	e.Gateways = gateways()
+	e.ResyncInterval = duration(os.Getenv(v1alpha1.TykResyncInterval))
+	e.DriftPolicy = v1alpha1.DriftPolicy(strings.TrimSpace(os.Getenv(v1alpha1.TykDriftPolicy)))
+}
+
+// gateways returns the gateways configured with TYK_GATEWAY_* env vars, nil if
+// there are none.
+func gateways() *v1alpha1.Gateways {
+	var g v1alpha1.Gateways
+
+	for _, u := range strings.Split(os.Getenv(v1alpha1.TykGatewayURLs), ",") {
+		if o := strings.TrimSpace(u); o != "" {
+			g.URLs = append(g.URLs, o)
+		}
+	}
+
+	if s := strings.TrimSpace(os.Getenv(v1alpha1.TykGatewaySelector)); s != "" {
+		g.Selector, _ = metav1.ParseToLabelSelector(s)
+	}
+
+	if g.URLs == nil && g.Selector == nil {
+		return nil
+	}
+
+	port, _ := strconv.ParseInt(os.Getenv(v1alpha1.TykGatewayPort), 10, 32)
+
+	g.Namespace = strings.TrimSpace(os.Getenv(v1alpha1.TykGatewayNamespace))
+	g.Port = int32(port)
+
+	return &g
+}
+
+// durations parses a comma separated list of durations, skipping invalid ones.
+func durations(v string) []metav1.Duration {
+	var o []metav1.Duration
+
+	for _, s := range strings.Split(v, ",") {
+		if d := duration(s); d != nil {
+			o = append(o, *d)
+		}
+	}
+
+	return o
+}
+
+// objectRef parses a namespace/name reference to an object. It returns nil if v
+// is not in that form.
+func objectRef(v string) *model.Target {
+	var t model.Target
+
+	t.Parse(strings.TrimSpace(v))
+
+	if t.Name == "" {
+		return nil
+	}
+
+	return &t
+}
+
+// duration parses v as a time.Duration. It returns nil if v is empty or
+// invalid.
+func duration(v string) *metav1.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: d}
 }
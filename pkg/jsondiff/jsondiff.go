@@ -0,0 +1,111 @@
+// Package jsondiff compares the JSON encoding of the objects sent to Tyk with
+// the objects served by Tyk.
+package jsondiff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change is a field whose value differs between the desired object and the
+// current one. A nil value is a field missing from its object.
+type Change struct {
+	// Path is the dot separated JSON path of the field, empty for a whole
+	// object.
+	Path    string      `json:"path"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+// Diff returns the changes, sorted by path, of the fields of desired whose
+// value differs in current. Fields only set in current, such as those filled by
+// Tyk, are ignored, unless desired is nil: all the fields of current are then
+// changed to nil.
+func Diff(desired, current interface{}) ([]Change, error) {
+	d, err := value(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := value(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var o []Change
+
+	if d == nil {
+		removed("", c, &o)
+	} else {
+		diff("", d, c, &o)
+	}
+
+	sort.Slice(o, func(i, j int) bool { return o[i].Path < o[j].Path })
+
+	return o, nil
+}
+
+// Paths returns the paths of changes.
+func Paths(changes []Change) []string {
+	o := make([]string, 0, len(changes))
+	for _, c := range changes {
+		o = append(o, c.Path)
+	}
+
+	return o
+}
+
+func value(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var o interface{}
+
+	return o, json.Unmarshal(b, &o)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// diff appends to o the changes under path of the leaves of desired whose value
+// differs in current.
+func diff(path string, desired, current interface{}, o *[]Change) {
+	d, ok := desired.(map[string]interface{})
+	if !ok {
+		if !reflect.DeepEqual(desired, current) {
+			*o = append(*o, Change{Path: path, Current: current, Desired: desired})
+		}
+
+		return
+	}
+
+	c, _ := current.(map[string]interface{}) //nolint:errcheck
+
+	for k, v := range d {
+		diff(join(path, k), v, c[k], o)
+	}
+}
+
+// removed appends to o the changes removing the leaves under path of current.
+func removed(path string, current interface{}, o *[]Change) {
+	c, ok := current.(map[string]interface{})
+	if !ok {
+		if current != nil {
+			*o = append(*o, Change{Path: path, Current: current})
+		}
+
+		return
+	}
+
+	for k, v := range c {
+		removed(join(path, k), v, o)
+	}
+}
@@ -0,0 +1,39 @@
+package jsondiff
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDiff(t *testing.T) {
+	is := is.New(t)
+
+	current := map[string]interface{}{
+		"name":  "httpbin",
+		"proxy": map[string]interface{}{"listen_path": "/httpbin", "target_url": "http://httpbin"},
+		"id":    "set-by-tyk",
+	}
+
+	desired := map[string]interface{}{
+		"name":  "httpbin",
+		"proxy": map[string]interface{}{"listen_path": "/v2", "target_url": "http://httpbin"},
+		"tags":  []string{"edge"},
+	}
+
+	changes, err := Diff(desired, current)
+	is.NoErr(err)
+	is.Equal(changes, []Change{
+		{Path: "proxy.listen_path", Current: "/httpbin", Desired: "/v2"},
+		{Path: "tags", Desired: []interface{}{"edge"}},
+	})
+
+	// All the fields of a deleted object are removed.
+	changes, err = Diff(nil, current)
+	is.NoErr(err)
+	is.Equal(Paths(changes), []string{"id", "name", "proxy.listen_path", "proxy.target_url"})
+
+	changes, err = Diff(current, current)
+	is.NoErr(err)
+	is.Equal(len(changes), 0)
+}
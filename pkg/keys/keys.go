@@ -10,6 +10,12 @@ const (
 	OperatorContextFinalizerName      = "finalizers.tyk.io/operatorcontext"
 	SubGraphFinalizerName             = "finalizers.tyk.io/subgraph"
 	SuperGraphFinalizerName           = "finalizers.tyk.io/supergraph"
+	TykOasApiDefinitionFinalizerName  = "finalizers.tyk.io/tykoasapidefinition"
+	ApiKeyFinalizerName               = "finalizers.tyk.io/apikey"
+	DashboardUserFinalizerName        = "finalizers.tyk.io/dashboarduser"
+	DashboardUserGroupFinalizerName   = "finalizers.tyk.io/dashboardusergroup"
+	TykOrganisationFinalizerName      = "finalizers.tyk.io/tykorganisation"
+	ApiEventWebhookFinalizerName      = "finalizers.tyk.io/apieventwebhook"
 )
 
 // Ingress
@@ -22,3 +28,29 @@ const (
 	IngressTemplateAnnotation          = "tyk.io/template"
 	DefaultIngressClassAnnotationValue = "tyk"
 )
+
+// OperatorIDKey is the key of the config_data of APIs, and of the meta_data of
+// policies, holding the identity of the operator that created them.
+const OperatorIDKey = "tyk-operator-id"
+
+// ShardLabel is the label the shards of the operator usually select resources
+// with. The resources created by the operator for a resource get its value.
+const ShardLabel = "tyk.io/shard"
+
+// Annotations
+const (
+	// AdoptIDAnnotation holds the id of an existing object on Tyk a resource
+	// takes over instead of creating a new one.
+	AdoptIDAnnotation = "tyk.io/adopt-id"
+
+	// DeletionPolicyAnnotation set to DeletionPolicyRetain keeps the object of
+	// a resource on Tyk when the resource is deleted.
+	DeletionPolicyAnnotation = "tyk.io/deletion-policy"
+	DeletionPolicyRetain     = "Retain"
+	DeletionPolicyDelete     = "Delete"
+
+	// ReconcileAnnotation set to ReconcilePaused stops applying a resource to
+	// Tyk until the annotation is removed.
+	ReconcileAnnotation = "tyk.io/reconcile"
+	ReconcilePaused     = "paused"
+)
@@ -0,0 +1,153 @@
+// Package tracing exports OpenTelemetry traces of reconciliations and of the
+// calls made to Tyk. Tracing is disabled unless an OTLP endpoint is configured.
+package tracing
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// EnvEndpoint holds the host:port of the OTLP/HTTP collector traces are
+	// exported to. Tracing is disabled when it is empty.
+	EnvEndpoint = "TYK_TRACING_ENDPOINT"
+
+	// EnvInsecure disables TLS when talking to the collector if it is true.
+	EnvInsecure = "TYK_TRACING_INSECURE"
+
+	// EnvSampleRatio is the fraction of reconciliations that are traced, between
+	// 0 and 1. Defaults to 1.
+	EnvSampleRatio = "TYK_TRACING_SAMPLE_RATIO"
+)
+
+const (
+	instrumentationName = "github.com/TykTechnologies/tyk-operator"
+	serviceName         = "tyk-operator"
+)
+
+// Config of the exporter.
+type Config struct {
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+}
+
+// BindFlags registers the tracing flags in fs. Their defaults are read from
+// the environment.
+func (c *Config) BindFlags(fs *flag.FlagSet) {
+	insecure, _ := strconv.ParseBool(os.Getenv(EnvInsecure))
+
+	ratio, err := strconv.ParseFloat(os.Getenv(EnvSampleRatio), 64)
+	if err != nil {
+		ratio = 1
+	}
+
+	fs.StringVar(&c.Endpoint, "tracing-endpoint", os.Getenv(EnvEndpoint),
+		"The host:port of the OTLP/HTTP collector traces are exported to. Tracing is disabled when empty.")
+	fs.BoolVar(&c.Insecure, "tracing-insecure", insecure,
+		"Export traces over plain HTTP.")
+	fs.Float64Var(&c.SampleRatio, "tracing-sample-ratio", ratio,
+		"The fraction of reconciliations that are traced, between 0 and 1.")
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans and must be called
+// before exiting. Nothing is installed when c.Endpoint is empty.
+func Setup(ctx context.Context, c Config) (func(context.Context) error, error) {
+	if c.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Endpoint)}
+	if c.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name, child of the span in ctx if any.
+func Start(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClient starts a span for a call made to a remote service.
+func StartClient(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// Inject adds the trace context of ctx to h, so that the remote service can
+// continue the trace.
+func Inject(ctx context.Context, h http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Reconciler wraps r so that every reconciliation runs in a span named
+// <kind>.Reconcile.
+func Reconciler(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		ctx, span := Start(ctx, kind+".Reconcile",
+			attribute.String("k8s.namespace.name", req.Namespace),
+			attribute.String("k8s.object.name", req.Name),
+		)
+
+		res, err := r.Reconcile(ctx, req)
+
+		End(span, err)
+
+		return res, err
+	})
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	return recorder
+}
+
+func TestReconciler(t *testing.T) {
+	recorder := recordSpans(t)
+	boom := errors.New("boom")
+
+	var parent trace.SpanContext
+
+	r := Reconciler("ApiDefinition", reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		parent = trace.SpanContextFromContext(ctx)
+
+		_, span := Start(ctx, "ApiDefinition.update")
+		span.End()
+
+		return ctrl.Result{}, boom
+	}))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "httpbin"},
+	})
+	if err != boom {
+		t.Fatalf("expected the error to be returned got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans got %d", len(spans))
+	}
+
+	child, root := spans[0], spans[1]
+
+	if root.Name() != "ApiDefinition.Reconcile" || root.Status().Code != codes.Error {
+		t.Errorf("unexpected reconcile span %q with status %v", root.Name(), root.Status())
+	}
+
+	if child.Parent().SpanID() != parent.SpanID() || parent.SpanID() != root.SpanContext().SpanID() {
+		t.Error("expected helper span to be a child of the reconcile span")
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	provider := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if otel.GetTracerProvider() != provider {
+		t.Error("expected tracer provider to be left untouched when no endpoint is set")
+	}
+}